@@ -2,6 +2,8 @@ package routes
 
 import (
 	"go-project/internal/admin/handler"
+	"go-project/pkg/middleware"
+	"net/http"
 
 	"github.com/gorilla/mux"
 )
@@ -13,7 +15,9 @@ func RegisterAdminRoutes(router *mux.Router,
 	appointmentHandler *handler.AppointmentHandler,
 	testimonialHandler *handler.TestimonialHandler,
 	commentHandler *handler.CommentHandler,
-	webinarHandler *handler.WebinarHandler) {
+	webinarHandler *handler.WebinarHandler,
+	notificationHandler *handler.NotificationHandler,
+	webhookHandler *handler.WebhookHandler) {
 
 	// ROUTES ARTICLE ADMIN || CRUD ||
 	router.HandleFunc("/admin/articles", articleHandler.GetAllArticles).Methods("GET")
@@ -21,6 +25,7 @@ func RegisterAdminRoutes(router *mux.Router,
 	router.HandleFunc("/admin/article/update", articleHandler.UpdateArticle).Methods("PUT")
 	router.HandleFunc("/admin/article/delete", articleHandler.DeleteArticle).Methods("DELETE")
 	router.HandleFunc("/admin/article/view", articleHandler.GetArticleByID).Methods("GET")
+	router.Handle("/admin/article/{id:[0-9]+}/review", middleware.AdminOnly(http.HandlerFunc(articleHandler.ReviewArticle))).Methods("PUT")
 
 	// ROUTES VIDEO ADMIN || CRUD ||
 	router.HandleFunc("/admin/videos", videoHandler.GetAllVideos).Methods("GET")
@@ -28,12 +33,18 @@ func RegisterAdminRoutes(router *mux.Router,
 	router.HandleFunc("/admin/video/update", videoHandler.UpdateVideo).Methods("PUT")
 	router.HandleFunc("/admin/video/delete", videoHandler.DeleteVideo).Methods("DELETE")
 	router.HandleFunc("/admin/video/view", videoHandler.GetVideoByID).Methods("GET")
+	router.Handle("/admin/video/{id:[0-9]+}/review", middleware.AdminOnly(http.HandlerFunc(videoHandler.ReviewVideo))).Methods("PUT")
 
 	// ROUTES APPOINTMENT ADMIN || ASSGIN HOST || CREATE || UPDATE ||
 	router.HandleFunc("/admin/staff", appointmentHandler.GetStaffList).Methods("GET")
+	router.Handle("/admin/staff/{id:[0-9]+}/specialties", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.SetStaffSpecialties))).Methods("PUT")
 	router.HandleFunc("/admin/appointments", appointmentHandler.CreateAppointment).Methods("POST")
-	router.HandleFunc("/admin/appointments/{id}/assign-host", appointmentHandler.AssignHost).Methods("POST")
-	router.HandleFunc("/admin/appointments/{id}/update-status", appointmentHandler.UpdateStatus).Methods("PUT")
+	router.Handle("/admin/appointments/{id}/assign-host", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.AssignHost))).Methods("POST")
+	router.Handle("/admin/appointments/{id}/update-status", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.UpdateStatus))).Methods("PUT")
+	router.Handle("/admin/appointments/{id}/timeline", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.GetTimeline))).Methods("GET")
+	router.Handle("/admin/appointments/{id}/whatsapp-messages", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.GetWhatsAppMessages))).Methods("GET")
+	router.Handle("/admin/appointments/{id}/meeting-link", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.GenerateMeetingLink))).Methods("POST")
+	router.Handle("/admin/appointments/{id}/ics", middleware.AdminOnly(http.HandlerFunc(appointmentHandler.DownloadCalendar))).Methods("GET")
 
 	// ROUTES TESTIMONIALS ADMIN || CRUD ||
 	router.HandleFunc("/admin/testimonials", testimonialHandler.GetAllTestimonials).Methods("GET")
@@ -43,6 +54,7 @@ func RegisterAdminRoutes(router *mux.Router,
 	router.HandleFunc("/admin/testimonial/{id:[0-9]+}", testimonialHandler.DeleteTestimonial).Methods("DELETE")
 	router.HandleFunc("/admin/testimonial/{id:[0-9]+}/approve", testimonialHandler.ApproveTestimonial).Methods("PUT")
 	router.HandleFunc("/admin/testimonial/{id:[0-9]+}/reject", testimonialHandler.RejectTestimonial).Methods("PUT")
+	router.Handle("/admin/testimonials/bulk", middleware.AdminOnly(http.HandlerFunc(testimonialHandler.BulkModerateTestimonials))).Methods("POST")
 
 	// ROUTES COMMENT ADMIN || APPROVE || REJECT || REPLY||
 	router.HandleFunc("/admin/comments", commentHandler.GetAllComments).Methods("GET")
@@ -51,8 +63,46 @@ func RegisterAdminRoutes(router *mux.Router,
 	router.HandleFunc("/admin/comment/{id:[0-9]+}/reject", commentHandler.RejectComment).Methods("PUT")
 	router.HandleFunc("/admin/comment/{id:[0-9]+}/delete", commentHandler.DeleteComment).Methods("DELETE")
 	router.HandleFunc("/admin/comment/{id:[0-9]+}/reply", commentHandler.ReplyComment).Methods("POST")
+	router.Handle("/admin/comments/reported", middleware.AdminOnly(http.HandlerFunc(commentHandler.GetReportedComments))).Methods("GET")
+	router.Handle("/admin/comment/{id:[0-9]+}/reports/resolve", middleware.AdminOnly(http.HandlerFunc(commentHandler.ResolveReports))).Methods("PUT")
+	router.Handle("/admin/comments/bulk", middleware.AdminOnly(http.HandlerFunc(commentHandler.BulkModerateComments))).Methods("POST")
 
+	// ROUTES WEBINAR ADMIN || CRUD || STATUS || CANCEL || HOST || RECORDING || REGISTRATIONS || ATTENDANCE ||
 	router.HandleFunc("/admin/webinar", webinarHandler.CreateWebinar).Methods("POST")
+	router.HandleFunc("/admin/webinars", webinarHandler.GetAllWebinars).Methods("GET")
+	router.HandleFunc("/admin/webinars/{id:[0-9]+}", webinarHandler.GetWebinarByID).Methods("GET")
+	router.Handle("/admin/webinars/{id:[0-9]+}", middleware.AdminOnly(http.HandlerFunc(webinarHandler.UpdateWebinar))).Methods("PUT")
+	router.Handle("/admin/webinars/{id:[0-9]+}", middleware.AdminOnly(http.HandlerFunc(webinarHandler.DeleteWebinar))).Methods("DELETE")
+	router.Handle("/admin/webinars/{id:[0-9]+}/status", middleware.AdminOnly(http.HandlerFunc(webinarHandler.UpdateStatus))).Methods("PUT")
+	router.Handle("/admin/webinars/{id:[0-9]+}/cancel", middleware.AdminOnly(http.HandlerFunc(webinarHandler.CancelWebinar))).Methods("POST")
+	router.Handle("/admin/webinars/{id:[0-9]+}/host", middleware.AdminOnly(http.HandlerFunc(webinarHandler.ReassignHost))).Methods("PUT")
+	router.Handle("/admin/webinars/{id:[0-9]+}/recording", middleware.AdminOnly(http.HandlerFunc(webinarHandler.SetRecording))).Methods("PUT")
+	router.Handle("/admin/webinars/{id:[0-9]+}/registrations", middleware.AdminOnly(http.HandlerFunc(webinarHandler.GetRegistrations))).Methods("GET")
+	router.Handle("/admin/webinars/{id:[0-9]+}/attendance", middleware.AdminOnly(http.HandlerFunc(webinarHandler.GetAttendance))).Methods("GET")
+	router.Handle("/admin/webinars/{id:[0-9]+}/certificates", middleware.AdminOnly(http.HandlerFunc(webinarHandler.IssueCertificates))).Methods("POST")
+
+	// ROUTES NOTIFICATION ADMIN || OUTBOX || DELIVERIES || RETRY || TEMPLATES ||
+	router.Handle("/admin/notifications/outbox", middleware.AdminOnly(http.HandlerFunc(notificationHandler.GetOutbox))).Methods("GET")
+	router.Handle("/admin/notifications/outbox/{id:[0-9]+}/retry", middleware.AdminOnly(http.HandlerFunc(notificationHandler.RetryOutbox))).Methods("POST")
+	router.Handle("/admin/notifications/deliveries", middleware.AdminOnly(http.HandlerFunc(notificationHandler.GetDeliveries))).Methods("GET")
+	router.Handle("/admin/notifications/deliveries/retry", middleware.AdminOnly(http.HandlerFunc(notificationHandler.RetryDeadDeliveries))).Methods("POST")
+	router.Handle("/admin/notifications/deliveries/{id:[0-9]+}/retry", middleware.AdminOnly(http.HandlerFunc(notificationHandler.RetryDelivery))).Methods("POST")
+	router.Handle("/admin/notifications/templates", middleware.AdminOnly(http.HandlerFunc(notificationHandler.ListTemplates))).Methods("GET")
+	router.Handle("/admin/notifications/templates/preview", middleware.AdminOnly(http.HandlerFunc(notificationHandler.PreviewTemplate))).Methods("POST")
+	router.Handle("/admin/notifications/templates/{event}/{locale}", middleware.AdminOnly(http.HandlerFunc(notificationHandler.GetTemplate))).Methods("GET")
+	router.Handle("/admin/notifications/templates/{event}/{locale}", middleware.AdminOnly(http.HandlerFunc(notificationHandler.UpdateTemplate))).Methods("PUT")
+	router.Handle("/admin/notifications/templates/{event}/{locale}", middleware.AdminOnly(http.HandlerFunc(notificationHandler.ResetTemplate))).Methods("DELETE")
+
+	// ROUTES WEBHOOK ADMIN || CRUD || DELIVERIES || RETRY || TEST ||
+	router.Handle("/admin/webhooks/events", middleware.AdminOnly(http.HandlerFunc(webhookHandler.GetEvents))).Methods("GET")
+	router.Handle("/admin/webhooks", middleware.AdminOnly(http.HandlerFunc(webhookHandler.GetSubscriptions))).Methods("GET")
+	router.Handle("/admin/webhooks", middleware.AdminOnly(http.HandlerFunc(webhookHandler.CreateSubscription))).Methods("POST")
+	router.Handle("/admin/webhooks/{id:[0-9]+}", middleware.AdminOnly(http.HandlerFunc(webhookHandler.GetSubscriptionByID))).Methods("GET")
+	router.Handle("/admin/webhooks/{id:[0-9]+}", middleware.AdminOnly(http.HandlerFunc(webhookHandler.UpdateSubscription))).Methods("PUT")
+	router.Handle("/admin/webhooks/{id:[0-9]+}", middleware.AdminOnly(http.HandlerFunc(webhookHandler.DeleteSubscription))).Methods("DELETE")
+	router.Handle("/admin/webhooks/{id:[0-9]+}/deliveries", middleware.AdminOnly(http.HandlerFunc(webhookHandler.GetDeliveries))).Methods("GET")
+	router.Handle("/admin/webhooks/{id:[0-9]+}/test", middleware.AdminOnly(http.HandlerFunc(webhookHandler.SendTestEvent))).Methods("POST")
+	router.Handle("/admin/webhooks/deliveries/{id:[0-9]+}/retry", middleware.AdminOnly(http.HandlerFunc(webhookHandler.RetryDelivery))).Methods("POST")
 
 	// Auth Routes
 	router.HandleFunc("/admin/register", handler.RegisterAdmin).Methods("POST")
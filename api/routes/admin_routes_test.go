@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"go-project/internal/admin/handler"
+	"go-project/internal/admin/model"
+	"go-project/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// newAdminRouter mendaftarkan route admin dengan handler kosong; request yang ditolak middleware
+// tidak pernah sampai ke handler sehingga cukup untuk menguji autentikasi
+func newAdminRouter() *mux.Router {
+	router := mux.NewRouter()
+	RegisterAdminRoutes(router, &handler.ArticleHandler{}, &handler.VideoHandler{}, &handler.AppointmentHandler{},
+		&handler.TestimonialHandler{}, &handler.CommentHandler{}, &handler.WebinarHandler{},
+		&handler.NotificationHandler{}, &handler.WebhookHandler{})
+	return router
+}
+
+func TestAdminRouteRejectsAppointmentManageToken(t *testing.T) {
+	utils.ConfigureJWT("0123456789abcdef0123456789abcdef", time.Hour)
+	manage, err := utils.GenerateAppointmentToken(1, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	staff, err := utils.GenerateJWT(model.User{Email: "staff@example.com", Role: "staff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := utils.GenerateJWT(model.User{Email: "user@example.com", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name, auth string
+		want       int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"manage token", "Bearer " + manage, http.StatusUnauthorized},
+		{"staff token", "Bearer " + staff, http.StatusForbidden},
+		{"user token", "Bearer " + user, http.StatusForbidden},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPut, "/admin/appointments/1/update-status", nil)
+		if c.auth != "" {
+			req.Header.Set("Authorization", c.auth)
+		}
+		rec := httptest.NewRecorder()
+		newAdminRouter().ServeHTTP(rec, req)
+		if rec.Code != c.want {
+			t.Errorf("%s: status = %d, want %d", c.name, rec.Code, c.want)
+		}
+	}
+}
+
+// adminOnlyRoutes adalah route admin yang juga menolak token login dengan peran selain admin
+var adminOnlyRoutes = []struct{ method, path string }{
+	{http.MethodPut, "/admin/article/1/review"},
+	{http.MethodPut, "/admin/video/1/review"},
+	{http.MethodPost, "/admin/testimonials/bulk"},
+	{http.MethodPost, "/admin/comments/bulk"},
+	{http.MethodGet, "/admin/comments/reported"},
+	{http.MethodPut, "/admin/comment/1/reports/resolve"},
+	{http.MethodPut, "/admin/staff/1/specialties"},
+	{http.MethodPost, "/admin/appointments/1/assign-host"},
+	{http.MethodPut, "/admin/appointments/1/update-status"},
+	{http.MethodGet, "/admin/appointments/1/timeline"},
+	{http.MethodGet, "/admin/appointments/1/whatsapp-messages"},
+	{http.MethodPost, "/admin/appointments/1/meeting-link"},
+	{http.MethodGet, "/admin/appointments/1/ics"},
+	{http.MethodGet, "/admin/webinars/1/registrations"},
+	{http.MethodPut, "/admin/webinars/1"},
+	{http.MethodDelete, "/admin/webinars/1"},
+	{http.MethodPut, "/admin/webinars/1/status"},
+	{http.MethodPost, "/admin/webinars/1/cancel"},
+	{http.MethodPut, "/admin/webinars/1/host"},
+	{http.MethodPut, "/admin/webinars/1/recording"},
+	{http.MethodGet, "/admin/webinars/1/attendance"},
+	{http.MethodPost, "/admin/webinars/1/certificates"},
+	{http.MethodGet, "/admin/notifications/outbox"},
+	{http.MethodPost, "/admin/notifications/outbox/1/retry"},
+	{http.MethodGet, "/admin/notifications/deliveries"},
+	{http.MethodPost, "/admin/notifications/deliveries/retry"},
+	{http.MethodPost, "/admin/notifications/deliveries/1/retry"},
+	{http.MethodGet, "/admin/notifications/templates"},
+	{http.MethodPost, "/admin/notifications/templates/preview"},
+	{http.MethodGet, "/admin/notifications/templates/article.submitted/id"},
+	{http.MethodPut, "/admin/notifications/templates/article.submitted/id"},
+	{http.MethodDelete, "/admin/notifications/templates/article.submitted/id"},
+	{http.MethodGet, "/admin/webhooks/events"},
+	{http.MethodGet, "/admin/webhooks"},
+	{http.MethodPost, "/admin/webhooks"},
+	{http.MethodGet, "/admin/webhooks/1"},
+	{http.MethodPut, "/admin/webhooks/1"},
+	{http.MethodDelete, "/admin/webhooks/1"},
+	{http.MethodGet, "/admin/webhooks/1/deliveries"},
+	{http.MethodPost, "/admin/webhooks/1/test"},
+	{http.MethodPost, "/admin/webhooks/deliveries/1/retry"},
+}
+
+func TestAdminOnlyRoutesRejectOtherRoles(t *testing.T) {
+	utils.ConfigureJWT("0123456789abcdef0123456789abcdef", time.Hour)
+	staff, err := utils.GenerateJWT(model.User{Email: "staff@example.com", Role: "staff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, route := range adminOnlyRoutes {
+		for auth, want := range map[string]int{"": http.StatusUnauthorized, "Bearer " + staff: http.StatusForbidden} {
+			req := httptest.NewRequest(route.method, route.path, nil)
+			if auth != "" {
+				req.Header.Set("Authorization", auth)
+			}
+			rec := httptest.NewRecorder()
+			newAdminRouter().ServeHTTP(rec, req)
+			if rec.Code != want {
+				t.Errorf("%s %s with token %t: status = %d, want %d", route.method, route.path, auth != "", rec.Code, want)
+			}
+		}
+	}
+}
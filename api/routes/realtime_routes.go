@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"go-project/pkg/middleware"
+	"go-project/pkg/realtime"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterRealtimeRoutes mendaftarkan stream notifikasi dan antrean moderasi untuk pengguna yang sudah login.
+// Klien meminta tiket dengan token login lalu membuka stream dengan tiket tersebut.
+func RegisterRealtimeRoutes(router *mux.Router, handler *realtime.Handler) {
+	router.Handle("/realtime/ticket", middleware.AuthMiddleware(http.HandlerFunc(handler.IssueTicket))).Methods("POST")
+	router.Handle("/realtime/events", middleware.StreamTicketAuth(http.HandlerFunc(handler.ServeSSE))).Methods("GET")
+	router.Handle("/realtime/ws", middleware.StreamTicketAuth(http.HandlerFunc(handler.ServeWebSocket))).Methods("GET")
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"go-project/internal/admin/model"
+	"go-project/pkg/realtime"
+	"go-project/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func newRealtimeRouter() *mux.Router {
+	router := mux.NewRouter()
+	RegisterRealtimeRoutes(router, realtime.NewHandler(nil, realtime.NewHub(), "https://app.example.com/"))
+	return router
+}
+
+func TestRealtimeStreamsRequireTicket(t *testing.T) {
+	utils.ConfigureJWT("0123456789abcdef0123456789abcdef", time.Hour)
+	login, err := utils.GenerateJWT(model.User{Email: "user@example.com", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/realtime/events", "/realtime/ws"} {
+		for _, query := range []string{"", "?access_token=" + login, "?ticket=" + login} {
+			rec := httptest.NewRecorder()
+			newRealtimeRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path+query, nil))
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("GET %s%s: status = %d, want %d", path, query, rec.Code, http.StatusUnauthorized)
+			}
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	newRealtimeRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/realtime/ticket", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("POST /realtime/ticket without token: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRealtimeWebSocketRejectsForeignOrigin(t *testing.T) {
+	utils.ConfigureJWT("0123456789abcdef0123456789abcdef", time.Hour)
+	ticket, err := utils.GenerateStreamTicket("user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/realtime/ws?ticket="+ticket, nil)
+	req.Header.Set("Origin", "https://evil.example.net")
+	rec := httptest.NewRecorder()
+	newRealtimeRouter().ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("websocket from foreign origin: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
@@ -2,23 +2,57 @@ package routes
 
 import (
 	"go-project/internal/staff/handler"
+	"go-project/pkg/middleware"
 	"net/http"
 
 	"github.com/gorilla/mux"
 )
 
 // FUNCTION REGISTER STAFF RESTFULLAPI
-func RegisterStaffRoutes(router *mux.Router, articleHandler *handler.ArticleHandler, videoHandler *handler.VideoHandler, appointmentHandler *handler.AppointmentHandler, handler *handler.TestimonialHandler, commentHandler *handler.CommentHandler, webinarHandler *handler.WebinarHandler) {
+func RegisterStaffRoutes(router *mux.Router, articleHandler *handler.ArticleHandler, videoHandler *handler.VideoHandler, appointmentHandler *handler.AppointmentHandler, handler *handler.TestimonialHandler, commentHandler *handler.CommentHandler, webinarHandler *handler.WebinarHandler, availabilityHandler *handler.AvailabilityHandler, calendarHandler *handler.CalendarHandler) {
 	// ROUTES STAFF ARTICLE || CRUD ||
-	router.HandleFunc("/staff/upload/articles", articleHandler.UploadArticle).Methods(http.MethodPost)
+	router.Handle("/staff/upload/articles", middleware.AuthMiddleware(http.HandlerFunc(articleHandler.UploadArticle))).Methods(http.MethodPost)
 	router.HandleFunc("/staff/articles/view", articleHandler.GetArticleByID).Methods(http.MethodGet)
 	router.HandleFunc("/staff/articles", articleHandler.GetAllArticles).Methods(http.MethodGet)
 
 	// ROUTES STAFF VIDEO || CRUD ||
-	router.HandleFunc("/staff/upload/videos", videoHandler.UploadVideo).Methods(http.MethodPost)
+	router.Handle("/staff/upload/videos", middleware.AuthMiddleware(http.HandlerFunc(videoHandler.UploadVideo))).Methods(http.MethodPost)
 	router.HandleFunc("/staff/videos/view", videoHandler.GetVideoByID).Methods(http.MethodGet)
 	router.HandleFunc("/staff/videos", videoHandler.GetAllVideos).Methods(http.MethodGet)
 
+	// ROUTES STAFF MY CONTENT || LIST || EDIT || WITHDRAW || REVIEW HISTORY ||
+	my := router.PathPrefix("/staff/my").Subrouter()
+	my.Use(middleware.AuthMiddleware)
+	my.HandleFunc("/articles", articleHandler.GetMyArticles).Methods(http.MethodGet)
+	my.HandleFunc("/articles/{id:[0-9]+}", articleHandler.UpdateMyArticle).Methods(http.MethodPut)
+	my.HandleFunc("/articles/{id:[0-9]+}/withdraw", articleHandler.WithdrawMyArticle).Methods(http.MethodPost)
+	my.HandleFunc("/articles/{id:[0-9]+}/reviews", articleHandler.GetMyArticleReviews).Methods(http.MethodGet)
+	my.HandleFunc("/videos", videoHandler.GetMyVideos).Methods(http.MethodGet)
+	my.HandleFunc("/videos/{id:[0-9]+}", videoHandler.UpdateMyVideo).Methods(http.MethodPut)
+	my.HandleFunc("/videos/{id:[0-9]+}/withdraw", videoHandler.WithdrawMyVideo).Methods(http.MethodPost)
+	my.HandleFunc("/videos/{id:[0-9]+}/reviews", videoHandler.GetMyVideoReviews).Methods(http.MethodGet)
+
+	// ROUTES STAFF AVAILABILITY || WEEKLY TEMPLATE || EXCEPTIONS ||
+	my.HandleFunc("/availability", availabilityHandler.GetAvailability).Methods(http.MethodGet)
+	my.HandleFunc("/availability", availabilityHandler.SetAvailability).Methods(http.MethodPut)
+	my.HandleFunc("/availability/exceptions", availabilityHandler.GetExceptions).Methods(http.MethodGet)
+	my.HandleFunc("/availability/exceptions", availabilityHandler.CreateException).Methods(http.MethodPost)
+	my.HandleFunc("/availability/exceptions/{id:[0-9]+}", availabilityHandler.DeleteException).Methods(http.MethodDelete)
+
+	// ROUTES STAFF CALENDAR FEED || URL || RESET || SUBSCRIBE ||
+	my.HandleFunc("/calendar", calendarHandler.GetMyFeed).Methods(http.MethodGet)
+	my.HandleFunc("/calendar/reset", calendarHandler.ResetMyFeed).Methods(http.MethodPost)
+	router.HandleFunc("/staff/calendar/{token:[0-9a-f]+}.ics", calendarHandler.GetFeed).Methods(http.MethodGet)
+
+	// ROUTES STAFF APPOINTMENT DASHBOARD || LIST || DETAIL || NOTES || COMPLETE || NO-SHOW || DOCUMENTS ||
+	my.HandleFunc("/appointments", appointmentHandler.ListAppointments).Methods(http.MethodGet)
+	my.HandleFunc("/appointments/{id:[0-9]+}", appointmentHandler.GetAppointment).Methods(http.MethodGet)
+	my.HandleFunc("/appointments/{id:[0-9]+}/notes", appointmentHandler.AddNote).Methods(http.MethodPost)
+	my.HandleFunc("/appointments/{id:[0-9]+}/complete", appointmentHandler.CompleteAppointment).Methods(http.MethodPost)
+	my.HandleFunc("/appointments/{id:[0-9]+}/no-show", appointmentHandler.MarkNoShow).Methods(http.MethodPost)
+	my.HandleFunc("/appointments/{id:[0-9]+}/documents", appointmentHandler.UploadDocument).Methods(http.MethodPost)
+	my.HandleFunc("/appointments/{id:[0-9]+}/documents/{documentId:[0-9]+}", appointmentHandler.DownloadDocument).Methods(http.MethodGet)
+
 	// ROUTES STAFF TESTIMONIALS || CREATE || GET PENDING || UPDATE || DELETE ||
 	router.HandleFunc("/staff/testimonials", handler.CreateTestimonial).Methods("POST")
 	router.HandleFunc("/staff/testimonials", handler.GetPendingTestimonials).Methods("GET")
@@ -34,8 +68,13 @@ func RegisterStaffRoutes(router *mux.Router, articleHandler *handler.ArticleHand
 
 	router.HandleFunc("/staff/webinars", webinarHandler.GetAllWebinars).Methods("GET")
 	router.HandleFunc("/staff/webinar/view", webinarHandler.GetWebinarByID).Methods("GET")
+	router.HandleFunc("/staff/webinar/ics", webinarHandler.DownloadWebinarCalendar).Methods("GET")
+	my.HandleFunc("/webinars/{id:[0-9]+}/registrations", webinarHandler.GetMyRegistrations).Methods(http.MethodGet)
+	my.HandleFunc("/webinars/{id:[0-9]+}/check-in-code", webinarHandler.OpenCheckIn).Methods(http.MethodPost)
+	my.HandleFunc("/webinars/{id:[0-9]+}/attendance", webinarHandler.GetMyAttendance).Methods(http.MethodGet)
+	my.HandleFunc("/webinars/{id:[0-9]+}/attendance/{registrationId:[0-9]+}", webinarHandler.MarkAttendance).Methods(http.MethodPut)
 
 	// ROUTES STAFF APPOINTMENTS || CREATE APPOINTMENTS || LIST APPOINTMENTS
 	router.HandleFunc("/staff/appointments", appointmentHandler.CreateAppointment).Methods(http.MethodPost)
-	router.HandleFunc("/staff/appointments", appointmentHandler.ListAppointments).Methods(http.MethodGet)
+	router.Handle("/staff/appointments", middleware.AuthMiddleware(http.HandlerFunc(appointmentHandler.ListAppointments))).Methods(http.MethodGet)
 }
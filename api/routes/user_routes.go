@@ -2,15 +2,69 @@ package routes
 
 import (
 	"go-project/internal/user/handler"
+	"go-project/pkg/middleware"
+	"go-project/pkg/ratelimit"
+	"net/http"
 
 	"github.com/gorilla/mux"
 )
 
+// UserRateLimits berisi pembatas request per IP client untuk endpoint publik
+type UserRateLimits struct {
+	CommentReports       *ratelimit.Limiter
+	AppointmentLookups   *ratelimit.Limiter
+	WebinarRegistrations *ratelimit.Limiter
+}
+
 func RegisterUserRoutes(
 	router *mux.Router,
 	appointmentHandler *handler.AppointmentHandler,
+	commentHandler *handler.CommentHandler,
+	webinarHandler *handler.WebinarHandler,
+	notificationHandler *handler.NotificationHandler,
+	whatsAppHandler *handler.WhatsAppHandler,
+	limits UserRateLimits,
 ) {
 	router.HandleFunc("/user/appointments", appointmentHandler.CreateAppointment).Methods("POST")
-	// router.HandleFunc("/user/appointments", appointmentHandler.Get).Methods("POST")
+	router.HandleFunc("/user/appointments/slots", appointmentHandler.ListFreeSlots).Methods("GET")
+	router.HandleFunc("/user/appointments/manage", appointmentHandler.GetManagedAppointment).Methods("GET")
+	router.HandleFunc("/user/appointments/manage/ics", appointmentHandler.DownloadManagedCalendar).Methods("GET")
+	router.HandleFunc("/user/appointments/manage/documents/{id:[0-9]+}", appointmentHandler.DownloadManagedDocument).Methods("GET")
+	router.HandleFunc("/user/appointments/manage/cancel", appointmentHandler.CancelAppointment).Methods("POST")
+	router.HandleFunc("/user/appointments/manage/reschedule", appointmentHandler.RescheduleAppointment).Methods("POST")
+	router.Handle("/user/appointments/lookup", limits.AppointmentLookups.Middleware(http.HandlerFunc(appointmentHandler.LookupAppointment))).Methods("POST")
+
+	// Webhook pesan WhatsApp masuk dari Twilio, diverifikasi lewat X-Twilio-Signature
+	router.HandleFunc("/user/whatsapp/inbound", whatsAppHandler.Inbound).Methods("POST")
+
+	// Appointment milik user yang login; user tanpa akun memakai lookup dengan kode referensi atau link kelola
+	my := router.PathPrefix("/user/my").Subrouter()
+	my.Use(middleware.AuthMiddleware)
+	my.HandleFunc("/appointments", appointmentHandler.ListMyAppointments).Methods("GET")
+	my.HandleFunc("/appointments/{reference}", appointmentHandler.GetMyAppointment).Methods("GET")
+	my.HandleFunc("/notifications", notificationHandler.ListNotifications).Methods("GET")
+	my.HandleFunc("/notifications/unread-count", notificationHandler.CountUnread).Methods("GET")
+	my.HandleFunc("/notifications/read-all", notificationHandler.MarkAllRead).Methods("PUT")
+	my.HandleFunc("/notifications/preferences", notificationHandler.GetPreferences).Methods("GET")
+	my.HandleFunc("/notifications/preferences", notificationHandler.UpdatePreferences).Methods("PUT")
+	my.HandleFunc("/notifications/{id:[0-9]+}/read", notificationHandler.MarkRead).Methods("PUT")
+	my.HandleFunc("/notifications/{id:[0-9]+}", notificationHandler.DeleteNotification).Methods("DELETE")
+
+	router.HandleFunc("/user/articles/{id:[0-9]+}/comments", commentHandler.GetCommentThreads).Methods("GET")
+	router.HandleFunc("/user/articles/{id:[0-9]+}/comments", commentHandler.CreateComment).Methods("POST")
+	// Reaksi dan laporan memakai email dari JWT jika ada, selain itu IP client
+	router.Handle("/user/comments/{id:[0-9]+}/reactions", middleware.OptionalAuth(http.HandlerFunc(commentHandler.ReactToComment))).Methods("POST")
+	router.Handle("/user/comments/{id:[0-9]+}/reactions", middleware.OptionalAuth(http.HandlerFunc(commentHandler.RemoveReaction))).Methods("DELETE")
+	router.Handle("/user/comments/{id:[0-9]+}/report", limits.CommentReports.Middleware(middleware.OptionalAuth(http.HandlerFunc(commentHandler.ReportComment)))).Methods("POST")
 
+	router.HandleFunc("/user/webinars/{id:[0-9]+}", webinarHandler.GetWebinar).Methods("GET")
+	router.HandleFunc("/user/webinars/{id:[0-9]+}/ics", webinarHandler.DownloadWebinarCalendar).Methods("GET")
+	router.Handle("/user/webinars/{id:[0-9]+}/register", limits.WebinarRegistrations.Middleware(http.HandlerFunc(webinarHandler.Register))).Methods("POST")
+	router.HandleFunc("/user/webinars/registration", webinarHandler.GetRegistration).Methods("GET")
+	router.HandleFunc("/user/webinars/registration/cancel", webinarHandler.CancelRegistration).Methods("POST")
+	router.HandleFunc("/user/webinars/registration/check-in", webinarHandler.CheckIn).Methods("POST")
+	router.HandleFunc("/user/webinars/registration/check-out", webinarHandler.CheckOut).Methods("POST")
+	router.HandleFunc("/user/webinars/registration/certificate", webinarHandler.DownloadMyCertificate).Methods("GET")
+	router.HandleFunc("/user/certificates/{code}", webinarHandler.VerifyCertificate).Methods("GET")
+	router.HandleFunc("/user/certificates/{code}/pdf", webinarHandler.DownloadCertificate).Methods("GET")
 }
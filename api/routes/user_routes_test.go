@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"go-project/internal/user/handler"
+	"go-project/pkg/ratelimit"
+	"go-project/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// newUserRouter mendaftarkan route user dengan handler kosong dan pembatas request yang diberikan
+func newUserRouter(limits UserRateLimits) *mux.Router {
+	router := mux.NewRouter()
+	RegisterUserRoutes(router, &handler.AppointmentHandler{}, &handler.CommentHandler{}, &handler.WebinarHandler{},
+		&handler.NotificationHandler{}, &handler.WhatsAppHandler{}, limits)
+	return router
+}
+
+func TestMyAppointmentsRequireLogin(t *testing.T) {
+	utils.ConfigureJWT("0123456789abcdef0123456789abcdef", time.Hour)
+	// Token link kelola hanya membuka appointment-nya sendiri, bukan daftar appointment dengan email yang sama
+	manageToken, err := utils.GenerateAppointmentToken(1, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/user/my/appointments", "/user/my/appointments/APT-TEST",
+		"/user/my/appointments?token=" + manageToken, "/user/my/appointments/APT-TEST?token=" + manageToken} {
+		rec := httptest.NewRecorder()
+		newUserRouter(UserRateLimits{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s: status = %d, want %d", path, rec.Code, http.StatusUnauthorized)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/user/my/appointments", nil)
+	req.Header.Set("Authorization", "Bearer "+manageToken)
+	rec := httptest.NewRecorder()
+	newUserRouter(UserRateLimits{}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("GET /user/my/appointments with manage token as bearer: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestPublicEndpointsRateLimited(t *testing.T) {
+	cases := []struct {
+		path   string
+		limits UserRateLimits
+	}{
+		{"/user/appointments/lookup", UserRateLimits{AppointmentLookups: ratelimit.New(1, time.Minute)}},
+		{"/user/webinars/1/register", UserRateLimits{WebinarRegistrations: ratelimit.New(1, time.Minute)}},
+	}
+	for _, c := range cases {
+		router := newUserRouter(c.limits)
+		// Request pertama diteruskan ke handler dan ditolak karena body tidak valid; request kedua dibatasi
+		for i, want := range []int{http.StatusBadRequest, http.StatusTooManyRequests} {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, c.path, strings.NewReader("{")))
+			if rec.Code != want {
+				t.Errorf("POST %s request %d: status = %d, want %d", c.path, i+1, rec.Code, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-project/config"
+	"os"
+)
+
+// runConfig menjalankan "config print": mencetak konfigurasi efektif dari semua sumber dengan nilai
+// rahasia disamarkan, lalu melaporkan masalah validasinya
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprint(os.Stderr, commandUsage)
+		return fmt.Errorf("usage: config print [-file FILE]")
+	}
+
+	fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+	file := fs.String("file", "", "file YAML konfigurasi (bawaan CONFIG_FILE atau "+config.DefaultFile+")")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, err := config.Read(*file)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Print(os.Stdout); err != nil {
+		return err
+	}
+	// Konfigurasi tetap dicetak meskipun tidak valid agar nilai yang salah bisa dilihat
+	return cfg.Validate()
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"go-project/api/routes"
 	"go-project/config"
 	"go-project/db"
@@ -13,13 +15,30 @@ import (
 	userHandler "go-project/internal/user/handler"
 	userRepo "go-project/internal/user/repository"
 	userService "go-project/internal/user/service"
+	"go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"go-project/pkg/moderation"
+	"go-project/pkg/notify"
+	"go-project/pkg/ratelimit"
+	"go-project/pkg/realtime"
+	"go-project/pkg/reminder"
+	"go-project/pkg/storage"
+	"go-project/pkg/utils"
+	"go-project/pkg/webhook"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/joho/godotenv"
 )
 
+// shutdownTimeout adalah batas waktu menunggu request yang masih berjalan saat aplikasi dihentikan
+const shutdownTimeout = 15 * time.Second
+
 func main() {
 	// Recovery untuk menghindari panic yang menyebabkan aplikasi crash
 	defer func() {
@@ -28,21 +47,73 @@ func main() {
 		}
 	}()
 
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	// Subcommand CLI, misalnya "migrate up" atau "config print"
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
 	}
 
-	// Load configuration
-	cfg := config.LoadConfig()
+	// Konfigurasi dari config.yaml (CONFIG_FILE), .env dan environment; aplikasi berhenti jika ada yang tidak valid
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Context dibatalkan saat SIGINT/SIGTERM agar job latar belakang berhenti dan server menyelesaikan request
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var jobs sync.WaitGroup
+	utils.ConfigureJWT(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+	if err := utils.ConfigureTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal(err)
+	}
+	calendar.ConfigureUIDDomain(cfg.Calendar.UIDDomain)
+	appointment.ConfigureCancelCutoff(cfg.Appointments.CancelCutoff)
+	utils.ConfigureTwilio(utils.TwilioSettings{
+		AccountSID:   cfg.Notifications.Twilio.AccountSID,
+		AuthToken:    cfg.Notifications.Twilio.AuthToken,
+		WhatsAppFrom: cfg.Notifications.Twilio.WhatsAppFrom,
+	})
+	utils.ConfigureSMTP(utils.SMTPSettings{
+		Host:     cfg.Notifications.SMTP.Host,
+		Port:     cfg.Notifications.SMTP.Port,
+		Username: cfg.Notifications.SMTP.Username,
+		Password: cfg.Notifications.SMTP.Password,
+		From:     cfg.Notifications.SMTP.From,
+	})
 
 	// Connect to database
-	db.ConnectDB(cfg)
+	if err := db.ConnectDB(cfg.DB); err != nil {
+		log.Fatal(err)
+	}
 	defer db.DB.Close() // Ensure the DB connection is closed when main exits.
 
+	// Menerapkan migrasi yang belum dijalankan; db.auto_migrate=false jika migrasi dijalankan terpisah
+	if cfg.DB.AutoMigrate {
+		if err := db.RunMigrations(context.Background(), cfg.Server.Timezone); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+	}
+
 	// Initialize router
 	router := mux.NewRouter()
 
+	// Pipeline moderasi komentar otomatis (moderation)
+	moderationCfg := cfg.Moderation.Pipeline()
+	commentModerator := moderation.NewDefaultPipeline(moderationCfg, moderation.NewSQLStore(db.DB))
+
+	// Pembuat link meeting otomatis (calendar.meeting_provider), nil jika tidak aktif
+	meetingProvider := calendar.NewMeetingProvider(cfg.Calendar.MeetingProvider, cfg.Calendar.MeetingBaseURL)
+
+	// Penyimpanan file unggahan (storage.upload_dir)
+	files := storage.NewLocalStorage(cfg.Storage.UploadDir)
+
+	// Notifikasi multi-kanal; penerima dicari dari pengguna dengan peran sesuai aturan routing (notifications.rules).
+	// Event dicatat di outbox bersama perubahan konten lalu dikirim oleh worker di bawah.
+	notifier := notify.NewRouter(cfg.Notifications.Rules.Router(), notify.NewUserDirectory(db.DB),
+		notify.NewInApp(db.DB), notify.NewWhatsApp(), notify.NewEmail())
+	notifier.Preferences = notify.NewUserPreferences(db.DB)
+	notifier.Templates = notify.NewTemplates(db.DB)
+
 	// Admin initialization
 	adminArticleRepo := adminRepo.NewArticleRepository(db.DB)
 	adminArticleService := adminService.NewArticleService(adminArticleRepo)
@@ -54,7 +125,7 @@ func main() {
 
 	// Appointment initialization for Admin
 	adminAppointmentRepo := adminRepo.NewAppointmentRepository(db.DB)
-	adminAppointmentService := adminService.NewAppointmentService(adminAppointmentRepo)
+	adminAppointmentService := adminService.NewAppointmentService(adminAppointmentRepo, meetingProvider, cfg.Appointments.AssignmentStrategy)
 	adminAppointmentHandler := adminHandler.NewAppointmentHandler(adminAppointmentService)
 
 	// Testimonial initialization for Admin
@@ -63,28 +134,40 @@ func main() {
 	adminTestimonialHandler := adminHandler.NewTestimonialHandler(adminTestimonialService)
 
 	adminCommentRepo := adminRepo.NewCommentRepository(db.DB)
-	adminCommentService := adminService.NewCommentService(adminCommentRepo)
+	adminCommentService := adminService.NewCommentService(adminCommentRepo, commentModerator)
 	adminCommentHandler := adminHandler.NewCommentHandler(adminCommentService)
 
 	adminWebinarRepo := adminRepo.NewWebinarRepository(db.DB)
-	adminWebinarService := adminService.NewWebinarService(adminWebinarRepo)
+	adminWebinarService := adminService.NewWebinarService(adminWebinarRepo, meetingProvider)
 	adminWebinarHandler := adminHandler.NewWebinarHandler(adminWebinarService)
 
+	adminNotificationRepo := adminRepo.NewNotificationRepository(db.DB)
+	adminNotificationService := adminService.NewNotificationService(adminNotificationRepo, notifier.Templates)
+	adminNotificationHandler := adminHandler.NewNotificationHandler(adminNotificationService)
+
+	// Webhook keluar untuk situs marketing dan CRM (notifications.webhook)
+	webhookCfg := cfg.Notifications.Webhook.Worker()
+	adminWebhookRepo := adminRepo.NewWebhookRepository(db.DB)
+	adminWebhookService := adminService.NewWebhookService(adminWebhookRepo, webhook.NewSender(webhookCfg.Timeout))
+	adminWebhookHandler := adminHandler.NewWebhookHandler(adminWebhookService)
+
 	// Register admin routes (including CommentHandler)
-	routes.RegisterAdminRoutes(router, adminArticleHandler, adminVideoHandler, adminAppointmentHandler, adminTestimonialHandler, adminCommentHandler, adminWebinarHandler)
+	routes.RegisterAdminRoutes(router, adminArticleHandler, adminVideoHandler, adminAppointmentHandler, adminTestimonialHandler, adminCommentHandler, adminWebinarHandler, adminNotificationHandler, adminWebhookHandler)
 
 	// Staff initialization
+	staffUserRepo := staffRepo.UserRepository{DB: db.DB}
+
 	staffArticleRepo := staffRepo.ArticleRepository{DB: db.DB}
-	staffArticleService := staffService.ArticleService{Repo: &staffArticleRepo}
+	staffArticleService := staffService.ArticleService{Repo: &staffArticleRepo, UserRepo: &staffUserRepo}
 	staffArticleHandler := staffHandler.ArticleHandler{Service: &staffArticleService}
 
 	staffVideoRepo := staffRepo.VideoRepository{DB: db.DB}
-	staffVideoService := staffService.VideoService{Repo: &staffVideoRepo}
-	staffVideoHandler := staffHandler.VideoHandler{Service: &staffVideoService}
+	staffVideoService := staffService.NewVideoService(&staffVideoRepo, &staffUserRepo)
+	staffVideoHandler := staffHandler.VideoHandler{Service: staffVideoService}
 
 	// Appointment initialization for Staff
 	staffAppointmentRepo := staffRepo.NewAppointmentRepository(db.DB)
-	staffAppointmentService := staffService.NewAppointmentService(staffAppointmentRepo)
+	staffAppointmentService := staffService.NewAppointmentService(staffAppointmentRepo, &staffUserRepo, files)
 	staffAppointmentHandler := staffHandler.NewAppointmentHandler(staffAppointmentService)
 
 	staffTestimonialRepo := staffRepo.NewTestimonialRepository(db.DB)
@@ -96,22 +179,109 @@ func main() {
 	staffCommentHandler := staffHandler.NewCommentHandler(staffCommentService)
 
 	staffWebinarRepo := staffRepo.NewWebinarRepository(db.DB)
-	staffWebinarService := staffService.NewWebinarService(staffWebinarRepo)
+	staffWebinarService := staffService.NewWebinarService(staffWebinarRepo, &staffUserRepo)
 	staffWebinarHandler := staffHandler.NewWebinarHandler(staffWebinarService)
 
+	staffAvailabilityRepo := staffRepo.NewAvailabilityRepository(db.DB)
+	staffAvailabilityService := staffService.NewAvailabilityService(staffAvailabilityRepo, &staffUserRepo)
+	staffAvailabilityHandler := staffHandler.NewAvailabilityHandler(staffAvailabilityService)
+
+	staffCalendarRepo := staffRepo.NewCalendarRepository(db.DB)
+	staffCalendarService := staffService.NewCalendarService(staffCalendarRepo, &staffUserRepo)
+	staffCalendarHandler := staffHandler.NewCalendarHandler(staffCalendarService)
+
 	// Register staff routes
-	routes.RegisterStaffRoutes(router, &staffArticleHandler, &staffVideoHandler, staffAppointmentHandler, staffTestimonialHandler, staffCommentHandler, staffWebinarHandler)
+	routes.RegisterStaffRoutes(router, &staffArticleHandler, &staffVideoHandler, staffAppointmentHandler, staffTestimonialHandler, staffCommentHandler, staffWebinarHandler, staffAvailabilityHandler, staffCalendarHandler)
 
 	appointmentRepo := userRepo.NewAppointmentRepository(db.DB)
-	appointmentService := userService.NewAppointmentService(appointmentRepo)
+	appointmentService := userService.NewAppointmentService(appointmentRepo, files, cfg.Appointments.AssignmentStrategy)
 	appointmentHandler := userHandler.NewAppointmentHandler(appointmentService)
 
+	commentRepo := userRepo.NewCommentRepository(db.DB)
+	commentService := userService.NewCommentService(commentRepo, commentModerator, moderationCfg.ReportThreshold)
+	commentHandler := userHandler.NewCommentHandler(commentService)
+
+	webinarRepo := userRepo.NewWebinarRepository(db.DB)
+	webinarService := userService.NewWebinarService(webinarRepo)
+	webinarHandler := userHandler.NewWebinarHandler(webinarService)
+
+	notificationRepo := userRepo.NewNotificationRepository(db.DB)
+	notificationService := userService.NewNotificationService(notificationRepo)
+	notificationHandler := userHandler.NewNotificationHandler(notificationService)
+
+	// Balasan WhatsApp klien (YA/BATAL) untuk konfirmasi atau pembatalan appointment
+	whatsAppRepo := userRepo.NewWhatsAppRepository(db.DB)
+	whatsAppService := userService.NewWhatsAppService(whatsAppRepo, notifier.Templates)
+	whatsAppHandler := userHandler.NewWhatsAppHandler(whatsAppService)
+
 	// Routing
-	routes.RegisterUserRoutes(router, appointmentHandler)
+	userLimits := routes.UserRateLimits{
+		CommentReports:       ratelimit.New(cfg.RateLimit.CommentReports, cfg.RateLimit.CommentReportsWindow),
+		AppointmentLookups:   ratelimit.New(cfg.RateLimit.AppointmentLookups, cfg.RateLimit.AppointmentLookupsWindow),
+		WebinarRegistrations: ratelimit.New(cfg.RateLimit.WebinarRegistrations, cfg.RateLimit.WebinarRegistrationsWindow),
+	}
+	routes.RegisterUserRoutes(router, appointmentHandler, commentHandler, webinarHandler, notificationHandler, whatsAppHandler, userLimits)
+
+	// Stream notifikasi dan antrean moderasi (SSE dan WebSocket) lewat Postgres LISTEN/NOTIFY
+	realtimeHub := realtime.NewHub()
+	realtimeListener := realtime.NewListener(db.DB, realtimeHub)
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		realtimeListener.Start(ctx)
+	}()
+	routes.RegisterRealtimeRoutes(router, realtime.NewHandler(db.DB, realtimeHub, cfg.Server.BaseURL))
+
+	// Job konfirmasi dan pengingat appointment lewat WhatsApp dan email (appointments.reminders)
+	reminderCfg := cfg.Appointments.Reminders.Job()
+	reminderJob := reminder.NewJob(reminder.NewStore(db.DB, reminderCfg), reminder.NewWebinarStore(db.DB, reminderCfg), reminderCfg, config.Location(), notifier.Templates, map[string]reminder.SendFunc{
+		reminder.ChannelWhatsApp: func(to, subject, body string, _ ...utils.Attachment) error {
+			return utils.SendWhatsAppNotification(to, body)
+		},
+		reminder.ChannelEmail: utils.SendEmail,
+	})
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		reminderJob.Start(ctx)
+	}()
+
+	// Worker outbox notifikasi dengan percobaan ulang dan dead-letter (notifications.outbox)
+	notifyWorker := notify.NewWorker(db.DB, notifier, cfg.Notifications.Outbox.Worker())
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		notifyWorker.Start(ctx)
+	}()
+
+	// Worker webhook dengan percobaan ulang dan dead-letter
+	webhookWorker := webhook.NewWorker(db.DB, webhookCfg)
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		webhookWorker.Start(ctx)
+	}()
 
 	// Start the server
-	log.Println("Starting server on http://localhost:8081")
-	if err := http.ListenAndServe(":8081", router); err != nil {
-		log.Fatalf("Could not start server: %s\n", err)
+	server := &http.Server{
+		Addr:              cfg.Server.Addr(),
+		Handler:           router,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+	}
+	go func() {
+		log.Printf("Starting server on %s (%s)", server.Addr, cfg.Server.BaseURL)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Could not start server: %s\n", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown: %v", err)
 	}
+	// Menunggu job menyelesaikan pengiriman yang sedang berjalan
+	jobs.Wait()
 }
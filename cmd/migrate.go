@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go-project/config"
+	"go-project/db"
+	"go-project/pkg/migrate"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const commandUsage = `Usage:
+  migrate up [N]       menerapkan N migrasi berikutnya (bawaan semua)
+  migrate down [N]     membatalkan N migrasi terakhir (bawaan 1)
+  migrate status       menampilkan migrasi yang sudah dan belum dijalankan
+  migrate create NAME  membuat file up dan down kosong untuk migrasi baru
+  seed                 menerapkan semua migrasi lalu mengisi data contoh untuk pengembangan lokal
+  config print [-file FILE]
+                       menampilkan konfigurasi efektif dengan nilai rahasia disamarkan
+`
+
+// runCommand menjalankan subcommand dari argumen CLI dan mengembalikan exit code
+func runCommand(args []string) int {
+	switch args[0] {
+	case "migrate":
+		if err := runMigrate(args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "migrate:", err)
+			return 1
+		}
+		return 0
+	case "seed":
+		if err := runSeed(); err != nil {
+			fmt.Fprintln(os.Stderr, "seed:", err)
+			return 1
+		}
+		return 0
+	case "config":
+		if err := runConfig(args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, "config:", err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], commandUsage)
+		return 2
+	}
+}
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, commandUsage)
+		return fmt.Errorf("missing subcommand")
+	}
+
+	if args[0] == "create" {
+		fs := flag.NewFlagSet("migrate create", flag.ContinueOnError)
+		dir := fs.String("dir", db.MigrationsDir, "direktori file migrasi")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: migrate create [-dir DIR] NAME")
+		}
+		up, down, err := migrate.Create(*dir, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", up)
+		fmt.Println("created", down)
+		return nil
+	}
+
+	steps := 0
+	if len(args) > 1 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid step count %q", args[1])
+		}
+		steps = n
+	}
+
+	cfg, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+	m, err := db.NewMigrator(cfg.Server.Timezone)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(ctx, steps)
+		if err == nil && len(done) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return err
+	case "down":
+		done, err := m.Down(ctx, steps)
+		if err == nil && len(done) == 0 {
+			fmt.Println("no applied migrations")
+		}
+		return err
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, commandUsage)
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+// runSeed menerapkan migrasi yang tertunda agar tabel lengkap, lalu mengisi data contoh
+func runSeed() error {
+	cfg, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+
+	ctx := context.Background()
+	if err := db.RunMigrations(ctx, cfg.Server.Timezone); err != nil {
+		return err
+	}
+	return db.Seed(ctx)
+}
+
+// connectDB membuka koneksi database untuk subcommand; hanya bagian db dan zona waktu dari konfigurasi
+// yang divalidasi
+func connectDB() (*config.Config, error) {
+	cfg, err := config.Read("")
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.DB.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := time.LoadLocation(cfg.Server.Timezone); err != nil || cfg.Server.Timezone == "" {
+		return nil, fmt.Errorf("server.timezone (APP_TIMEZONE) must be an IANA time zone, got %q", cfg.Server.Timezone)
+	}
+	return cfg, db.ConnectDB(cfg.DB)
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", ""
+		if s.Applied {
+			state, appliedAt = "applied", s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case s.Missing:
+			state += " (file missing)"
+		case s.Modified:
+			state += " (modified)"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -1,57 +1,374 @@
 package config
 
 import (
-	"fmt"
+	"go-project/pkg/appointment"
+	"go-project/pkg/moderation"
+	"go-project/pkg/notify"
+	"go-project/pkg/reminder"
+	"go-project/pkg/webhook"
 	"log"
-	"os"
-
-	"github.com/joho/godotenv"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// Config  mmenghubungkan database Postgresql "go_education"
+// Config adalah seluruh konfigurasi aplikasi. Nilai dibaca berurutan dari nilai bawaan, file YAML,
+// file .env lalu environment; sumber yang belakangan menimpa yang sebelumnya. Tag yaml adalah kunci di
+// file YAML, tag env adalah nama variabel environment (pada struct bertingkat menjadi awalan nama variabel
+// di dalamnya) dan tag secret menandai nilai yang disamarkan saat dicetak.
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
+	Server        ServerConfig        `yaml:"server"`
+	DB            DBConfig            `yaml:"db"`
+	Auth          AuthConfig          `yaml:"auth"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Storage       StorageConfig       `yaml:"storage"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit"`
+	Moderation    ModerationConfig    `yaml:"moderation"`
+	Appointments  AppointmentsConfig  `yaml:"appointments"`
+	Calendar      CalendarConfig      `yaml:"calendar"`
+}
+
+// ServerConfig mengatur HTTP server dan alamat publik aplikasi
+type ServerConfig struct {
+	Host              string        `yaml:"host" env:"SERVER_HOST"`
+	Port              int           `yaml:"port" env:"SERVER_PORT"`
+	BaseURL           string        `yaml:"base_url" env:"APP_BASE_URL"` // Alamat publik untuk link yang dikirim ke pengguna
+	Timezone          string        `yaml:"timezone" env:"APP_TIMEZONE"` // Zona waktu jadwal appointment dan webinar
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	TrustedProxies    []string      `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"` // IP/CIDR reverse proxy yang X-Forwarded-For-nya dipercaya
+}
+
+// Addr mengembalikan alamat listen HTTP server, misalnya ":8081"
+func (c ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// DBConfig mengatur koneksi dan pool database PostgreSQL
+type DBConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	AutoMigrate     bool          `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"` // false jika migrasi dijalankan terpisah
+}
+
+// ConnectionString mengembalikan URL koneksi PostgreSQL; user dan password di-escape
+func (c DBConfig) ConnectionString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// AuthConfig mengatur token JWT untuk login
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	TokenTTL  time.Duration `yaml:"token_ttl" env:"JWT_TOKEN_TTL"`
+}
+
+// NotificationsConfig mengatur kanal pengiriman notifikasi dan worker pengirimnya
+type NotificationsConfig struct {
+	Twilio  TwilioConfig            `yaml:"twilio"`
+	SMTP    SMTPConfig              `yaml:"smtp"`
+	Outbox  OutboxConfig            `yaml:"outbox"`
+	Webhook WebhookConfig           `yaml:"webhook"`
+	Rules   NotificationRulesConfig `yaml:"rules"`
 }
 
 // TwilioConfig menghubungkan API dari WhatsApp
 type TwilioConfig struct {
-	AccountSID     string
-	AuthToken      string
-	WhatsAppNumber string
+	AccountSID   string `yaml:"account_sid" env:"TWILIO_ACCOUNT_SID"`
+	AuthToken    string `yaml:"auth_token" env:"TWILIO_AUTH_TOKEN" secret:"true"`
+	WhatsAppFrom string `yaml:"whatsapp_from" env:"TWILIO_WHATSAPP_FROM"`
 }
 
-// LoadTwilioConfig memanggil isi dari file .env
-var Twilio TwilioConfig
+// Enabled bernilai true jika akun Twilio diisi
+func (c TwilioConfig) Enabled() bool {
+	return c.AccountSID != "" || c.AuthToken != "" || c.WhatsAppFrom != ""
+}
 
-func LoadTwilioConfig() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file")
+// SMTPConfig mengatur server SMTP untuk notifikasi email
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+// OutboxConfig mengatur worker outbox notifikasi. Jeda percobaan ulang berlipat dua dari
+// RetryBaseDelay sampai RetryMaxDelay.
+type OutboxConfig struct {
+	Interval       time.Duration `yaml:"interval" env:"NOTIFY_OUTBOX_INTERVAL"`
+	BatchSize      int           `yaml:"batch_size" env:"NOTIFY_OUTBOX_BATCH_SIZE"`
+	MaxAttempts    int           `yaml:"max_attempts" env:"NOTIFY_MAX_ATTEMPTS"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" env:"NOTIFY_RETRY_BASE_DELAY"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay" env:"NOTIFY_RETRY_MAX_DELAY"`
+}
+
+// Worker mengembalikan pengaturan untuk notify.NewWorker
+func (c OutboxConfig) Worker() notify.WorkerConfig {
+	return notify.WorkerConfig{
+		Interval:    c.Interval,
+		BatchSize:   c.BatchSize,
+		MaxAttempts: c.MaxAttempts,
+		BaseDelay:   c.RetryBaseDelay,
+		MaxDelay:    c.RetryMaxDelay,
 	}
+}
+
+// WebhookConfig mengatur pengiriman webhook keluar
+type WebhookConfig struct {
+	Interval       time.Duration `yaml:"interval" env:"WEBHOOK_INTERVAL"`
+	BatchSize      int           `yaml:"batch_size" env:"WEBHOOK_BATCH_SIZE"`
+	MaxAttempts    int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" env:"WEBHOOK_RETRY_BASE_DELAY"`
+	RetryMaxDelay  time.Duration `yaml:"retry_max_delay" env:"WEBHOOK_RETRY_MAX_DELAY"`
+	Timeout        time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+}
 
-	Twilio = TwilioConfig{
-		AccountSID:     os.Getenv("TWILIO_ACCOUNT_SID"),
-		AuthToken:      os.Getenv("TWILIO_AUTH_TOKEN"),
-		WhatsAppNumber: os.Getenv("TWILIO_WHATSAPP_NUMBER"),
+// Worker mengembalikan pengaturan untuk webhook.NewWorker
+func (c WebhookConfig) Worker() webhook.Config {
+	return webhook.Config{
+		Interval:    c.Interval,
+		BatchSize:   c.BatchSize,
+		MaxAttempts: c.MaxAttempts,
+		BaseDelay:   c.RetryBaseDelay,
+		MaxDelay:    c.RetryMaxDelay,
+		Timeout:     c.Timeout,
 	}
 }
 
-// LoadConfig memanggil isi dari file .env
-func LoadConfig() *Config {
+// NotificationRulesConfig mengatur peran penerima dan kanal setiap event notifikasi, misalnya
+// NOTIFY_ARTICLE_SUBMITTED_CHANNELS="in_app,email"
+type NotificationRulesConfig struct {
+	ArticleSubmitted RuleConfig `yaml:"article_submitted" env:"NOTIFY_ARTICLE_SUBMITTED_"`
+	VideoSubmitted   RuleConfig `yaml:"video_submitted" env:"NOTIFY_VIDEO_SUBMITTED_"`
+}
+
+// RuleConfig adalah aturan routing satu event notifikasi
+type RuleConfig struct {
+	Roles    []string `yaml:"roles" env:"ROLES"`       // Peran pengguna yang menerima notifikasi
+	Channels []string `yaml:"channels" env:"CHANNELS"` // in_app, whatsapp dan/atau email
+}
+
+// Router mengembalikan aturan routing untuk notify.NewRouter
+func (c NotificationRulesConfig) Router() map[string]notify.Rule {
+	rules := notify.DefaultRules()
+	for event, rule := range c.byEvent() {
+		rules[event] = notify.Rule(rule)
+	}
+	return rules
+}
+
+// byEvent memetakan nama event notifikasi ke aturannya
+func (c NotificationRulesConfig) byEvent() map[string]RuleConfig {
+	return map[string]RuleConfig{
+		notify.EventArticleSubmitted: c.ArticleSubmitted,
+		notify.EventVideoSubmitted:   c.VideoSubmitted,
+	}
+}
+
+// StorageConfig mengatur penyimpanan file unggahan
+type StorageConfig struct {
+	UploadDir string `yaml:"upload_dir" env:"UPLOAD_DIR"`
+}
+
+// RateLimitConfig membatasi jumlah request per IP client untuk endpoint publik yang rawan disalahgunakan.
+// Batas 0 mematikan pembatasan.
+type RateLimitConfig struct {
+	CommentReports       int           `yaml:"comment_reports" env:"RATE_LIMIT_COMMENT_REPORTS"`
+	CommentReportsWindow time.Duration `yaml:"comment_reports_window" env:"RATE_LIMIT_COMMENT_REPORTS_WINDOW"`
+	// Pencarian appointment dengan email dan kode referensi, dibatasi agar kode tidak bisa ditebak
+	AppointmentLookups       int           `yaml:"appointment_lookups" env:"RATE_LIMIT_APPOINTMENT_LOOKUPS"`
+	AppointmentLookupsWindow time.Duration `yaml:"appointment_lookups_window" env:"RATE_LIMIT_APPOINTMENT_LOOKUPS_WINDOW"`
+	// Pendaftaran webinar publik, dibatasi agar kuota kursi tidak dihabiskan pendaftaran palsu
+	WebinarRegistrations       int           `yaml:"webinar_registrations" env:"RATE_LIMIT_WEBINAR_REGISTRATIONS"`
+	WebinarRegistrationsWindow time.Duration `yaml:"webinar_registrations_window" env:"RATE_LIMIT_WEBINAR_REGISTRATIONS_WINDOW"`
+}
+
+// ModerationConfig mengatur moderasi komentar otomatis
+type ModerationConfig struct {
+	ApproveBelow    float64       `yaml:"approve_below" env:"MODERATION_APPROVE_BELOW"` // Skor di bawah nilai ini otomatis disetujui
+	RejectAt        float64       `yaml:"reject_at" env:"MODERATION_REJECT_AT"`         // Skor mulai nilai ini otomatis ditolak
+	MaxLinks        int           `yaml:"max_links" env:"MODERATION_MAX_LINKS"`
+	RateLimit       int           `yaml:"rate_limit" env:"MODERATION_RATE_LIMIT"` // Komentar per email/IP; 0 mematikan pembatasan
+	RateLimitWindow time.Duration `yaml:"rate_limit_window" env:"MODERATION_RATE_WINDOW"`
+	DuplicateWindow time.Duration `yaml:"duplicate_window" env:"MODERATION_DUPLICATE_WINDOW"`
+	ReportThreshold int           `yaml:"report_threshold" env:"MODERATION_REPORT_THRESHOLD"` // Laporan pembaca sebelum komentar disembunyikan
+	// Kata terlarang tambahan di luar daftar bawaan, misalnya MODERATION_BANNED_WORDS_ID="kata1,kata2"
+	BannedWordsID []string `yaml:"banned_words_id" env:"MODERATION_BANNED_WORDS_ID"`
+	BannedWordsEN []string `yaml:"banned_words_en" env:"MODERATION_BANNED_WORDS_EN"`
+}
+
+// Pipeline mengembalikan pengaturan untuk moderation.NewDefaultPipeline
+func (c ModerationConfig) Pipeline() moderation.Config {
+	cfg := moderation.DefaultConfig()
+	cfg.ApproveBelow = c.ApproveBelow
+	cfg.RejectAt = c.RejectAt
+	cfg.MaxLinks = c.MaxLinks
+	cfg.RateLimit = c.RateLimit
+	cfg.RateWindow = c.RateLimitWindow
+	cfg.DuplicateWindow = c.DuplicateWindow
+	cfg.ReportThreshold = c.ReportThreshold
+	cfg.BannedWords["id"] = append(cfg.BannedWords["id"], c.BannedWordsID...)
+	cfg.BannedWords["en"] = append(cfg.BannedWords["en"], c.BannedWordsEN...)
+	return cfg
+}
+
+// AppointmentsConfig mengatur penentuan host, batas pembatalan dan pengingat appointment
+type AppointmentsConfig struct {
+	// Strategi host otomatis: round_robin, least_loaded, specialty, atau kosong agar host dipilih manual
+	AssignmentStrategy string `yaml:"assignment_strategy" env:"APPOINTMENT_ASSIGNMENT_STRATEGY"`
+	// Batas waktu sebelum appointment dimulai di mana user tidak bisa lagi membatalkan atau menjadwal ulang sendiri
+	CancelCutoff time.Duration   `yaml:"cancel_cutoff" env:"APPOINTMENT_CANCEL_CUTOFF"`
+	Reminders    RemindersConfig `yaml:"reminders"`
+}
+
+// RemindersConfig mengatur job konfirmasi dan pengingat appointment dan webinar
+type RemindersConfig struct {
+	Interval    time.Duration   `yaml:"interval" env:"APPOINTMENT_REMINDER_INTERVAL"`
+	Offsets     []time.Duration `yaml:"offsets" env:"APPOINTMENT_REMINDER_OFFSETS"` // Pengingat dikirim sebesar offset sebelum mulai, misalnya "24h,1h"
+	Channels    []string        `yaml:"channels" env:"APPOINTMENT_NOTIFICATION_CHANNELS"`
+	Lookback    time.Duration   `yaml:"lookback" env:"APPOINTMENT_NOTIFICATION_LOOKBACK"` // Booking lebih lama dari ini tidak dikonfirmasi lagi
+	BatchSize   int             `yaml:"batch_size" env:"APPOINTMENT_NOTIFICATION_BATCH_SIZE"`
+	MaxAttempts int             `yaml:"max_attempts" env:"APPOINTMENT_NOTIFICATION_MAX_ATTEMPTS"`
+}
+
+// Job mengembalikan pengaturan untuk reminder.NewJob
+func (c RemindersConfig) Job() reminder.Config {
+	return reminder.Config{
+		Interval:    c.Interval,
+		Offsets:     c.Offsets,
+		Channels:    c.Channels,
+		Lookback:    c.Lookback,
+		BatchSize:   c.BatchSize,
+		MaxAttempts: c.MaxAttempts,
+	}
+}
+
+// CalendarConfig mengatur ekspor iCalendar dan pembuatan link meeting otomatis
+type CalendarConfig struct {
+	UIDDomain       string `yaml:"uid_domain" env:"APP_DOMAIN"`             // Domain pada UID event iCalendar
+	MeetingProvider string `yaml:"meeting_provider" env:"MEETING_PROVIDER"` // jitsi, fake, atau kosong agar link diisi manual
+	MeetingBaseURL  string `yaml:"meeting_base_url" env:"MEETING_BASE_URL"` // Alamat server meeting; kosong memakai https://meet.jit.si untuk jitsi
+}
+
+// Default mengembalikan konfigurasi bawaan sebelum file YAML dan environment dibaca
+func Default() *Config {
+	outbox := notify.DefaultWorkerConfig()
+	hooks := webhook.DefaultConfig()
+	rules := notify.DefaultRules()
+	moderationCfg := moderation.DefaultConfig()
+	reminders := reminder.DefaultConfig()
 	return &Config{
-		DBHost:     os.Getenv("DB_HOST"),
-		DBPort:     os.Getenv("DB_PORT"),
-		DBUser:     os.Getenv("DB_USER"),
-		DBPassword: os.Getenv("DB_PASSWORD"),
-		DBName:     os.Getenv("DB_NAME"),
+		Server: ServerConfig{
+			Port:              8081,
+			BaseURL:           "http://localhost:8081",
+			Timezone:          "Asia/Jakarta",
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		DB: DBConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnectTimeout:  5 * time.Second,
+			AutoMigrate:     true,
+		},
+		Auth: AuthConfig{TokenTTL: 24 * time.Hour},
+		Notifications: NotificationsConfig{
+			SMTP: SMTPConfig{Port: 587},
+			Outbox: OutboxConfig{
+				Interval:       outbox.Interval,
+				BatchSize:      outbox.BatchSize,
+				MaxAttempts:    outbox.MaxAttempts,
+				RetryBaseDelay: outbox.BaseDelay,
+				RetryMaxDelay:  outbox.MaxDelay,
+			},
+			Webhook: WebhookConfig{
+				Interval:       hooks.Interval,
+				BatchSize:      hooks.BatchSize,
+				MaxAttempts:    hooks.MaxAttempts,
+				RetryBaseDelay: hooks.BaseDelay,
+				RetryMaxDelay:  hooks.MaxDelay,
+				Timeout:        hooks.Timeout,
+			},
+			Rules: NotificationRulesConfig{
+				ArticleSubmitted: RuleConfig(rules[notify.EventArticleSubmitted]),
+				VideoSubmitted:   RuleConfig(rules[notify.EventVideoSubmitted]),
+			},
+		},
+		Storage: StorageConfig{UploadDir: "uploads"},
+		RateLimit: RateLimitConfig{
+			CommentReports:             10,
+			CommentReportsWindow:       time.Hour,
+			AppointmentLookups:         10,
+			AppointmentLookupsWindow:   15 * time.Minute,
+			WebinarRegistrations:       5,
+			WebinarRegistrationsWindow: time.Hour,
+		},
+		Moderation: ModerationConfig{
+			ApproveBelow:    moderationCfg.ApproveBelow,
+			RejectAt:        moderationCfg.RejectAt,
+			MaxLinks:        moderationCfg.MaxLinks,
+			RateLimit:       moderationCfg.RateLimit,
+			RateLimitWindow: moderationCfg.RateWindow,
+			DuplicateWindow: moderationCfg.DuplicateWindow,
+			ReportThreshold: moderationCfg.ReportThreshold,
+		},
+		Appointments: AppointmentsConfig{
+			CancelCutoff: appointment.DefaultCancelCutoff,
+			Reminders: RemindersConfig{
+				Interval:    reminders.Interval,
+				Offsets:     reminders.Offsets,
+				Channels:    reminders.Channels,
+				Lookback:    reminders.Lookback,
+				BatchSize:   reminders.BatchSize,
+				MaxAttempts: reminders.MaxAttempts,
+			},
+		},
+		Calendar: CalendarConfig{UIDDomain: "go-project.local"},
 	}
 }
 
-func (c *Config) GetDBConnectionString() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName)
+// Location mengembalikan zona waktu aplikasi untuk jadwal appointment (server.timezone, bawaan Asia/Jakarta)
+func Location() *time.Location {
+	name := Current().Server.Timezone
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("Invalid APP_TIMEZONE %q, using UTC: %v", name, err)
+		return time.UTC
+	}
+	return loc
+}
+
+// BaseURL mengembalikan alamat publik aplikasi untuk link yang dikirim ke pengguna (server.base_url)
+func BaseURL() string {
+	return strings.TrimRight(Current().Server.BaseURL, "/")
+}
+
+// splitList memecah nilai dipisahkan koma; mengembalikan nil jika kosong
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func validConfig() *Config {
+	cfg := Default()
+	cfg.DB.User = "postgres"
+	cfg.DB.Name = "go_project"
+	cfg.Auth.JWTSecret = testSecret
+	return cfg
+}
+
+func TestDefaultIsValidWithRequiredValues(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string // awal pesan masalah yang diharapkan
+	}{
+		{"missing secret", func(c *Config) { c.Auth.JWTSecret = "" }, "auth.jwt_secret (JWT_SECRET) is required"},
+		{"short secret", func(c *Config) { c.Auth.JWTSecret = "short" }, "auth.jwt_secret (JWT_SECRET) must be at least 32 characters"},
+		{"port out of range", func(c *Config) { c.Server.Port = 70000 }, "server.port (SERVER_PORT) must be between 1 and 65535"},
+		{"relative base url", func(c *Config) { c.Server.BaseURL = "localhost:8081" }, "server.base_url (APP_BASE_URL) must be an absolute http or https URL"},
+		{"unknown timezone", func(c *Config) { c.Server.Timezone = "Mars/Olympus" }, "server.timezone (APP_TIMEZONE) must be an IANA time zone"},
+		{"bad trusted proxy", func(c *Config) { c.Server.TrustedProxies = []string{"not-an-ip"} }, "server.trusted_proxies (TRUSTED_PROXIES) must be a list"},
+		{"missing db user", func(c *Config) { c.DB.User = " " }, "db.user (DB_USER) is required"},
+		{"bad sslmode", func(c *Config) { c.DB.SSLMode = "on" }, "db.sslmode (DB_SSLMODE) must be one of"},
+		{"idle above open", func(c *Config) { c.DB.MaxOpenConns, c.DB.MaxIdleConns = 5, 10 }, "db.max_idle_conns (DB_MAX_IDLE_CONNS) must not exceed"},
+		{"partial twilio", func(c *Config) { c.Notifications.Twilio.AccountSID = "AC123" }, "notifications.twilio.auth_token (TWILIO_AUTH_TOKEN) is required"},
+		{"smtp without from", func(c *Config) { c.Notifications.SMTP.Host = "smtp.example.com" }, "notifications.smtp.from (SMTP_FROM) is required"},
+		{"outbox max below base", func(c *Config) { c.Notifications.Outbox.RetryMaxDelay = time.Second }, "notifications.outbox.retry_max_delay (NOTIFY_RETRY_MAX_DELAY) must not be less than"},
+		{"webhook without timeout", func(c *Config) { c.Notifications.Webhook.Timeout = 0 }, "notifications.webhook.timeout (WEBHOOK_TIMEOUT) must be greater than zero"},
+		{"rate limit without window", func(c *Config) { c.RateLimit.CommentReportsWindow = 0 }, "rate_limit.comment_reports_window (RATE_LIMIT_COMMENT_REPORTS_WINDOW) must be greater than zero"},
+		{"negative rate limit", func(c *Config) { c.RateLimit.AppointmentLookups = -1 }, "rate_limit.appointment_lookups (RATE_LIMIT_APPOINTMENT_LOOKUPS) must not be negative"},
+		{"unknown strategy", func(c *Config) { c.Appointments.AssignmentStrategy = "random" }, "appointments.assignment_strategy (APPOINTMENT_ASSIGNMENT_STRATEGY) must be one of"},
+		{"approve above reject", func(c *Config) { c.Moderation.ApproveBelow = 1.5 }, "moderation.approve_below (MODERATION_APPROVE_BELOW) must be between 0 and reject_at"},
+		{"moderation rate limit without window", func(c *Config) { c.Moderation.RateLimitWindow = 0 }, "moderation.rate_limit_window (MODERATION_RATE_WINDOW) must be greater than zero"},
+		{"no report threshold", func(c *Config) { c.Moderation.ReportThreshold = 0 }, "moderation.report_threshold (MODERATION_REPORT_THRESHOLD) must be greater than zero"},
+		{"unknown rule role", func(c *Config) { c.Notifications.Rules.ArticleSubmitted.Roles = []string{"editor"} }, "notifications.rules.article_submitted.roles (NOTIFY_ARTICLE_SUBMITTED_ROLES) must only contain"},
+		{"empty rule channels", func(c *Config) { c.Notifications.Rules.VideoSubmitted.Channels = nil }, "notifications.rules.video_submitted.channels (NOTIFY_VIDEO_SUBMITTED_CHANNELS) must not be empty"},
+		{"negative cancel cutoff", func(c *Config) { c.Appointments.CancelCutoff = -time.Hour }, "appointments.cancel_cutoff (APPOINTMENT_CANCEL_CUTOFF) must not be negative"},
+		{"non-positive reminder offset", func(c *Config) { c.Appointments.Reminders.Offsets = []time.Duration{0} }, "appointments.reminders.offsets (APPOINTMENT_REMINDER_OFFSETS) must only contain durations greater than zero"},
+		{"unknown reminder channel", func(c *Config) { c.Appointments.Reminders.Channels = []string{"sms"} }, "appointments.reminders.channels (APPOINTMENT_NOTIFICATION_CHANNELS) must only contain"},
+		{"unknown meeting provider", func(c *Config) { c.Calendar.MeetingProvider = "zoom" }, "calendar.meeting_provider (MEETING_PROVIDER) must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+			var verr *ValidationError
+			if err := cfg.Validate(); !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want *ValidationError", err)
+			}
+			for _, problem := range verr.Problems {
+				if strings.HasPrefix(problem, tt.want) {
+					return
+				}
+			}
+			t.Errorf("problems %q do not include %q", verr.Problems, tt.want)
+		})
+	}
+}
+
+func TestValidateCollectsAllProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.JWTSecret = ""
+	cfg.DB.Port = 0
+	cfg.Storage.UploadDir = ""
+
+	var verr *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &verr) {
+		t.Fatalf("Validate() = %v, want *ValidationError", err)
+	}
+	if len(verr.Problems) != 3 {
+		t.Errorf("got %d problems, want 3: %q", len(verr.Problems), verr.Problems)
+	}
+}
+
+func TestApplyEnvOverridesAndRejectsInvalidValues(t *testing.T) {
+	t.Setenv("SERVER_PORT", "9090")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 127.0.0.1")
+	t.Setenv("JWT_TOKEN_TTL", "2h")
+
+	cfg := Default()
+	if err := applyEnv(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != 9090 || cfg.Auth.TokenTTL != 2*time.Hour {
+		t.Errorf("port = %d, token ttl = %s; want 9090 and 2h", cfg.Server.Port, cfg.Auth.TokenTTL)
+	}
+	if strings.Join(cfg.Server.TrustedProxies, ",") != "10.0.0.0/8,127.0.0.1" {
+		t.Errorf("trusted proxies = %q", cfg.Server.TrustedProxies)
+	}
+
+	t.Setenv("DB_PORT", "five")
+	if err := applyEnv(Default()); err == nil || !strings.Contains(err.Error(), "DB_PORT") {
+		t.Errorf("applyEnv error = %v, want it to name DB_PORT", err)
+	}
+}
+
+func TestExampleFileMatchesDefaults(t *testing.T) {
+	cfg := Default()
+	if err := readFile(cfg, "../config.example.yaml", true); err != nil {
+		t.Fatal(err)
+	}
+	want := Default()
+	// Daftar kosong di file terbaca sebagai slice kosong, bukan nil, jadi dibandingkan dari teksnya
+	if fmt.Sprint(cfg.Moderation) != fmt.Sprint(want.Moderation) {
+		t.Errorf("moderation = %+v, want %+v", cfg.Moderation, want.Moderation)
+	}
+	if !reflect.DeepEqual(cfg.Appointments.Reminders, want.Appointments.Reminders) || cfg.Appointments.CancelCutoff != want.Appointments.CancelCutoff {
+		t.Errorf("appointments = %+v, want %+v", cfg.Appointments, want.Appointments)
+	}
+	if !reflect.DeepEqual(cfg.Notifications.Rules, want.Notifications.Rules) {
+		t.Errorf("notification rules = %+v, want %+v", cfg.Notifications.Rules, want.Notifications.Rules)
+	}
+}
+
+func TestApplyEnvNestedSections(t *testing.T) {
+	t.Setenv("NOTIFY_ARTICLE_SUBMITTED_CHANNELS", "in_app,email")
+	t.Setenv("APPOINTMENT_REMINDER_OFFSETS", "48h, 2h")
+	t.Setenv("MODERATION_APPROVE_BELOW", "0.25")
+	t.Setenv("MODERATION_BANNED_WORDS_ID", "kata1, kata2")
+	t.Setenv("APPOINTMENT_CANCEL_CUTOFF", "12h")
+
+	cfg := validConfig()
+	if err := applyEnv(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := cfg.Notifications.Rules.Router()
+	if got := strings.Join(rules["article.submitted"].Channels, ","); got != "in_app,email" {
+		t.Errorf("article.submitted channels = %s, want in_app,email", got)
+	}
+	if got := strings.Join(rules["video.submitted"].Channels, ","); got != "in_app,whatsapp,email" {
+		t.Errorf("video.submitted channels = %s, want the default", got)
+	}
+	if offsets := cfg.Appointments.Reminders.Job().Offsets; !reflect.DeepEqual(offsets, []time.Duration{48 * time.Hour, 2 * time.Hour}) {
+		t.Errorf("reminder offsets = %v, want [48h 2h]", offsets)
+	}
+	if cfg.Appointments.CancelCutoff != 12*time.Hour {
+		t.Errorf("cancel cutoff = %s, want 12h", cfg.Appointments.CancelCutoff)
+	}
+
+	moderation := cfg.Moderation.Pipeline()
+	if moderation.ApproveBelow != 0.25 {
+		t.Errorf("approve below = %g, want 0.25", moderation.ApproveBelow)
+	}
+	// Kata tambahan melengkapi daftar bawaan, bukan menggantikannya
+	words := strings.Join(moderation.BannedWords["id"], ",")
+	if !strings.HasSuffix(words, ",kata1,kata2") || !strings.HasPrefix(words, "anjing,") {
+		t.Errorf("banned words id = %s", words)
+	}
+}
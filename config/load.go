@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultFile dibaca jika ada dan CONFIG_FILE tidak diisi
+	DefaultFile = "config.yaml"
+	// FileEnv adalah variabel environment untuk lokasi file YAML
+	FileEnv = "CONFIG_FILE"
+	// DotEnvFile dibaca ke environment jika ada; variabel yang sudah ada di environment tidak ditimpa
+	DotEnvFile = ".env"
+)
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	durationsType = reflect.TypeOf([]time.Duration(nil))
+)
+
+// active adalah konfigurasi dari Load terakhir yang dipakai Location, BaseURL dan Current
+var active atomic.Pointer[Config]
+
+// Load membaca konfigurasi (lihat Read), memvalidasinya lalu menjadikannya konfigurasi aktif.
+// Error validasi berisi semua masalah sekaligus agar bisa diperbaiki dalam satu kali jalan.
+func Load(file string) (*Config, error) {
+	cfg, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	active.Store(cfg)
+	return cfg, nil
+}
+
+// Read membaca konfigurasi tanpa validasi dengan urutan prioritas dari rendah ke tinggi: nilai bawaan,
+// file YAML, file .env, lalu environment. file kosong berarti CONFIG_FILE, atau config.yaml jika ada.
+// File yang disebut langsung atau lewat CONFIG_FILE wajib ada.
+func Read(file string) (*Config, error) {
+	// .env hanya mengisi variabel yang belum ada, sehingga environment proses tetap menang
+	if err := godotenv.Load(DotEnvFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", DotEnvFile, err)
+	}
+
+	cfg := Default()
+
+	required := true
+	if file == "" {
+		file = os.Getenv(FileEnv)
+	}
+	if file == "" {
+		file, required = DefaultFile, false
+	}
+	if err := readFile(cfg, file, required); err != nil {
+		return nil, err
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Current mengembalikan konfigurasi aktif dari Load. Jika Load belum dipanggil, dipakai nilai bawaan
+// ditambah environment dengan nilai yang tidak valid diabaikan.
+func Current() *Config {
+	if cfg := active.Load(); cfg != nil {
+		return cfg
+	}
+	cfg := Default()
+	applyEnv(cfg)
+	return cfg
+}
+
+// readFile mengisi cfg dari file YAML. Kunci yang tidak dikenal ditolak agar salah ketik tidak diam-diam diabaikan.
+func readFile(cfg *Config, file string, required bool) error {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", file, err)
+	}
+	return nil
+}
+
+// applyEnv menimpa cfg dengan variabel environment yang diisi. Nilai kosong dianggap tidak diisi.
+func applyEnv(cfg *Config) error {
+	var errs []error
+	for _, f := range fields(cfg) {
+		raw := os.Getenv(f.Env)
+		if f.Env == "" || raw == "" {
+			continue
+		}
+		if err := setValue(f.Value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.Env, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// field adalah satu nilai konfigurasi beserta kunci YAML bertitik (misalnya db.sslmode) dan nama variabel environment-nya
+type field struct {
+	Path   string
+	Env    string
+	Secret bool
+	Value  reflect.Value
+}
+
+// fields mengembalikan semua nilai konfigurasi di cfg sesuai urutan deklarasi
+func fields(cfg *Config) []field {
+	var out []field
+	walkFields(reflect.ValueOf(cfg).Elem(), "", "", &out)
+	return out
+}
+
+// walkFields menelusuri struct konfigurasi; tag env pada struct bertingkat menjadi awalan nama variabel di dalamnya
+func walkFields(v reflect.Value, prefix, envPrefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		path := sf.Tag.Get("yaml")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if sf.Type.Kind() == reflect.Struct {
+			walkFields(v.Field(i), path, envPrefix+sf.Tag.Get("env"), out)
+			continue
+		}
+		env := sf.Tag.Get("env")
+		if env != "" {
+			env = envPrefix + env
+		}
+		*out = append(*out, field{
+			Path:   path,
+			Env:    env,
+			Secret: sf.Tag.Get("secret") == "true",
+			Value:  v.Field(i),
+		})
+	}
+}
+
+// setValue mengisi v dari teks sesuai tipenya
+func setValue(v reflect.Value, raw string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, expected a value such as 30s, 5m or 1h", raw)
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case v.Type() == durationsType:
+		// Daftar durasi ditulis dipisahkan koma, misalnya APPOINTMENT_REMINDER_OFFSETS="24h,1h"
+		var durations []time.Duration
+		for _, item := range splitList(raw) {
+			d, err := time.ParseDuration(item)
+			if err != nil {
+				return fmt.Errorf("invalid duration %q, expected a value such as 30s, 5m or 1h", item)
+			}
+			durations = append(durations, d)
+		}
+		v.Set(reflect.ValueOf(durations))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		// Daftar ditulis dipisahkan koma, misalnya TRUSTED_PROXIES="10.0.0.0/8,127.0.0.1"
+		v.Set(reflect.ValueOf(splitList(raw)))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q, expected true or false", raw)
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
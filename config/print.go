@@ -0,0 +1,79 @@
+package config
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted menggantikan nilai rahasia yang diisi saat konfigurasi dicetak
+const redacted = "********"
+
+// Print menulis konfigurasi efektif sebagai YAML ke w dengan nama variabel environment sebagai komentar.
+// Nilai bertanda secret disamarkan; nilai kosong tetap ditampilkan kosong agar terlihat belum diisi.
+func (c *Config) Print(w io.Writer) error {
+	root, err := yamlNode(reflect.ValueOf(c).Elem(), "")
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// yamlNode menyusun mapping YAML dari struct konfigurasi dengan urutan sesuai deklarasi.
+// envPrefix adalah awalan nama variabel environment dari struct induknya.
+func yamlNode(v reflect.Value, envPrefix string) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: sf.Tag.Get("yaml")}
+
+		var value *yaml.Node
+		var err error
+		if sf.Type.Kind() == reflect.Struct {
+			value, err = yamlNode(v.Field(i), envPrefix+sf.Tag.Get("env"))
+		} else {
+			value, err = scalarNode(v.Field(i), sf.Tag.Get("secret") == "true")
+			if env := sf.Tag.Get("env"); err == nil && env != "" {
+				value.LineComment = envPrefix + env
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+func scalarNode(v reflect.Value, secret bool) (*yaml.Node, error) {
+	var value any = v.Interface()
+	switch {
+	case secret && v.String() != "":
+		value = redacted
+	case v.Type() == durationType:
+		value = time.Duration(v.Int()).String()
+	case v.Type() == durationsType:
+		durations := make([]string, 0, v.Len())
+		for _, d := range v.Interface().([]time.Duration) {
+			durations = append(durations, d.String())
+		}
+		value = durations
+	}
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	// Daftar ditulis satu baris agar komentar nama variabel environment tetap di baris kuncinya
+	if node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	return node, nil
+}
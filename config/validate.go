@@ -0,0 +1,275 @@
+package config
+
+import (
+	"fmt"
+	"go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"go-project/pkg/notify"
+	"go-project/pkg/reminder"
+	"go-project/pkg/utils"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sslModes adalah nilai sslmode yang diterima PostgreSQL
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// roles adalah peran pengguna yang bisa menerima notifikasi, sesuai constraint users_role_check
+var roles = []string{"admin", "staff", "user"}
+
+// minJWTSecretLength adalah panjang minimal secret JWT (256 bit untuk HS256)
+const minJWTSecretLength = 32
+
+// envNames memetakan kunci YAML bertitik ke nama variabel environment-nya untuk pesan error
+var envNames = sync.OnceValue(func() map[string]string {
+	names := map[string]string{}
+	for _, f := range fields(Default()) {
+		names[f.Path] = f.Env
+	}
+	return names
+})
+
+// ValidationError berisi semua masalah konfigurasi yang ditemukan saat validasi
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// problems mengumpulkan masalah validasi; setiap masalah menyebut kunci YAML dan variabel environment-nya
+type problems []string
+
+func (p *problems) add(path, format string, args ...any) {
+	name := path
+	if env := envNames()[path]; env != "" {
+		name += " (" + env + ")"
+	}
+	*p = append(*p, name+" "+fmt.Sprintf(format, args...))
+}
+
+func (p *problems) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		p.add(path, "is required")
+	}
+}
+
+func (p *problems) port(path string, value int) {
+	if value < 1 || value > 65535 {
+		p.add(path, "must be between 1 and 65535, got %d", value)
+	}
+}
+
+// oneOf memeriksa bahwa daftar tidak kosong dan setiap nilainya ada di allowed
+func (p *problems) oneOf(path string, values, allowed []string) {
+	if len(values) == 0 {
+		p.add(path, "must not be empty")
+	}
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			p.add(path, "must only contain %s, got %q", strings.Join(allowed, ", "), value)
+		}
+	}
+}
+
+func (p *problems) positive(path string, value int64) {
+	if value <= 0 {
+		p.add(path, "must be greater than zero")
+	}
+}
+
+// rateLimit memeriksa batas request; jendela waktu wajib diisi jika batas aktif
+func (p *problems) rateLimit(path string, limit int, window time.Duration) {
+	if limit < 0 {
+		p.add(path, "must not be negative (0 disables the limit)")
+	}
+	if limit > 0 {
+		p.positive(path+"_window", int64(window))
+	}
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: p}
+}
+
+// Validate memeriksa seluruh konfigurasi dan mengembalikan *ValidationError berisi semua masalah
+func (c *Config) Validate() error {
+	var p problems
+	c.Server.validate(&p)
+	c.DB.validate(&p)
+	c.Auth.validate(&p)
+	c.Notifications.validate(&p)
+	p.required("storage.upload_dir", c.Storage.UploadDir)
+	p.rateLimit("rate_limit.comment_reports", c.RateLimit.CommentReports, c.RateLimit.CommentReportsWindow)
+	p.rateLimit("rate_limit.appointment_lookups", c.RateLimit.AppointmentLookups, c.RateLimit.AppointmentLookupsWindow)
+	p.rateLimit("rate_limit.webinar_registrations", c.RateLimit.WebinarRegistrations, c.RateLimit.WebinarRegistrationsWindow)
+	c.Moderation.validate(&p)
+	c.Appointments.validate(&p)
+	c.Calendar.validate(&p)
+	return p.err()
+}
+
+// Validate memeriksa konfigurasi database saja, untuk perintah yang hanya butuh koneksi database
+func (c DBConfig) Validate() error {
+	var p problems
+	c.validate(&p)
+	return p.err()
+}
+
+func (c ServerConfig) validate(p *problems) {
+	p.port("server.port", c.Port)
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.add("server.base_url", "must be an absolute http or https URL, got %q", c.BaseURL)
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		p.add("server.timezone", "must be an IANA time zone such as Asia/Jakarta, got %q", c.Timezone)
+	}
+	if c.ReadHeaderTimeout < 0 {
+		p.add("server.read_header_timeout", "must not be negative")
+	}
+	if _, err := utils.ParseNetworks(c.TrustedProxies); err != nil {
+		p.add("server.trusted_proxies", "must be a list of IP addresses or CIDR ranges: %v", err)
+	}
+}
+
+func (c DBConfig) validate(p *problems) {
+	p.required("db.host", c.Host)
+	p.port("db.port", c.Port)
+	p.required("db.user", c.User)
+	p.required("db.name", c.Name)
+	valid := false
+	for _, mode := range sslModes {
+		valid = valid || c.SSLMode == mode
+	}
+	if !valid {
+		p.add("db.sslmode", "must be one of %s, got %q", strings.Join(sslModes, ", "), c.SSLMode)
+	}
+	if c.MaxOpenConns < 0 {
+		p.add("db.max_open_conns", "must not be negative (0 means unlimited)")
+	}
+	if c.MaxIdleConns < 0 {
+		p.add("db.max_idle_conns", "must not be negative")
+	} else if c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns {
+		p.add("db.max_idle_conns", "must not exceed db.max_open_conns (%d), got %d", c.MaxOpenConns, c.MaxIdleConns)
+	}
+	if c.ConnMaxLifetime < 0 {
+		p.add("db.conn_max_lifetime", "must not be negative (0 means unlimited)")
+	}
+	p.positive("db.connect_timeout", int64(c.ConnectTimeout))
+}
+
+func (c AuthConfig) validate(p *problems) {
+	if c.JWTSecret == "" {
+		p.add("auth.jwt_secret", "is required")
+	} else if len(c.JWTSecret) < minJWTSecretLength {
+		p.add("auth.jwt_secret", "must be at least %d characters, got %d", minJWTSecretLength, len(c.JWTSecret))
+	}
+	p.positive("auth.token_ttl", int64(c.TokenTTL))
+}
+
+func (c NotificationsConfig) validate(p *problems) {
+	// Twilio boleh dikosongkan seluruhnya, tetapi jika dipakai semua nilainya wajib diisi
+	if c.Twilio.Enabled() {
+		p.required("notifications.twilio.account_sid", c.Twilio.AccountSID)
+		p.required("notifications.twilio.auth_token", c.Twilio.AuthToken)
+		p.required("notifications.twilio.whatsapp_from", c.Twilio.WhatsAppFrom)
+	}
+
+	p.port("notifications.smtp.port", c.SMTP.Port)
+	if c.SMTP.Host != "" || c.SMTP.From != "" {
+		p.required("notifications.smtp.host", c.SMTP.Host)
+		p.required("notifications.smtp.from", c.SMTP.From)
+	}
+
+	outbox := c.Outbox
+	p.positive("notifications.outbox.interval", int64(outbox.Interval))
+	p.positive("notifications.outbox.batch_size", int64(outbox.BatchSize))
+	p.positive("notifications.outbox.max_attempts", int64(outbox.MaxAttempts))
+	p.positive("notifications.outbox.retry_base_delay", int64(outbox.RetryBaseDelay))
+	if outbox.RetryMaxDelay < outbox.RetryBaseDelay {
+		p.add("notifications.outbox.retry_max_delay", "must not be less than retry_base_delay (%s), got %s", outbox.RetryBaseDelay, outbox.RetryMaxDelay)
+	}
+
+	hooks := c.Webhook
+	p.positive("notifications.webhook.interval", int64(hooks.Interval))
+	p.positive("notifications.webhook.batch_size", int64(hooks.BatchSize))
+	p.positive("notifications.webhook.max_attempts", int64(hooks.MaxAttempts))
+	p.positive("notifications.webhook.retry_base_delay", int64(hooks.RetryBaseDelay))
+	if hooks.RetryMaxDelay < hooks.RetryBaseDelay {
+		p.add("notifications.webhook.retry_max_delay", "must not be less than retry_base_delay (%s), got %s", hooks.RetryBaseDelay, hooks.RetryMaxDelay)
+	}
+	p.positive("notifications.webhook.timeout", int64(hooks.Timeout))
+
+	channels := []string{notify.ChannelInApp, notify.ChannelWhatsApp, notify.ChannelEmail}
+	for _, rule := range []struct {
+		path string
+		rule RuleConfig
+	}{
+		{"notifications.rules.article_submitted", c.Rules.ArticleSubmitted},
+		{"notifications.rules.video_submitted", c.Rules.VideoSubmitted},
+	} {
+		p.oneOf(rule.path+".roles", rule.rule.Roles, roles)
+		p.oneOf(rule.path+".channels", rule.rule.Channels, channels)
+	}
+}
+
+func (c ModerationConfig) validate(p *problems) {
+	if c.RejectAt <= 0 {
+		p.add("moderation.reject_at", "must be greater than zero")
+	}
+	if c.ApproveBelow < 0 || c.ApproveBelow > c.RejectAt {
+		p.add("moderation.approve_below", "must be between 0 and reject_at (%g), got %g", c.RejectAt, c.ApproveBelow)
+	}
+	if c.MaxLinks < 0 {
+		p.add("moderation.max_links", "must not be negative")
+	}
+	p.rateLimit("moderation.rate_limit", c.RateLimit, c.RateLimitWindow)
+	p.positive("moderation.duplicate_window", int64(c.DuplicateWindow))
+	p.positive("moderation.report_threshold", int64(c.ReportThreshold))
+}
+
+func (c AppointmentsConfig) validate(p *problems) {
+	if c.AssignmentStrategy != "" && !appointment.IsValidStrategy(c.AssignmentStrategy) {
+		p.add("appointments.assignment_strategy", "must be one of %s, %s, %s or empty, got %q",
+			appointment.StrategyRoundRobin, appointment.StrategyLeastLoaded, appointment.StrategySpecialty, c.AssignmentStrategy)
+	}
+	if c.CancelCutoff < 0 {
+		p.add("appointments.cancel_cutoff", "must not be negative (0 disables the cutoff)")
+	}
+
+	r := c.Reminders
+	p.positive("appointments.reminders.interval", int64(r.Interval))
+	for _, offset := range r.Offsets {
+		if offset <= 0 {
+			p.add("appointments.reminders.offsets", "must only contain durations greater than zero, got %s", offset)
+		}
+	}
+	p.oneOf("appointments.reminders.channels", r.Channels, []string{reminder.ChannelWhatsApp, reminder.ChannelEmail})
+	if r.Lookback < 0 {
+		p.add("appointments.reminders.lookback", "must not be negative")
+	}
+	p.positive("appointments.reminders.batch_size", int64(r.BatchSize))
+	p.positive("appointments.reminders.max_attempts", int64(r.MaxAttempts))
+}
+
+func (c CalendarConfig) validate(p *problems) {
+	p.required("calendar.uid_domain", c.UIDDomain)
+	switch c.MeetingProvider {
+	case "", calendar.ProviderJitsi, calendar.ProviderFake:
+	default:
+		p.add("calendar.meeting_provider", "must be one of %s, %s or empty, got %q",
+			calendar.ProviderJitsi, calendar.ProviderFake, c.MeetingProvider)
+	}
+	if c.MeetingBaseURL != "" {
+		if u, err := url.Parse(c.MeetingBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			p.add("calendar.meeting_base_url", "must be an absolute http or https URL, got %q", c.MeetingBaseURL)
+		}
+	}
+}
@@ -4,11 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"os"
-	"path/filepath"
-	"time"
 
 	"go-project/config"
 
@@ -17,55 +13,30 @@ import (
 
 var DB *sql.DB
 
-func ConnectDB(cfg *config.Config) {
-	var err error
-	DB, err = sql.Open("pgx", cfg.GetDBConnectionString())
+// ConnectDB membuka pool koneksi sesuai cfg dan memastikan database bisa dihubungi
+func ConnectDB(cfg config.DBConfig) error {
+	conn, err := sql.Open("pgx", cfg.ConnectionString())
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Set database connection pool parameters
-	DB.SetMaxOpenConns(25)
-	DB.SetMaxIdleConns(25)
-	DB.SetConnMaxLifetime(5 * time.Minute)
+	conn.SetMaxOpenConns(cfg.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
 
-	if err = DB.PingContext(ctx); err != nil {
-		log.Fatalf("Database connection error: %v", err)
+	if err = conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return fmt.Errorf("database connection error (%s:%d/%s): %w", cfg.Host, cfg.Port, cfg.Name, err)
 	}
 
+	DB = conn
 	log.Println("Connected to PostgreSQL database successfully!")
-}
-
-func RunMigrations(migrationsDir string) {
-
-	files, err := ioutil.ReadDir(migrationsDir)
-	if err != nil {
-		log.Fatalf("Gagal untuk membaca migrations %v", err)
-	}
-
-	// run sql
-	for _, file := range files {
-		if file.IsDir() || filepath.Ext(file.Name()) != ".sql" {
-			continue
-		}
-
-		filepath := filepath.Join(migrationsDir, file.Name())
-		content, err := os.ReadFile(filepath)
-		if err != nil {
-			log.Fatalf("Gagal membaca migration file %v", file.Name(), err)
-		}
-
-		fmt.Println("Running Migrations %s: %v", file.Name(), err)
-		_, err = DB.Exec(string(content))
-		if err != nil {
-			log.Fatalf("Failed execute migration %s: %v", file.Name(), err)
-		}
-		fmt.Println("Migration success !", file.Name())
-	}
+	return nil
 }
 
 func SaveUser(email, hashedPassword, role string) error {
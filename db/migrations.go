@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"log"
+
+	"go-project/pkg/migrate"
+)
+
+// MigrationsDir adalah lokasi file migrasi relatif terhadap root repository, dipakai "migrate create"
+const MigrationsDir = "db/migrations"
+
+// migrationFiles berisi semua migrasi bernomor sehingga binary bisa menjalankan migrasi tanpa file di disk
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator membuat Migrator untuk migrasi yang di-embed pada koneksi DB. timezone adalah zona waktu
+// aplikasi (server.timezone) yang dipakai migrasi untuk menafsirkan waktu lokal lewat app.timezone.
+func NewMigrator(timezone string) (*migrate.Migrator, error) {
+	m, err := migrate.New(DB, migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	m.Logf = log.Printf
+	m.Settings = map[string]string{"app.timezone": timezone}
+	return m, nil
+}
+
+// RunMigrations menerapkan semua migrasi yang belum dijalankan
+func RunMigrations(ctx context.Context, timezone string) error {
+	m, err := NewMigrator(timezone)
+	if err != nil {
+		return err
+	}
+	_, err = m.Up(ctx, 0)
+	return err
+}
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "videos";
+DROP TABLE IF EXISTS "webinars";
+DROP TABLE IF EXISTS "appointments";
+DROP TABLE IF EXISTS "testimonials";
+DROP TABLE IF EXISTS "visits";
+DROP TABLE IF EXISTS "comments";
+DROP TABLE IF EXISTS "articles_views";
+DROP TABLE IF EXISTS "articles";
+DROP TABLE IF EXISTS "categories";
+DROP TABLE IF EXISTS "users";
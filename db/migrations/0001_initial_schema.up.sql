@@ -1,5 +1,8 @@
+-- Skema awal. Dibuat dengan IF NOT EXISTS agar database yang sudah berisi skema ini sebelum migrasi
+-- bernomor dipakai bisa langsung ditandai sudah menjalankan migrasi ini.
+
 -- Tabel Users
-CREATE TABLE "users" (
+CREATE TABLE IF NOT EXISTS "users" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "employee_id" varchar,
   "name" varchar,
@@ -16,7 +19,7 @@ CREATE TABLE "users" (
 );
 
 -- Tabel Categories
-CREATE TABLE "categories" (
+CREATE TABLE IF NOT EXISTS "categories" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "name" varchar,
   "created_at" timestamp DEFAULT (now()),
@@ -24,9 +27,9 @@ CREATE TABLE "categories" (
 );
 
 -- Tabel Articles
-CREATE TABLE "articles" (
+CREATE TABLE IF NOT EXISTS "articles" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
-  "category_id" integer,
+  "category_id" integer REFERENCES "categories" ("id"),
   "title" varchar,
   "slug" varchar,
   "tags" json,
@@ -42,35 +45,35 @@ CREATE TABLE "articles" (
   "status" varchar CHECK (status IN ('published', 'draft', 'archived')),
   "meta_title" varchar,
   "meta_description" varchar,
-  "author_id" integer,
+  "author_id" integer REFERENCES "users" ("id"),
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
 
 -- Tabel Articles Views
-CREATE TABLE "articles_views" (
+CREATE TABLE IF NOT EXISTS "articles_views" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
-  "article_id" integer,
+  "article_id" integer REFERENCES "articles" ("id"),
   "ip_address" varchar,
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
 
 -- Tabel Comments
-CREATE TABLE "comments" (
+CREATE TABLE IF NOT EXISTS "comments" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
-  "article_id" integer,
+  "article_id" integer REFERENCES "articles" ("id"),
   "username" varchar,
   "email" varchar,
   "comment" varchar,
-  "parent_id" integer,
+  "parent_id" integer REFERENCES "comments" ("id"),
   "status" varchar CHECK (status IN ('approved', 'pending', 'rejected')),
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
 
 -- Tabel Visits
-CREATE TABLE "visits" (
+CREATE TABLE IF NOT EXISTS "visits" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "ip_address" varchar,
   "url" varchar,
@@ -79,18 +82,18 @@ CREATE TABLE "visits" (
 );
 
 -- Tabel Testimonials
-CREATE TABLE "testimonials" (
+CREATE TABLE IF NOT EXISTS "testimonials" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "name" varchar,
   "comment" varchar,
   "photo_profile" varchar,
-  "category_id" integer,
+  "category_id" integer REFERENCES "categories" ("id"),
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
 
 -- Tabel Appointments
-CREATE TABLE "appointments" (
+CREATE TABLE IF NOT EXISTS "appointments" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "name" varchar,
   "phone_number" varchar,
@@ -98,7 +101,7 @@ CREATE TABLE "appointments" (
   "date_of_booking" date,
   "time" timestamp,
   "link_meet" varchar,
-  "host_id" integer,
+  "host_id" integer REFERENCES "users" ("id"),
   "pdf_file" varchar,
   "img" varchar,
   "status" varchar CHECK (status IN ('confirmed', 'pending', 'cancelled')),
@@ -107,23 +110,23 @@ CREATE TABLE "appointments" (
 );
 
 -- Tabel Webinars
-CREATE TABLE "webinars" (
+CREATE TABLE IF NOT EXISTS "webinars" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "title" varchar,
   "description" varchar,
   "link_meet" varchar,
-  "host_id" integer,
+  "host_id" integer REFERENCES "users" ("id"),
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
 
 -- Tabel Videos
-CREATE TABLE "videos" (
+CREATE TABLE IF NOT EXISTS "videos" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
   "title" varchar,
   "description" varchar,
   "link_video" varchar,
-  "category_id" integer,
+  "category_id" integer REFERENCES "categories" ("id"),
   "meta_title" varchar,
   "meta_description" varchar,
   "created_at" timestamp DEFAULT (now()),
@@ -131,24 +134,12 @@ CREATE TABLE "videos" (
 );
 
 -- Tabel Notifications
-CREATE TABLE "notifications" (
+CREATE TABLE IF NOT EXISTS "notifications" (
   "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
-  "user_id" integer,
+  "user_id" integer REFERENCES "users" ("id"),
   "type" varchar,
   "message" text,
   "status" varchar CHECK (status IN ('unread', 'read')),
   "created_at" timestamp DEFAULT (now()),
   "updated_at" timestamp DEFAULT (now())
 );
-
--- Relasi Foreign Key
-ALTER TABLE "articles" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
-ALTER TABLE "articles" ADD FOREIGN KEY ("author_id") REFERENCES "users" ("id");
-ALTER TABLE "articles_views" ADD FOREIGN KEY ("article_id") REFERENCES "articles" ("id");
-ALTER TABLE "comments" ADD FOREIGN KEY ("article_id") REFERENCES "articles" ("id");
-ALTER TABLE "comments" ADD FOREIGN KEY ("parent_id") REFERENCES "comments" ("id");
-ALTER TABLE "testimonials" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
-ALTER TABLE "videos" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
-ALTER TABLE "notifications" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
-ALTER TABLE "appointments" ADD FOREIGN KEY ("host_id") REFERENCES "users" ("id");
-ALTER TABLE "webinars" ADD FOREIGN KEY ("host_id") REFERENCES "users" ("id");
\ No newline at end of file
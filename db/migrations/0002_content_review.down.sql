@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "idx_videos_author_id";
+DROP INDEX IF EXISTS "idx_articles_author_id";
+DROP TABLE IF EXISTS "content_reviews";
+
+ALTER TABLE "videos" DROP COLUMN IF EXISTS "author_id";
+ALTER TABLE "videos" DROP COLUMN IF EXISTS "status";
+
+-- Artikel yang masih dalam alur review dikembalikan ke draft
+ALTER TABLE "articles" DROP CONSTRAINT IF EXISTS "articles_status_check";
+UPDATE "articles" SET "status" = 'draft' WHERE "status" NOT IN ('published', 'draft', 'archived');
+ALTER TABLE "articles" ADD CONSTRAINT "articles_status_check" CHECK (status IN ('published', 'draft', 'archived'));
@@ -0,0 +1,22 @@
+-- Status artikel & video mengikuti alur review staff -> admin
+ALTER TABLE "articles" DROP CONSTRAINT IF EXISTS "articles_status_check";
+ALTER TABLE "articles" ADD CONSTRAINT "articles_status_check"
+  CHECK (status IN ('pending approval', 'changes requested', 'approval', 'rejected', 'withdrawn', 'published', 'draft', 'archived'));
+
+ALTER TABLE "videos" ADD COLUMN IF NOT EXISTS "status" varchar DEFAULT 'pending approval';
+ALTER TABLE "videos" ADD COLUMN IF NOT EXISTS "author_id" integer REFERENCES "users" ("id");
+
+-- Tabel Content Reviews (riwayat feedback reviewer untuk artikel & video)
+CREATE TABLE IF NOT EXISTS "content_reviews" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "content_type" varchar NOT NULL CHECK (content_type IN ('article', 'video')),
+  "content_id" integer NOT NULL,
+  "reviewer_id" integer REFERENCES "users" ("id"),
+  "status" varchar NOT NULL,
+  "feedback" text,
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_content_reviews_content" ON "content_reviews" ("content_type", "content_id");
+CREATE INDEX IF NOT EXISTS "idx_articles_author_id" ON "articles" ("author_id");
+CREATE INDEX IF NOT EXISTS "idx_videos_author_id" ON "videos" ("author_id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_comments_parent_id";
+DROP INDEX IF EXISTS "idx_comments_article_status";
@@ -0,0 +1,3 @@
+-- Index untuk mengambil thread komentar yang sudah disetujui per artikel
+CREATE INDEX IF NOT EXISTS "idx_comments_article_status" ON "comments" ("article_id", "status");
+CREATE INDEX IF NOT EXISTS "idx_comments_parent_id" ON "comments" ("parent_id");
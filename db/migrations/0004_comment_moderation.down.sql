@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_comments_ip_created_at";
+DROP INDEX IF EXISTS "idx_comments_email_created_at";
+
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "moderation_reason";
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "moderation_score";
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "ip_address";
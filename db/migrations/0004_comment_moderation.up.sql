@@ -0,0 +1,8 @@
+-- Kolom hasil moderasi otomatis komentar
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS "ip_address" varchar;
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS "moderation_score" numeric(6, 2) DEFAULT 0;
+ALTER TABLE "comments" ADD COLUMN IF NOT EXISTS "moderation_reason" text;
+
+-- Index untuk check duplikat dan rate limit per email/IP
+CREATE INDEX IF NOT EXISTS "idx_comments_email_created_at" ON "comments" ("email", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_comments_ip_created_at" ON "comments" ("ip_address", "created_at");
@@ -0,0 +1,2 @@
+ALTER TABLE "testimonials" DROP COLUMN IF EXISTS "status";
+DROP TABLE IF EXISTS "moderation_actions";
@@ -0,0 +1,14 @@
+-- Tabel Moderation Actions (catatan setiap aksi moderasi komentar & testimonial)
+CREATE TABLE IF NOT EXISTS "moderation_actions" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "entity_type" varchar NOT NULL CHECK (entity_type IN ('comment', 'testimonial')),
+  "entity_id" integer NOT NULL,
+  "action" varchar NOT NULL CHECK (action IN ('approve', 'reject', 'delete')),
+  "actor_id" integer REFERENCES "users" ("id"),
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_moderation_actions_entity" ON "moderation_actions" ("entity_type", "entity_id");
+
+-- Status testimonial dipakai oleh alur approve/reject
+ALTER TABLE "testimonials" ADD COLUMN IF NOT EXISTS "status" varchar DEFAULT 'pending';
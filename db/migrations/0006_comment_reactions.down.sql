@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "comment_reports";
+DROP TABLE IF EXISTS "comment_reactions";
+
+-- Komentar yang disembunyikan karena laporan dikembalikan ke antrean moderasi
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_status_check";
+UPDATE "comments" SET "status" = 'pending' WHERE "status" = 'hidden';
+ALTER TABLE "comments" ADD CONSTRAINT "comments_status_check" CHECK (status IN ('approved', 'pending', 'rejected'));
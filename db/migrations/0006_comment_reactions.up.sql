@@ -0,0 +1,30 @@
+-- Status "hidden" untuk komentar yang disembunyikan otomatis karena banyak dilaporkan
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_status_check";
+ALTER TABLE "comments" ADD CONSTRAINT "comments_status_check" CHECK (status IN ('approved', 'pending', 'rejected', 'hidden'));
+
+-- Tabel Comment Reactions (satu reaksi per pembaca per komentar)
+CREATE TABLE IF NOT EXISTS "comment_reactions" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "comment_id" integer NOT NULL REFERENCES "comments" ("id") ON DELETE CASCADE,
+  "user_email" varchar NOT NULL,
+  "type" varchar NOT NULL CHECK (type IN ('like', 'helpful')),
+  "created_at" timestamp DEFAULT (now()),
+  "updated_at" timestamp DEFAULT (now()),
+  UNIQUE ("comment_id", "user_email")
+);
+
+-- Tabel Comment Reports (laporan komentar kasar/spam dari pembaca)
+CREATE TABLE IF NOT EXISTS "comment_reports" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "comment_id" integer NOT NULL REFERENCES "comments" ("id") ON DELETE CASCADE,
+  "reporter_email" varchar NOT NULL,
+  "ip_address" varchar,
+  "reason" text NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'upheld')),
+  "resolved_by" integer REFERENCES "users" ("id"),
+  "resolved_at" timestamp,
+  "created_at" timestamp DEFAULT (now()),
+  UNIQUE ("comment_id", "reporter_email")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_comment_reports_open" ON "comment_reports" ("comment_id") WHERE status = 'open';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "uniq_appointments_host_slot";
+ALTER TABLE "appointments" DROP COLUMN IF EXISTS "end_time";
+
+DROP TABLE IF EXISTS "availability_exceptions";
+DROP TABLE IF EXISTS "staff_availability";
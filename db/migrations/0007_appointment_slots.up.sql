@@ -0,0 +1,32 @@
+-- Tabel Staff Availability (template jadwal mingguan staff)
+CREATE TABLE IF NOT EXISTS "staff_availability" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "staff_id" integer NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "weekday" smallint NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+  "start_time" time NOT NULL,
+  "end_time" time NOT NULL,
+  "slot_minutes" integer NOT NULL DEFAULT 60 CHECK (slot_minutes BETWEEN 15 AND 240),
+  "created_at" timestamp DEFAULT (now()),
+  "updated_at" timestamp DEFAULT (now()),
+  CHECK (start_time < end_time)
+);
+
+CREATE INDEX IF NOT EXISTS "idx_staff_availability_staff" ON "staff_availability" ("staff_id", "weekday");
+
+-- Tabel Availability Exceptions (libur atau jam khusus pada tanggal tertentu)
+CREATE TABLE IF NOT EXISTS "availability_exceptions" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "staff_id" integer NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "date" date NOT NULL,
+  "start_time" time,
+  "end_time" time,
+  "reason" varchar,
+  "created_at" timestamp DEFAULT (now()),
+  CHECK ((start_time IS NULL AND end_time IS NULL) OR (start_time < end_time))
+);
+
+CREATE INDEX IF NOT EXISTS "idx_availability_exceptions_staff_date" ON "availability_exceptions" ("staff_id", "date");
+
+-- Slot appointment: waktu selesai dan constraint agar satu host tidak dibooking dua kali pada slot yang sama
+ALTER TABLE "appointments" ADD COLUMN IF NOT EXISTS "end_time" timestamp;
+CREATE UNIQUE INDEX IF NOT EXISTS "uniq_appointments_host_slot" ON "appointments" ("host_id", "time") WHERE status <> 'cancelled';
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "appointment_status_history";
+
+-- Status yang tidak dikenal skema lama dipetakan ke status terdekat
+ALTER TABLE "appointments" DROP CONSTRAINT IF EXISTS "appointments_status_check";
+UPDATE "appointments" SET "status" = 'confirmed' WHERE "status" IN ('rescheduled', 'completed');
+UPDATE "appointments" SET "status" = 'cancelled' WHERE "status" = 'no-show';
+ALTER TABLE "appointments" ADD CONSTRAINT "appointments_status_check" CHECK (status IN ('confirmed', 'pending', 'cancelled'));
@@ -0,0 +1,17 @@
+-- Status appointment mengikuti lifecycle pending -> confirmed -> rescheduled -> completed / cancelled / no-show
+ALTER TABLE "appointments" DROP CONSTRAINT IF EXISTS "appointments_status_check";
+ALTER TABLE "appointments" ADD CONSTRAINT "appointments_status_check"
+  CHECK (status IN ('pending', 'confirmed', 'rescheduled', 'completed', 'cancelled', 'no-show'));
+
+-- Tabel Appointment Status History (timeline perubahan status per appointment)
+CREATE TABLE IF NOT EXISTS "appointment_status_history" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "appointment_id" integer NOT NULL REFERENCES "appointments" ("id") ON DELETE CASCADE,
+  "from_status" varchar,
+  "to_status" varchar NOT NULL,
+  "changed_by" varchar NOT NULL,
+  "note" text,
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_appointment_status_history_appointment" ON "appointment_status_history" ("appointment_id", "created_at");
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "staff_specialties";
+DROP INDEX IF EXISTS "idx_appointments_host_date";
+
+ALTER TABLE "appointments" DROP COLUMN IF EXISTS "host_assigned_at";
+ALTER TABLE "appointments" DROP COLUMN IF EXISTS "assignment_method";
+ALTER TABLE "appointments" DROP COLUMN IF EXISTS "category_id";
@@ -0,0 +1,15 @@
+-- Kategori appointment dan cara host ditentukan (manual oleh admin atau strategi otomatis)
+ALTER TABLE "appointments" ADD COLUMN IF NOT EXISTS "category_id" integer REFERENCES "categories" ("id");
+ALTER TABLE "appointments" ADD COLUMN IF NOT EXISTS "assignment_method" varchar
+  CHECK (assignment_method IN ('manual', 'round_robin', 'least_loaded', 'specialty'));
+ALTER TABLE "appointments" ADD COLUMN IF NOT EXISTS "host_assigned_at" timestamp;
+
+CREATE INDEX IF NOT EXISTS "idx_appointments_host_date" ON "appointments" ("host_id", "date_of_booking");
+
+-- Tabel Staff Specialties (kategori yang ditangani setiap staff)
+CREATE TABLE IF NOT EXISTS "staff_specialties" (
+  "staff_id" integer NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "category_id" integer NOT NULL REFERENCES "categories" ("id") ON DELETE CASCADE,
+  "created_at" timestamp DEFAULT (now()),
+  PRIMARY KEY ("staff_id", "category_id")
+);
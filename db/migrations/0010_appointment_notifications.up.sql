@@ -0,0 +1,21 @@
+-- Tabel Appointment Notifications (status pengiriman konfirmasi dan pengingat appointment)
+CREATE TABLE IF NOT EXISTS "appointment_notifications" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "appointment_id" integer NOT NULL REFERENCES "appointments" ("id") ON DELETE CASCADE,
+  "kind" varchar NOT NULL CHECK (kind IN ('booking_confirmation', 'host_assigned', 'reminder')),
+  "recipient_role" varchar NOT NULL CHECK (recipient_role IN ('user', 'host')),
+  "channel" varchar NOT NULL CHECK (channel IN ('whatsapp', 'email')),
+  "recipient" varchar NOT NULL,
+  "offset_minutes" integer NOT NULL DEFAULT 0,
+  "dedupe_key" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+  "sent_at" timestamp,
+  "created_at" timestamp DEFAULT (now()),
+  "updated_at" timestamp DEFAULT (now()),
+  UNIQUE ("appointment_id", "kind", "recipient_role", "channel", "offset_minutes", "dedupe_key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_appointment_notifications_pending" ON "appointment_notifications" ("next_attempt_at") WHERE status = 'pending';
@@ -0,0 +1,12 @@
+ALTER TABLE "users" DROP COLUMN IF EXISTS "calendar_token";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "end_time";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "start_time";
+
+-- Waktu appointment kembali ke timestamp tanpa zona waktu, dalam waktu lokal aplikasi (app.timezone)
+DO $$
+DECLARE
+  tz text := COALESCE(NULLIF(current_setting('app.timezone', true), ''), 'Asia/Jakarta');
+BEGIN
+  ALTER TABLE "appointments" ALTER COLUMN "time" TYPE timestamp USING "time" AT TIME ZONE tz;
+  ALTER TABLE "appointments" ALTER COLUMN "end_time" TYPE timestamp USING "end_time" AT TIME ZONE tz;
+END $$;
@@ -0,0 +1,20 @@
+-- Waktu appointment disimpan sebagai timestamptz agar instant yang diekspor ke kalender tepat.
+-- Data lama dianggap memakai zona waktu aplikasi (server.timezone) yang diisi migrator ke app.timezone;
+-- jika dijalankan tanpa migrator memakai nilai bawaan Asia/Jakarta.
+DO $$
+DECLARE
+  tz text := COALESCE(NULLIF(current_setting('app.timezone', true), ''), 'Asia/Jakarta');
+BEGIN
+  IF (SELECT data_type FROM information_schema.columns
+      WHERE table_name = 'appointments' AND column_name = 'time') = 'timestamp without time zone' THEN
+    ALTER TABLE "appointments" ALTER COLUMN "time" TYPE timestamptz USING "time" AT TIME ZONE tz;
+    ALTER TABLE "appointments" ALTER COLUMN "end_time" TYPE timestamptz USING "end_time" AT TIME ZONE tz;
+  END IF;
+END $$;
+
+-- Jadwal webinar untuk undangan kalender
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "start_time" timestamptz;
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "end_time" timestamptz;
+
+-- Token rahasia untuk feed iCal staff yang bisa di-subscribe dari aplikasi kalender
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "calendar_token" varchar UNIQUE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_appointments_email";
+DROP INDEX IF EXISTS "uniq_appointments_reference_code";
+ALTER TABLE "appointments" DROP COLUMN IF EXISTS "reference_code";
@@ -0,0 +1,9 @@
+-- Kode referensi booking agar user bisa melacak appointment tanpa login
+ALTER TABLE "appointments" ADD COLUMN IF NOT EXISTS "reference_code" varchar;
+
+UPDATE "appointments"
+SET "reference_code" = 'APT-' || upper(substr(md5(id::text || random()::text), 1, 8))
+WHERE "reference_code" IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "uniq_appointments_reference_code" ON "appointments" ("reference_code");
+CREATE INDEX IF NOT EXISTS "idx_appointments_email" ON "appointments" (lower("email"));
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_appointments_host_time";
+DROP TABLE IF EXISTS "appointment_documents";
+DROP TABLE IF EXISTS "appointment_notes";
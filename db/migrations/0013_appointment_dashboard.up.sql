@@ -0,0 +1,25 @@
+-- Tabel Appointment Notes (catatan sesi privat milik staff host)
+CREATE TABLE IF NOT EXISTS "appointment_notes" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "appointment_id" integer NOT NULL REFERENCES "appointments" ("id") ON DELETE CASCADE,
+  "staff_id" integer NOT NULL REFERENCES "users" ("id"),
+  "note" text NOT NULL,
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_appointment_notes_appointment" ON "appointment_notes" ("appointment_id", "created_at");
+
+-- Tabel Appointment Documents (dokumen tindak lanjut untuk klien)
+CREATE TABLE IF NOT EXISTS "appointment_documents" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "appointment_id" integer NOT NULL REFERENCES "appointments" ("id") ON DELETE CASCADE,
+  "uploaded_by" integer NOT NULL REFERENCES "users" ("id"),
+  "filename" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size_bytes" bigint NOT NULL,
+  "storage_key" varchar NOT NULL,
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_appointment_documents_appointment" ON "appointment_documents" ("appointment_id");
+CREATE INDEX IF NOT EXISTS "idx_appointments_host_time" ON "appointments" ("host_id", "time");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "webinar_notifications";
+DROP TABLE IF EXISTS "webinar_registrations";
+
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "registration_deadline";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "capacity";
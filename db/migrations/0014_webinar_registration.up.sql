@@ -0,0 +1,44 @@
+-- Kapasitas dan batas pendaftaran webinar. Kapasitas NULL berarti tidak dibatasi,
+-- batas pendaftaran NULL berarti pendaftaran dibuka sampai webinar dimulai.
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "capacity" integer CHECK (capacity > 0);
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "registration_deadline" timestamptz;
+
+-- Tabel Webinar Registrations (pendaftar webinar dan daftar tunggu)
+CREATE TABLE IF NOT EXISTS "webinar_registrations" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "webinar_id" integer NOT NULL REFERENCES "webinars" ("id") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "email" varchar NOT NULL,
+  "phone_number" varchar,
+  "status" varchar NOT NULL DEFAULT 'registered' CHECK (status IN ('registered', 'waitlisted', 'cancelled')),
+  "cancel_token" varchar NOT NULL UNIQUE,
+  "promoted_at" timestamptz,
+  "cancelled_at" timestamptz,
+  "created_at" timestamptz DEFAULT (now()),
+  "updated_at" timestamptz DEFAULT (now())
+);
+
+-- Satu email hanya bisa terdaftar sekali per webinar, kecuali pendaftaran sebelumnya dibatalkan
+CREATE UNIQUE INDEX IF NOT EXISTS "uniq_webinar_registrations_email" ON "webinar_registrations" ("webinar_id", lower("email")) WHERE status <> 'cancelled';
+CREATE INDEX IF NOT EXISTS "idx_webinar_registrations_webinar" ON "webinar_registrations" ("webinar_id", "status", "created_at");
+
+-- Tabel Webinar Notifications (status pengiriman konfirmasi dan pengingat untuk pendaftar webinar)
+CREATE TABLE IF NOT EXISTS "webinar_notifications" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "registration_id" integer NOT NULL REFERENCES "webinar_registrations" ("id") ON DELETE CASCADE,
+  "kind" varchar NOT NULL CHECK (kind IN ('webinar_registered', 'webinar_waitlisted', 'webinar_promoted', 'webinar_reminder')),
+  "channel" varchar NOT NULL CHECK (channel IN ('whatsapp', 'email')),
+  "recipient" varchar NOT NULL,
+  "offset_minutes" integer NOT NULL DEFAULT 0,
+  "dedupe_key" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+  "sent_at" timestamp,
+  "created_at" timestamp DEFAULT (now()),
+  "updated_at" timestamp DEFAULT (now()),
+  UNIQUE ("registration_id", "kind", "channel", "offset_minutes", "dedupe_key")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webinar_notifications_pending" ON "webinar_notifications" ("next_attempt_at") WHERE status = 'pending';
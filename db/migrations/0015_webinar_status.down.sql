@@ -0,0 +1,11 @@
+ALTER TABLE "webinar_notifications" DROP CONSTRAINT IF EXISTS "webinar_notifications_kind_check";
+DELETE FROM "webinar_notifications" WHERE "kind" = 'webinar_cancelled';
+ALTER TABLE "webinar_notifications" ADD CONSTRAINT "webinar_notifications_kind_check"
+  CHECK (kind IN ('webinar_registered', 'webinar_waitlisted', 'webinar_promoted', 'webinar_reminder'));
+
+DROP INDEX IF EXISTS "idx_webinars_status_start";
+
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "recording_video_id";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "cancellation_reason";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "cancelled_at";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "status";
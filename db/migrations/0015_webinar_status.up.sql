@@ -0,0 +1,17 @@
+-- Status webinar: draft -> scheduled -> live -> ended, atau cancelled sebelum dimulai
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "status" varchar NOT NULL DEFAULT 'scheduled';
+ALTER TABLE "webinars" DROP CONSTRAINT IF EXISTS "webinars_status_check";
+ALTER TABLE "webinars" ADD CONSTRAINT "webinars_status_check"
+  CHECK (status IN ('draft', 'scheduled', 'live', 'ended', 'cancelled'));
+
+-- Pembatalan dan rekaman webinar
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "cancelled_at" timestamptz;
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "cancellation_reason" text;
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "recording_video_id" integer REFERENCES "videos" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_webinars_status_start" ON "webinars" ("status", "start_time");
+
+-- Notifikasi pembatalan webinar untuk pendaftar
+ALTER TABLE "webinar_notifications" DROP CONSTRAINT IF EXISTS "webinar_notifications_kind_check";
+ALTER TABLE "webinar_notifications" ADD CONSTRAINT "webinar_notifications_kind_check"
+  CHECK (kind IN ('webinar_registered', 'webinar_waitlisted', 'webinar_promoted', 'webinar_reminder', 'webinar_cancelled'));
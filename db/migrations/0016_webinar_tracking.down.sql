@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "webinar_certificates";
+DROP TABLE IF EXISTS "webinar_attendance";
+
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "certificate_threshold";
+ALTER TABLE "webinars" DROP COLUMN IF EXISTS "check_in_code";
@@ -0,0 +1,32 @@
+-- Kode check-in yang dibagikan host selama webinar live dan batas kehadiran (persen) untuk sertifikat
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "check_in_code" varchar;
+ALTER TABLE "webinars" ADD COLUMN IF NOT EXISTS "certificate_threshold" integer NOT NULL DEFAULT 75;
+ALTER TABLE "webinars" DROP CONSTRAINT IF EXISTS "webinars_certificate_threshold_check";
+ALTER TABLE "webinars" ADD CONSTRAINT "webinars_certificate_threshold_check"
+  CHECK (certificate_threshold BETWEEN 1 AND 100);
+
+-- Tabel Webinar Attendance (kehadiran peserta, satu baris per pendaftaran)
+CREATE TABLE IF NOT EXISTS "webinar_attendance" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "registration_id" integer NOT NULL UNIQUE REFERENCES "webinar_registrations" ("id") ON DELETE CASCADE,
+  "webinar_id" integer NOT NULL REFERENCES "webinars" ("id") ON DELETE CASCADE,
+  "method" varchar NOT NULL CHECK (method IN ('host', 'self')),
+  "checked_in_at" timestamptz NOT NULL DEFAULT (now()),
+  "marked_by" integer REFERENCES "users" ("id") ON DELETE SET NULL,
+  "created_at" timestamptz DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webinar_attendance_webinar" ON "webinar_attendance" ("webinar_id");
+
+-- Tabel Webinar Certificates (sertifikat kehadiran yang bisa diverifikasi lewat kode)
+CREATE TABLE IF NOT EXISTS "webinar_certificates" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "code" varchar NOT NULL UNIQUE,
+  "registration_id" integer NOT NULL UNIQUE REFERENCES "webinar_registrations" ("id") ON DELETE CASCADE,
+  "webinar_id" integer NOT NULL REFERENCES "webinars" ("id") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "attended_percent" integer NOT NULL,
+  "issued_at" timestamptz DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webinar_certificates_webinar" ON "webinar_certificates" ("webinar_id");
@@ -0,0 +1,34 @@
+-- Tabel Notification Outbox (event notifikasi yang ditulis dalam transaksi yang sama dengan perubahan konten)
+CREATE TABLE IF NOT EXISTS "notification_outbox" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "event" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dispatched', 'dead')),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "dispatched_at" timestamptz,
+  "created_at" timestamptz DEFAULT (now()),
+  "updated_at" timestamptz DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_notification_outbox_pending" ON "notification_outbox" ("next_attempt_at") WHERE status = 'pending';
+
+-- Tabel Notification Deliveries (satu baris per penerima per kanal, dikirim ulang dengan backoff)
+CREATE TABLE IF NOT EXISTS "notification_deliveries" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "outbox_id" integer NOT NULL REFERENCES "notification_outbox" ("id") ON DELETE CASCADE,
+  "channel" varchar NOT NULL,
+  "user_id" integer NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "created_at" timestamptz DEFAULT (now()),
+  "updated_at" timestamptz DEFAULT (now()),
+  UNIQUE ("outbox_id", "channel", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS "idx_notification_deliveries_pending" ON "notification_deliveries" ("next_attempt_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_notification_deliveries_dead" ON "notification_deliveries" ("updated_at") WHERE status = 'dead';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_notifications_unread";
+DROP INDEX IF EXISTS "idx_notifications_user";
+DROP TABLE IF EXISTS "notification_preferences";
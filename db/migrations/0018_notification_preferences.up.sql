@@ -0,0 +1,13 @@
+-- Tabel Notification Preferences (tipe notifikasi yang dibisukan pengguna per kanal)
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+  "user_id" integer NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+  "type" varchar NOT NULL,
+  "channel" varchar NOT NULL CHECK (channel IN ('in_app', 'whatsapp', 'email')),
+  "muted" boolean NOT NULL DEFAULT true,
+  "updated_at" timestamptz DEFAULT (now()),
+  PRIMARY KEY ("user_id", "type", "channel")
+);
+
+-- Inbox in-app dibaca per pengguna, terbaru lebih dulu
+CREATE INDEX IF NOT EXISTS "idx_notifications_user" ON "notifications" ("user_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS "idx_notifications_unread" ON "notifications" ("user_id") WHERE status = 'unread';
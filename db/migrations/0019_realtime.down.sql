@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "comment_reports";
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "comments";
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "videos";
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "articles";
+DROP TRIGGER IF EXISTS "trg_realtime_notification_changed" ON "notifications";
+DROP TRIGGER IF EXISTS "trg_realtime_notification_inserted" ON "notifications";
+
+DROP FUNCTION IF EXISTS realtime_moderation_changed();
+DROP FUNCTION IF EXISTS realtime_notification_changed();
+DROP FUNCTION IF EXISTS realtime_notification_inserted();
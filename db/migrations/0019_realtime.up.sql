@@ -0,0 +1,68 @@
+-- Trigger realtime: perubahan notifikasi dan antrean moderasi diumumkan lewat NOTIFY pada kanal
+-- "realtime" agar setiap instance server bisa meneruskannya ke klien SSE dan WebSocket.
+
+-- Notifikasi in-app baru dikirim beserta jumlah yang belum dibaca. Pesan dipotong agar payload
+-- tetap di bawah batas 8000 byte NOTIFY.
+CREATE OR REPLACE FUNCTION realtime_notification_inserted() RETURNS trigger AS $$
+BEGIN
+  IF NEW.user_id IS NOT NULL THEN
+    PERFORM pg_notify('realtime', json_build_object(
+      'event', 'notification',
+      'user_id', NEW.user_id,
+      'data', json_build_object(
+        'notification', json_build_object('id', NEW.id, 'type', NEW.type, 'message', left(NEW.message, 2000),
+                                          'status', NEW.status, 'created_at', NEW.created_at),
+        'unread', (SELECT COUNT(*) FROM notifications WHERE user_id = NEW.user_id AND status = 'unread')))::text);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Notifikasi dibaca atau dihapus: hanya jumlah yang belum dibaca yang dikirim. Payload yang sama
+-- dalam satu transaksi digabung Postgres, jadi "tandai semua dibaca" cukup mengirim satu event.
+CREATE OR REPLACE FUNCTION realtime_notification_changed() RETURNS trigger AS $$
+DECLARE
+  target integer := COALESCE(NEW.user_id, OLD.user_id);
+BEGIN
+  IF target IS NOT NULL THEN
+    PERFORM pg_notify('realtime', json_build_object(
+      'event', 'notification.unread',
+      'user_id', target,
+      'data', json_build_object('unread',
+        (SELECT COUNT(*) FROM notifications WHERE user_id = target AND status = 'unread')))::text);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Antrean moderasi berubah; jumlahnya dihitung ulang oleh server
+CREATE OR REPLACE FUNCTION realtime_moderation_changed() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('realtime', '{"event":"moderation.queue"}');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "trg_realtime_notification_inserted" ON "notifications";
+CREATE TRIGGER "trg_realtime_notification_inserted" AFTER INSERT ON "notifications"
+  FOR EACH ROW EXECUTE FUNCTION realtime_notification_inserted();
+
+DROP TRIGGER IF EXISTS "trg_realtime_notification_changed" ON "notifications";
+CREATE TRIGGER "trg_realtime_notification_changed" AFTER UPDATE OF status OR DELETE ON "notifications"
+  FOR EACH ROW EXECUTE FUNCTION realtime_notification_changed();
+
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "articles";
+CREATE TRIGGER "trg_realtime_moderation" AFTER INSERT OR UPDATE OF status OR DELETE ON "articles"
+  FOR EACH STATEMENT EXECUTE FUNCTION realtime_moderation_changed();
+
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "videos";
+CREATE TRIGGER "trg_realtime_moderation" AFTER INSERT OR UPDATE OF status OR DELETE ON "videos"
+  FOR EACH STATEMENT EXECUTE FUNCTION realtime_moderation_changed();
+
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "comments";
+CREATE TRIGGER "trg_realtime_moderation" AFTER INSERT OR UPDATE OF status OR DELETE ON "comments"
+  FOR EACH STATEMENT EXECUTE FUNCTION realtime_moderation_changed();
+
+DROP TRIGGER IF EXISTS "trg_realtime_moderation" ON "comment_reports";
+CREATE TRIGGER "trg_realtime_moderation" AFTER INSERT OR UPDATE OF status OR DELETE ON "comment_reports"
+  FOR EACH STATEMENT EXECUTE FUNCTION realtime_moderation_changed();
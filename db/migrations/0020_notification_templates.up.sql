@@ -0,0 +1,15 @@
+-- Bahasa pesan notifikasi per pengguna
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" varchar NOT NULL DEFAULT 'id';
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_locale_check";
+ALTER TABLE "users" ADD CONSTRAINT "users_locale_check" CHECK (locale IN ('id', 'en'));
+
+-- Tabel Notification Templates (template pesan yang diubah admin; tanpa baris berarti memakai bawaan)
+CREATE TABLE IF NOT EXISTS "notification_templates" (
+  "event" varchar NOT NULL,
+  "locale" varchar NOT NULL CHECK (locale IN ('id', 'en')),
+  "subject" text NOT NULL DEFAULT '',
+  "body" text NOT NULL,
+  "updated_by" integer REFERENCES "users" ("id") ON DELETE SET NULL,
+  "updated_at" timestamptz DEFAULT (now()),
+  PRIMARY KEY ("event", "locale")
+);
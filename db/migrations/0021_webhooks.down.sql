@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS "trg_webhook_appointment_booked" ON "appointments";
+DROP TRIGGER IF EXISTS "trg_webhook_testimonial_approved" ON "testimonials";
+DROP TRIGGER IF EXISTS "trg_webhook_article_published" ON "articles";
+
+DROP FUNCTION IF EXISTS webhook_appointment_booked();
+DROP FUNCTION IF EXISTS webhook_testimonial_approved();
+DROP FUNCTION IF EXISTS webhook_article_published();
+DROP FUNCTION IF EXISTS webhook_enqueue(text, jsonb);
+
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhook_subscriptions";
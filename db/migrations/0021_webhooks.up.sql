@@ -0,0 +1,91 @@
+-- Tabel Webhook Subscriptions (URL sistem lain yang menerima event, dikelola admin)
+CREATE TABLE IF NOT EXISTS "webhook_subscriptions" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "url" varchar NOT NULL,
+  "secret" varchar NOT NULL,
+  "events" varchar[] NOT NULL DEFAULT '{}',
+  "description" varchar NOT NULL DEFAULT '',
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz DEFAULT (now()),
+  "updated_at" timestamptz DEFAULT (now())
+);
+
+-- Tabel Webhook Deliveries (log pengiriman per langganan, dikirim ulang dengan backoff)
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "subscription_id" integer NOT NULL REFERENCES "webhook_subscriptions" ("id") ON DELETE CASCADE,
+  "event" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'dead')),
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "response_status" integer,
+  "response_body" text,
+  "duration_ms" integer,
+  "next_attempt_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "created_at" timestamptz DEFAULT (now()),
+  "updated_at" timestamptz DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_pending" ON "webhook_deliveries" ("next_attempt_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_subscription" ON "webhook_deliveries" ("subscription_id", "created_at" DESC);
+
+-- webhook_enqueue mencatat satu pengiriman untuk setiap langganan aktif yang memilih event tersebut.
+-- Dipanggil dari trigger sehingga event ikut tersimpan atau batal bersama perubahan datanya.
+CREATE OR REPLACE FUNCTION webhook_enqueue(event_name text, data jsonb) RETURNS void AS $$
+BEGIN
+  INSERT INTO webhook_deliveries (subscription_id, event, payload)
+  SELECT s.id, event_name, jsonb_build_object('event', event_name, 'occurred_at', now(), 'data', data)
+  FROM webhook_subscriptions s
+  WHERE s.active AND event_name = ANY(s.events);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Artikel yang disetujui reviewer ('approval') atau diterbitkan ('published')
+CREATE OR REPLACE FUNCTION webhook_article_published() RETURNS trigger AS $$
+BEGIN
+  IF NEW.status IN ('approval', 'published')
+     AND (TG_OP = 'INSERT' OR OLD.status IS NULL OR OLD.status NOT IN ('approval', 'published')) THEN
+    PERFORM webhook_enqueue('article.published', jsonb_build_object(
+      'id', NEW.id, 'title', NEW.title, 'slug', NEW.slug, 'category_id', NEW.category_id,
+      'author_id', NEW.author_id, 'status', NEW.status, 'thumbnail', NEW.thumbnail,
+      'meta_title', NEW.meta_title, 'meta_description', NEW.meta_description, 'updated_at', NEW.updated_at));
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION webhook_testimonial_approved() RETURNS trigger AS $$
+BEGIN
+  IF NEW.status = 'approved' AND (TG_OP = 'INSERT' OR OLD.status IS DISTINCT FROM 'approved') THEN
+    PERFORM webhook_enqueue('testimonial.approved', jsonb_build_object(
+      'id', NEW.id, 'name', NEW.name, 'comment', NEW.comment, 'photo_profile', NEW.photo_profile,
+      'category_id', NEW.category_id, 'updated_at', NEW.updated_at));
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION webhook_appointment_booked() RETURNS trigger AS $$
+BEGIN
+  PERFORM webhook_enqueue('appointment.booked', jsonb_build_object(
+    'id', NEW.id, 'reference_code', NEW.reference_code, 'name', NEW.name, 'email', NEW.email,
+    'phone_number', NEW.phone_number, 'date_of_booking', NEW.date_of_booking, 'time', NEW.time,
+    'end_time', NEW.end_time, 'host_id', NEW.host_id, 'category_id', NEW.category_id,
+    'status', NEW.status, 'created_at', NEW.created_at));
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "trg_webhook_article_published" ON "articles";
+CREATE TRIGGER "trg_webhook_article_published" AFTER INSERT OR UPDATE OF status ON "articles"
+  FOR EACH ROW EXECUTE FUNCTION webhook_article_published();
+
+DROP TRIGGER IF EXISTS "trg_webhook_testimonial_approved" ON "testimonials";
+CREATE TRIGGER "trg_webhook_testimonial_approved" AFTER INSERT OR UPDATE OF status ON "testimonials"
+  FOR EACH ROW EXECUTE FUNCTION webhook_testimonial_approved();
+
+DROP TRIGGER IF EXISTS "trg_webhook_appointment_booked" ON "appointments";
+CREATE TRIGGER "trg_webhook_appointment_booked" AFTER INSERT ON "appointments"
+  FOR EACH ROW EXECUTE FUNCTION webhook_appointment_booked();
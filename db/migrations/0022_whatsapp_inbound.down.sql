@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "whatsapp_messages";
+DROP INDEX IF EXISTS "idx_appointments_phone_normalized";
+DROP FUNCTION IF EXISTS normalize_phone(text);
@@ -0,0 +1,29 @@
+-- Nomor telepon dinormalisasi ke digit saja dengan kode negara (0812... dan +62 812... menjadi 62812...)
+-- supaya pengirim balasan WhatsApp bisa dicocokkan dengan nomor yang diisi saat booking
+CREATE OR REPLACE FUNCTION normalize_phone(phone text) RETURNS text
+LANGUAGE sql IMMUTABLE AS $$
+  SELECT CASE
+    WHEN digits LIKE '0%' THEN '62' || substr(digits, 2)
+    ELSE digits
+  END
+  FROM (SELECT regexp_replace(COALESCE(phone, ''), '\D', '', 'g') AS digits) d
+$$;
+
+CREATE INDEX IF NOT EXISTS "idx_appointments_phone_normalized" ON "appointments" (normalize_phone("phone_number"), "time");
+
+-- Tabel WhatsApp Messages (semua pesan masuk dari webhook Twilio beserta tindakan yang diambil)
+CREATE TABLE IF NOT EXISTS "whatsapp_messages" (
+  "id" INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "message_sid" varchar NOT NULL UNIQUE,
+  "from_number" varchar NOT NULL,
+  "to_number" varchar NOT NULL,
+  "body" text NOT NULL DEFAULT '',
+  "appointment_id" integer REFERENCES "appointments" ("id") ON DELETE SET NULL,
+  "action" varchar CHECK (action IN ('confirm', 'cancel')),
+  "result" varchar NOT NULL DEFAULT 'received',
+  "reply" text,
+  "created_at" timestamp DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS "idx_whatsapp_messages_appointment" ON "whatsapp_messages" ("appointment_id", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_whatsapp_messages_from" ON "whatsapp_messages" (normalize_phone("from_number"), "created_at");
@@ -0,0 +1,53 @@
+DROP INDEX IF EXISTS "idx_webinars_host";
+DROP INDEX IF EXISTS "idx_appointments_category";
+DROP INDEX IF EXISTS "idx_appointments_status_time";
+DROP INDEX IF EXISTS "idx_comments_status_created_at";
+DROP INDEX IF EXISTS "idx_testimonials_category";
+DROP INDEX IF EXISTS "idx_testimonials_status";
+DROP INDEX IF EXISTS "idx_videos_category";
+DROP INDEX IF EXISTS "idx_videos_status";
+DROP INDEX IF EXISTS "idx_articles_views_article";
+DROP INDEX IF EXISTS "idx_articles_category";
+DROP INDEX IF EXISTS "idx_articles_status";
+DROP INDEX IF EXISTS "idx_articles_created_at";
+DROP INDEX IF EXISTS "idx_users_role";
+
+-- Foreign key kembali tanpa aturan hapus
+ALTER TABLE "comment_reports" DROP CONSTRAINT IF EXISTS "comment_reports_resolved_by_fkey";
+ALTER TABLE "comment_reports" ADD CONSTRAINT "comment_reports_resolved_by_fkey" FOREIGN KEY ("resolved_by") REFERENCES "users" ("id");
+ALTER TABLE "moderation_actions" DROP CONSTRAINT IF EXISTS "moderation_actions_actor_id_fkey";
+ALTER TABLE "moderation_actions" ADD CONSTRAINT "moderation_actions_actor_id_fkey" FOREIGN KEY ("actor_id") REFERENCES "users" ("id");
+ALTER TABLE "content_reviews" DROP CONSTRAINT IF EXISTS "content_reviews_reviewer_id_fkey";
+ALTER TABLE "content_reviews" ADD CONSTRAINT "content_reviews_reviewer_id_fkey" FOREIGN KEY ("reviewer_id") REFERENCES "users" ("id");
+ALTER TABLE "webinars" DROP CONSTRAINT IF EXISTS "webinars_host_id_fkey";
+ALTER TABLE "webinars" ADD CONSTRAINT "webinars_host_id_fkey" FOREIGN KEY ("host_id") REFERENCES "users" ("id");
+ALTER TABLE "appointments" DROP CONSTRAINT IF EXISTS "appointments_host_id_fkey";
+ALTER TABLE "appointments" ADD CONSTRAINT "appointments_host_id_fkey" FOREIGN KEY ("host_id") REFERENCES "users" ("id");
+ALTER TABLE "videos" DROP CONSTRAINT IF EXISTS "videos_author_id_fkey";
+ALTER TABLE "videos" ADD CONSTRAINT "videos_author_id_fkey" FOREIGN KEY ("author_id") REFERENCES "users" ("id");
+ALTER TABLE "articles" DROP CONSTRAINT IF EXISTS "articles_author_id_fkey";
+ALTER TABLE "articles" ADD CONSTRAINT "articles_author_id_fkey" FOREIGN KEY ("author_id") REFERENCES "users" ("id");
+ALTER TABLE "notifications" DROP CONSTRAINT IF EXISTS "notifications_user_id_fkey";
+ALTER TABLE "notifications" ADD CONSTRAINT "notifications_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "articles_views" DROP CONSTRAINT IF EXISTS "articles_views_article_id_fkey";
+ALTER TABLE "articles_views" ADD CONSTRAINT "articles_views_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES "articles" ("id");
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+ALTER TABLE "comments" ADD CONSTRAINT "comments_parent_id_fkey" FOREIGN KEY ("parent_id") REFERENCES "comments" ("id");
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_article_id_fkey";
+ALTER TABLE "comments" ADD CONSTRAINT "comments_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES "articles" ("id");
+
+ALTER TABLE "notifications" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "notifications" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TABLE "appointments" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "appointments" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TABLE "comments" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "comments" ALTER COLUMN "status" DROP DEFAULT;
+
+ALTER TABLE "testimonials" DROP CONSTRAINT IF EXISTS "testimonials_status_check";
+ALTER TABLE "testimonials" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "videos" DROP CONSTRAINT IF EXISTS "videos_status_check";
+ALTER TABLE "videos" ALTER COLUMN "status" DROP NOT NULL;
+
+ALTER TABLE "users" ALTER COLUMN "status" DROP NOT NULL;
+ALTER TABLE "users" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_role_check";
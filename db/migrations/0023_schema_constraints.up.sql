@@ -0,0 +1,115 @@
+-- Skema kanonik: status yang valid, aturan hapus foreign key dan index untuk setiap query daftar
+
+-- Peran pengguna sesuai yang diterima endpoint register. Data lama dirapikan dulu agar constraint bisa
+-- dipasang: huruf besar dan spasi dinormalkan, sedangkan peran yang tidak dikenal atau kosong diturunkan
+-- menjadi 'user' (hak akses paling kecil) dan jumlahnya dicatat sebagai NOTICE untuk diperiksa admin.
+-- Perubahan data ini tidak dikembalikan oleh migrasi down.
+UPDATE "users" SET "role" = LOWER(TRIM("role")) WHERE "role" <> LOWER(TRIM("role"));
+UPDATE "users" SET "role" = 'admin' WHERE "role" = 'administrator';
+DO $$
+DECLARE
+  demoted integer;
+BEGIN
+  UPDATE "users" SET "role" = 'user', "updated_at" = NOW()
+  WHERE "role" IS NULL OR "role" NOT IN ('admin', 'staff', 'user');
+  GET DIAGNOSTICS demoted = ROW_COUNT;
+  IF demoted > 0 THEN
+    RAISE NOTICE '% users with an unknown or empty role were set to role user', demoted;
+  END IF;
+END $$;
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_role_check";
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK (role IN ('admin', 'staff', 'user'));
+UPDATE "users" SET "status" = 'active' WHERE "status" IS NULL;
+ALTER TABLE "users" ALTER COLUMN "status" SET DEFAULT 'active';
+ALTER TABLE "users" ALTER COLUMN "status" SET NOT NULL;
+
+-- Status video mengikuti alur review yang sama dengan artikel. Status lama yang tidak dikenal dikembalikan
+-- ke antrean review agar diperiksa ulang, bukan tetap tayang.
+UPDATE "videos" SET "status" = LOWER(TRIM("status")) WHERE "status" <> LOWER(TRIM("status"));
+DO $$
+DECLARE
+  flagged integer;
+BEGIN
+  UPDATE "videos" SET "status" = 'pending approval', "updated_at" = NOW()
+  WHERE "status" IS NULL OR "status" NOT IN
+    ('pending approval', 'changes requested', 'approval', 'rejected', 'withdrawn', 'published', 'draft', 'archived');
+  GET DIAGNOSTICS flagged = ROW_COUNT;
+  IF flagged > 0 THEN
+    RAISE NOTICE '% videos with an unknown or empty status were sent back to review', flagged;
+  END IF;
+END $$;
+ALTER TABLE "videos" ALTER COLUMN "status" SET NOT NULL;
+ALTER TABLE "videos" DROP CONSTRAINT IF EXISTS "videos_status_check";
+ALTER TABLE "videos" ADD CONSTRAINT "videos_status_check"
+  CHECK (status IN ('pending approval', 'changes requested', 'approval', 'rejected', 'withdrawn', 'published', 'draft', 'archived'));
+
+-- Status testimonial: pending -> approved / rejected
+UPDATE "testimonials" SET "status" = LOWER(TRIM("status")) WHERE "status" <> LOWER(TRIM("status"));
+UPDATE "testimonials" SET "status" = 'pending' WHERE "status" IS NULL OR "status" NOT IN ('pending', 'approved', 'rejected');
+ALTER TABLE "testimonials" ALTER COLUMN "status" SET NOT NULL;
+ALTER TABLE "testimonials" DROP CONSTRAINT IF EXISTS "testimonials_status_check";
+ALTER TABLE "testimonials" ADD CONSTRAINT "testimonials_status_check" CHECK (status IN ('pending', 'approved', 'rejected'));
+
+UPDATE "comments" SET "status" = 'pending' WHERE "status" IS NULL;
+ALTER TABLE "comments" ALTER COLUMN "status" SET DEFAULT 'pending';
+ALTER TABLE "comments" ALTER COLUMN "status" SET NOT NULL;
+
+UPDATE "appointments" SET "status" = 'pending' WHERE "status" IS NULL;
+ALTER TABLE "appointments" ALTER COLUMN "status" SET DEFAULT 'pending';
+ALTER TABLE "appointments" ALTER COLUMN "status" SET NOT NULL;
+
+UPDATE "notifications" SET "status" = 'unread' WHERE "status" IS NULL;
+ALTER TABLE "notifications" ALTER COLUMN "status" SET DEFAULT 'unread';
+ALTER TABLE "notifications" ALTER COLUMN "status" SET NOT NULL;
+
+-- Foreign key: data turunan ikut terhapus bersama induknya, sedangkan referensi ke pengguna yang dihapus
+-- dikosongkan agar konten dan jadwal tetap tersimpan
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_article_id_fkey";
+ALTER TABLE "comments" ADD CONSTRAINT "comments_article_id_fkey"
+  FOREIGN KEY ("article_id") REFERENCES "articles" ("id") ON DELETE CASCADE;
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+ALTER TABLE "comments" ADD CONSTRAINT "comments_parent_id_fkey"
+  FOREIGN KEY ("parent_id") REFERENCES "comments" ("id") ON DELETE CASCADE;
+ALTER TABLE "articles_views" DROP CONSTRAINT IF EXISTS "articles_views_article_id_fkey";
+ALTER TABLE "articles_views" ADD CONSTRAINT "articles_views_article_id_fkey"
+  FOREIGN KEY ("article_id") REFERENCES "articles" ("id") ON DELETE CASCADE;
+ALTER TABLE "notifications" DROP CONSTRAINT IF EXISTS "notifications_user_id_fkey";
+ALTER TABLE "notifications" ADD CONSTRAINT "notifications_user_id_fkey"
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "articles" DROP CONSTRAINT IF EXISTS "articles_author_id_fkey";
+ALTER TABLE "articles" ADD CONSTRAINT "articles_author_id_fkey"
+  FOREIGN KEY ("author_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "videos" DROP CONSTRAINT IF EXISTS "videos_author_id_fkey";
+ALTER TABLE "videos" ADD CONSTRAINT "videos_author_id_fkey"
+  FOREIGN KEY ("author_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "appointments" DROP CONSTRAINT IF EXISTS "appointments_host_id_fkey";
+ALTER TABLE "appointments" ADD CONSTRAINT "appointments_host_id_fkey"
+  FOREIGN KEY ("host_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "webinars" DROP CONSTRAINT IF EXISTS "webinars_host_id_fkey";
+ALTER TABLE "webinars" ADD CONSTRAINT "webinars_host_id_fkey"
+  FOREIGN KEY ("host_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "content_reviews" DROP CONSTRAINT IF EXISTS "content_reviews_reviewer_id_fkey";
+ALTER TABLE "content_reviews" ADD CONSTRAINT "content_reviews_reviewer_id_fkey"
+  FOREIGN KEY ("reviewer_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "moderation_actions" DROP CONSTRAINT IF EXISTS "moderation_actions_actor_id_fkey";
+ALTER TABLE "moderation_actions" ADD CONSTRAINT "moderation_actions_actor_id_fkey"
+  FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+ALTER TABLE "comment_reports" DROP CONSTRAINT IF EXISTS "comment_reports_resolved_by_fkey";
+ALTER TABLE "comment_reports" ADD CONSTRAINT "comment_reports_resolved_by_fkey"
+  FOREIGN KEY ("resolved_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+-- Index untuk query daftar dan kolom foreign key
+CREATE INDEX IF NOT EXISTS "idx_users_role" ON "users" ("role");
+CREATE INDEX IF NOT EXISTS "idx_articles_created_at" ON "articles" ("created_at" DESC);
+CREATE INDEX IF NOT EXISTS "idx_articles_status" ON "articles" ("status");
+CREATE INDEX IF NOT EXISTS "idx_articles_category" ON "articles" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_articles_views_article" ON "articles_views" ("article_id");
+CREATE INDEX IF NOT EXISTS "idx_videos_status" ON "videos" ("status");
+CREATE INDEX IF NOT EXISTS "idx_videos_category" ON "videos" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_testimonials_status" ON "testimonials" ("status", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_testimonials_category" ON "testimonials" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_comments_status_created_at" ON "comments" ("status", "created_at");
+CREATE INDEX IF NOT EXISTS "idx_appointments_status_time" ON "appointments" ("status", "time");
+CREATE INDEX IF NOT EXISTS "idx_appointments_category" ON "appointments" ("category_id");
+CREATE INDEX IF NOT EXISTS "idx_webinars_host" ON "webinars" ("host_id");
@@ -0,0 +1,17 @@
+-- Laporan dari pembaca tanpa login tidak punya email, sehingga tidak bisa dikembalikan ke skema lama
+DELETE FROM "comment_reports" WHERE "reporter_email" IS NULL AND "reader_key" NOT LIKE 'legacy:%';
+UPDATE "comment_reports" SET "reporter_email" = substr("reader_key", length('legacy:') + 1)
+  WHERE "reporter_email" IS NULL AND "reader_key" LIKE 'legacy:%';
+DELETE FROM "comment_reports" a USING "comment_reports" b
+  WHERE a."comment_id" = b."comment_id" AND a."reporter_email" = b."reporter_email" AND a."id" > b."id";
+ALTER TABLE "comment_reports" DROP CONSTRAINT IF EXISTS "comment_reports_comment_id_reader_key_key";
+ALTER TABLE "comment_reports" ALTER COLUMN "reporter_email" SET NOT NULL;
+ALTER TABLE "comment_reports" ADD CONSTRAINT "comment_reports_comment_id_reporter_email_key" UNIQUE ("comment_id", "reporter_email");
+ALTER TABLE "comment_reports" DROP COLUMN "reader_key";
+
+ALTER TABLE "comment_reactions" RENAME CONSTRAINT "comment_reactions_comment_id_reader_key_key" TO "comment_reactions_comment_id_user_email_key";
+DELETE FROM "comment_reactions" a USING "comment_reactions" b
+  WHERE a."comment_id" = b."comment_id" AND a."id" > b."id"
+    AND regexp_replace(a."reader_key", '^(legacy|user|ip):', '') = regexp_replace(b."reader_key", '^(legacy|user|ip):', '');
+UPDATE "comment_reactions" SET "reader_key" = regexp_replace("reader_key", '^(legacy|user|ip):', '');
+ALTER TABLE "comment_reactions" RENAME COLUMN "reader_key" TO "user_email";
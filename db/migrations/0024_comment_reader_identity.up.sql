@@ -0,0 +1,12 @@
+-- Reaksi dan laporan dikenali dari email di JWT atau IP client, bukan email yang dikirim di body request.
+-- Data lama diberi awalan legacy: karena emailnya tidak pernah diverifikasi.
+ALTER TABLE "comment_reactions" RENAME COLUMN "user_email" TO "reader_key";
+UPDATE "comment_reactions" SET "reader_key" = 'legacy:' || "reader_key";
+ALTER TABLE "comment_reactions" RENAME CONSTRAINT "comment_reactions_comment_id_user_email_key" TO "comment_reactions_comment_id_reader_key_key";
+
+ALTER TABLE "comment_reports" ADD COLUMN "reader_key" varchar;
+UPDATE "comment_reports" SET "reader_key" = 'legacy:' || "reporter_email";
+ALTER TABLE "comment_reports" ALTER COLUMN "reader_key" SET NOT NULL;
+ALTER TABLE "comment_reports" ALTER COLUMN "reporter_email" DROP NOT NULL;
+ALTER TABLE "comment_reports" DROP CONSTRAINT IF EXISTS "comment_reports_comment_id_reporter_email_key";
+ALTER TABLE "comment_reports" ADD CONSTRAINT "comment_reports_comment_id_reader_key_key" UNIQUE ("comment_id", "reader_key");
@@ -0,0 +1,2 @@
+ALTER TABLE "appointments" DROP CONSTRAINT IF EXISTS "excl_appointments_host_overlap";
+CREATE UNIQUE INDEX IF NOT EXISTS "uniq_appointments_host_slot" ON "appointments" ("host_id", "time") WHERE status <> 'cancelled';
@@ -0,0 +1,10 @@
+-- Host tidak boleh punya dua appointment aktif dengan rentang waktu yang beririsan, bukan hanya jam mulai yang sama.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- end_time kosong diisi durasi bawaan 60 menit (appointment.DefaultDuration) agar rentangnya tidak terbuka
+UPDATE "appointments" SET "end_time" = "time" + interval '60 minutes' WHERE "end_time" IS NULL AND "time" IS NOT NULL;
+
+-- Gagal jika data lama sudah berisi jadwal host yang bentrok; selesaikan bentrokan tersebut lalu jalankan ulang
+DROP INDEX IF EXISTS "uniq_appointments_host_slot";
+ALTER TABLE "appointments" ADD CONSTRAINT "excl_appointments_host_overlap"
+  EXCLUDE USING gist ("host_id" WITH =, tstzrange("time", "end_time") WITH &&) WHERE (status <> 'cancelled');
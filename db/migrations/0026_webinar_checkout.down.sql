@@ -0,0 +1 @@
+ALTER TABLE "webinar_attendance" DROP COLUMN IF EXISTS "left_at";
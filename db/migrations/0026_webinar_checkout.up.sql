@@ -0,0 +1,2 @@
+-- Waktu peserta meninggalkan webinar; kosong berarti peserta mengikuti sampai sesi selesai
+ALTER TABLE "webinar_attendance" ADD COLUMN IF NOT EXISTS "left_at" timestamptz;
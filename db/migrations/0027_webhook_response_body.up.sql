@@ -0,0 +1,2 @@
+-- Isi respons penerima webhook tidak lagi disimpan agar endpoint uji tidak bisa dipakai membaca layanan lain
+ALTER TABLE "webhook_deliveries" DROP COLUMN IF EXISTS "response_body";
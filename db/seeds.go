@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"log"
+	"sort"
+)
+
+// seedFiles berisi data contoh untuk pengembangan lokal. Tidak pernah dijalankan otomatis.
+//
+//go:embed seeds/*.sql
+var seedFiles embed.FS
+
+// Seed mengisi database dengan data contoh dalam satu transaksi. Migrasi harus sudah dijalankan.
+func Seed(ctx context.Context) error {
+	names, err := fs.Glob(seedFiles, "seeds/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	tx, err := DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, name := range names {
+		content, err := seedFiles.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+			return err
+		}
+		log.Printf("seeded %s", name)
+	}
+	return tx.Commit()
+}
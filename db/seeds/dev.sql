@@ -0,0 +1,97 @@
+-- Data contoh untuk pengembangan lokal. Aman dijalankan berulang kali: baris yang sudah ada tidak digandakan.
+-- Semua akun memakai password "password123".
+
+INSERT INTO "users" ("name", "email", "password", "phone_number", "role", "status") VALUES
+  ('Admin Edukasi', 'admin@example.com', '$2a$10$5WVPt3aSwuZ3KH0LIvTFou3ueigiiz2YklWYw2uuyjg78EgbvWRwe', '+6281100000001', 'admin', 'active'),
+  ('Dewi Psikolog', 'dewi@example.com', '$2a$10$5WVPt3aSwuZ3KH0LIvTFou3ueigiiz2YklWYw2uuyjg78EgbvWRwe', '+6281100000002', 'staff', 'active'),
+  ('Budi Konselor', 'budi@example.com', '$2a$10$5WVPt3aSwuZ3KH0LIvTFou3ueigiiz2YklWYw2uuyjg78EgbvWRwe', '+6281100000003', 'staff', 'active'),
+  ('Sari Pembaca', 'sari@example.com', '$2a$10$5WVPt3aSwuZ3KH0LIvTFou3ueigiiz2YklWYw2uuyjg78EgbvWRwe', '+6281100000004', 'user', 'active')
+ON CONFLICT ("email") DO NOTHING;
+
+INSERT INTO "categories" ("name")
+SELECT c.name FROM (VALUES ('Parenting'), ('Kesehatan Mental'), ('Pendidikan Anak')) AS c(name)
+WHERE NOT EXISTS (SELECT 1 FROM "categories" WHERE "name" = c.name);
+
+INSERT INTO "staff_specialties" ("staff_id", "category_id")
+SELECT u.id, c.id FROM "users" u JOIN "categories" c ON (u.email, c.name) IN
+  (('dewi@example.com', 'Kesehatan Mental'), ('budi@example.com', 'Parenting'), ('budi@example.com', 'Pendidikan Anak'))
+ON CONFLICT DO NOTHING;
+
+-- Jadwal mingguan host: Senin sampai Jumat 09:00-16:00, slot 60 menit
+INSERT INTO "staff_availability" ("staff_id", "weekday", "start_time", "end_time", "slot_minutes")
+SELECT u.id, d.weekday, '09:00', '16:00', 60
+FROM "users" u CROSS JOIN generate_series(1, 5) AS d(weekday)
+WHERE u.email IN ('dewi@example.com', 'budi@example.com')
+  AND NOT EXISTS (SELECT 1 FROM "staff_availability" sa WHERE sa.staff_id = u.id AND sa.weekday = d.weekday);
+
+INSERT INTO "articles" ("category_id", "title", "slug", "tags", "content", "status", "meta_title", "meta_description", "author_id")
+SELECT c.id, a.title, a.slug, a.tags::json, a.content, a.status, a.title, a.description, u.id
+FROM (VALUES
+  ('Parenting', 'dewi@example.com', 'Mengenali Emosi Anak Sejak Dini', 'mengenali-emosi-anak-sejak-dini',
+   '["parenting", "emosi"]', 'Anak belajar mengenali emosi dari orang tua yang menamai perasaan mereka.', 'published',
+   'Cara sederhana membantu anak mengenali dan menamai emosinya.'),
+  ('Kesehatan Mental', 'dewi@example.com', 'Menjaga Kesehatan Mental Orang Tua', 'menjaga-kesehatan-mental-orang-tua',
+   '["kesehatan mental"]', 'Orang tua yang beristirahat cukup lebih sabar mendampingi anak.', 'published',
+   'Tips menjaga kesehatan mental di tengah kesibukan mengasuh.'),
+  ('Pendidikan Anak', 'budi@example.com', 'Rutinitas Belajar di Rumah', 'rutinitas-belajar-di-rumah',
+   '["belajar", "rutinitas"]', 'Rutinitas singkat dan konsisten lebih efektif daripada sesi panjang.', 'pending approval',
+   'Membangun kebiasaan belajar yang menyenangkan di rumah.')
+) AS a(category, author, title, slug, tags, content, status, description)
+JOIN "categories" c ON c.name = a.category
+JOIN "users" u ON u.email = a.author
+WHERE NOT EXISTS (SELECT 1 FROM "articles" WHERE "slug" = a.slug);
+
+INSERT INTO "videos" ("title", "description", "link_video", "category_id", "status", "author_id", "meta_title", "meta_description")
+SELECT v.title, v.description, v.link, c.id, v.status, u.id, v.title, v.description
+FROM (VALUES
+  ('Parenting', 'budi@example.com', 'Bermain Sambil Belajar', 'Ide permainan edukatif untuk anak usia dini.',
+   'https://www.youtube.com/watch?v=example1', 'published'),
+  ('Kesehatan Mental', 'dewi@example.com', 'Teknik Relaksasi Singkat', 'Latihan napas lima menit untuk orang tua.',
+   'https://www.youtube.com/watch?v=example2', 'pending approval')
+) AS v(category, author, title, description, link, status)
+JOIN "categories" c ON c.name = v.category
+JOIN "users" u ON u.email = v.author
+WHERE NOT EXISTS (SELECT 1 FROM "videos" WHERE "title" = v.title);
+
+INSERT INTO "testimonials" ("name", "comment", "category_id", "status")
+SELECT t.name, t.comment, c.id, t.status
+FROM (VALUES
+  ('Rina', 'Sesi konseling sangat membantu kami memahami anak.', 'Parenting', 'approved'),
+  ('Andi', 'Artikelnya praktis dan mudah diterapkan.', 'Pendidikan Anak', 'pending')
+) AS t(name, comment, category, status)
+JOIN "categories" c ON c.name = t.category
+WHERE NOT EXISTS (SELECT 1 FROM "testimonials" WHERE "name" = t.name AND "comment" = t.comment);
+
+INSERT INTO "comments" ("article_id", "username", "email", "comment", "status")
+SELECT a.id, cm.username, cm.email, cm.comment, cm.status
+FROM (VALUES
+  ('mengenali-emosi-anak-sejak-dini', 'Sari', 'sari@example.com', 'Terima kasih, sangat bermanfaat!', 'approved'),
+  ('mengenali-emosi-anak-sejak-dini', 'Tono', 'tono@example.com', 'Apakah ada tips untuk anak usia 2 tahun?', 'pending')
+) AS cm(slug, username, email, comment, status)
+JOIN "articles" a ON a.slug = cm.slug
+WHERE NOT EXISTS (SELECT 1 FROM "comments" WHERE "article_id" = a.id AND "email" = cm.email AND "comment" = cm.comment);
+
+-- Appointment pada hari kerja berikutnya pukul 10:00 waktu Jakarta, sesuai jadwal host di atas
+INSERT INTO "appointments" ("reference_code", "name", "phone_number", "email", "date_of_booking", "time", "end_time",
+                            "host_id", "category_id", "assignment_method", "host_assigned_at", "status")
+SELECT 'APT-DEV00001', 'Sari Pembaca', '+6281100000004', 'sari@example.com', s.day, s.start, s.start + interval '1 hour',
+       u.id, c.id, 'manual', now(), 'pending'
+FROM (
+  SELECT day, (day + time '10:00') AT TIME ZONE 'Asia/Jakarta' AS start
+  FROM (SELECT (current_date + n) AS day FROM generate_series(1, 7) AS n) d
+  WHERE extract(isodow FROM day) < 6
+  ORDER BY day LIMIT 1
+) s
+JOIN "users" u ON u.email = 'dewi@example.com'
+JOIN "categories" c ON c.name = 'Kesehatan Mental'
+WHERE NOT EXISTS (SELECT 1 FROM "appointments" WHERE "reference_code" = 'APT-DEV00001');
+
+INSERT INTO "webinars" ("title", "description", "link_meet", "host_id", "start_time", "end_time", "capacity",
+                        "registration_deadline", "status")
+SELECT 'Webinar Pengasuhan Positif', 'Diskusi bersama psikolog tentang disiplin tanpa hukuman.',
+       'https://meet.example.com/pengasuhan-positif', u.id,
+       date_trunc('day', now()) + interval '14 days 19 hours', date_trunc('day', now()) + interval '14 days 21 hours',
+       100, date_trunc('day', now()) + interval '14 days 12 hours', 'scheduled'
+FROM "users" u
+WHERE u.email = 'dewi@example.com'
+  AND NOT EXISTS (SELECT 1 FROM "webinars" WHERE "title" = 'Webinar Pengasuhan Positif');
@@ -5,6 +5,8 @@ go 1.23.3
 require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/gorilla/mux v1.8.1
+	golang.org/x/image v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -13,7 +15,7 @@ require (
 	github.com/lib/pq v1.10.9 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/stretchr/testify v1.10.0 // indirect
-	golang.org/x/sync v0.9.0 // indirect
+	golang.org/x/sync v0.12.0 // indirect
 )
 
 require (
@@ -23,5 +25,5 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/twilio/twilio-go v1.23.6
 	golang.org/x/crypto v0.29.0
-	golang.org/x/text v0.20.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
 )
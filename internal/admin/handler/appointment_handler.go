@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
 	"go-project/internal/admin/service"
+	"go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"go-project/pkg/middleware"
 	"log"
 	"net/http"
 	"strconv"
@@ -64,8 +70,8 @@ func (h *AppointmentHandler) CreateAppointment(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := h.Service.CreateAppointment(&appointment); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.Service.CreateAppointment(&appointment, middleware.GetUserEmail(r.Context())); err != nil {
+		writeHostError(w, err)
 		return
 	}
 
@@ -104,7 +110,7 @@ func (h *AppointmentHandler) AssignHost(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.Service.AssignHost(appointmentID, requestBody.HostID); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeHostError(w, err)
 		return
 	}
 
@@ -112,13 +118,105 @@ func (h *AppointmentHandler) AssignHost(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte("Host assigned successfully"))
 }
 
+// SetStaffSpecialties
+// --------------------
+// Fungsi ini digunakan untuk mengatur kategori yang ditangani seorang staff, dipakai oleh
+// strategi penentuan host "specialty".
+//
+// Parameter:
+// - id (path variable): ID staff.
+// - category_ids (JSON body): Daftar ID kategori.
+func (h *AppointmentHandler) SetStaffSpecialties(w http.ResponseWriter, r *http.Request) {
+	staffID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid staff ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		CategoryIDs []int `json:"category_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.SetStaffSpecialties(staffID, requestBody.CategoryIDs); err != nil {
+		writeHostError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Specialties updated successfully"))
+}
+
+// GenerateMeetingLink
+// --------------------
+// Fungsi ini digunakan untuk membuat ulang link meeting sebuah appointment melalui MeetingProvider.
+//
+// Parameter:
+// - id (path variable): ID appointment.
+func (h *AppointmentHandler) GenerateMeetingLink(w http.ResponseWriter, r *http.Request) {
+	appointmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid appointment ID", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.Service.GenerateMeetingLink(appointmentID)
+	if err != nil {
+		writeHostError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"link_meet": link})
+}
+
+// DownloadCalendar
+// -----------------
+// Fungsi ini digunakan untuk mengunduh file .ics sebuah appointment.
+//
+// Parameter:
+// - id (path variable): ID appointment.
+func (h *AppointmentHandler) DownloadCalendar(w http.ResponseWriter, r *http.Request) {
+	appointmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid appointment ID", http.StatusBadRequest)
+		return
+	}
+
+	cal, err := h.Service.GetCalendar(appointmentID)
+	if err != nil {
+		writeHostError(w, err)
+		return
+	}
+	calendar.Serve(w, "appointment-"+strconv.Itoa(appointmentID), cal)
+}
+
+// writeHostError memetakan error validasi host ke status HTTP yang sesuai.
+func writeHostError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Appointment not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrHostNotFound), errors.Is(err, service.ErrHostNotStaff):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrHostBusy), errors.Is(err, appointment.ErrNoHostAvailable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // UpdateStatus
 // -------------
-// Fungsi ini digunakan untuk mengupdate status dari sebuah appointment.
+// Fungsi ini digunakan untuk mengupdate status dari sebuah appointment sesuai aturan lifecycle
+// (pending -> confirmed -> rescheduled -> completed / cancelled / no-show).
 //
 // Parameter:
 // - id (path variable): ID appointment yang statusnya akan diubah.
-// - status (JSON body): Status baru yang akan diterapkan (approve/pending/rejected).
+// - status (JSON body): Status baru yang akan diterapkan.
+// - note (JSON body, opsional): Catatan yang disimpan pada timeline.
 
 func (h *AppointmentHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -130,17 +228,74 @@ func (h *AppointmentHandler) UpdateStatus(w http.ResponseWriter, r *http.Request
 
 	var data struct {
 		Status string `json:"status"`
+		Note   string `json:"note"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		http.Error(w, "Invalid input", http.StatusBadRequest)
 		return
 	}
 
-	if err := h.Service.UpdateStatus(appointmentID, data.Status); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.Service.UpdateStatus(appointmentID, data.Status, data.Note, middleware.GetUserEmail(r.Context())); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			http.Error(w, "Appointment not found", http.StatusNotFound)
+		case errors.Is(err, appointment.ErrInvalidTransition):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Status updated successfully"))
 }
+
+// GetTimeline
+// -------------
+// Fungsi ini digunakan untuk mengambil riwayat perubahan status sebuah appointment.
+//
+// Parameter:
+// - id (path variable): ID appointment.
+
+func (h *AppointmentHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	appointmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid appointment ID", http.StatusBadRequest)
+		return
+	}
+
+	timeline, err := h.Service.GetTimeline(appointmentID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(timeline)
+}
+
+// GetWhatsAppMessages
+// --------------------
+// Fungsi ini digunakan untuk mengambil pesan WhatsApp masuk dari klien untuk sebuah appointment,
+// beserta tindakan (konfirmasi/batal) dan balasan yang dikirim.
+//
+// Parameter:
+// - id (path variable): ID appointment.
+
+func (h *AppointmentHandler) GetWhatsAppMessages(w http.ResponseWriter, r *http.Request) {
+	appointmentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid appointment ID", http.StatusBadRequest)
+		return
+	}
+
+	messages, err := h.Service.GetWhatsAppMessages(appointmentID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(messages)
+}
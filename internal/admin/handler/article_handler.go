@@ -2,12 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
 	"go-project/internal/admin/service"
+	"go-project/pkg/middleware"
 	"log"
 	"net/http"
 	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 type ArticleHandler struct {
@@ -154,3 +159,42 @@ func (h *ArticleHandler) GetAllArticles(w http.ResponseWriter, r *http.Request)
 
 	json.NewEncoder(w).Encode(articles)
 }
+
+// ReviewArticle
+// --------------
+// Fungsi ini digunakan reviewer untuk menyetujui, menolak, atau meminta revisi artikel.
+//
+// Parameter:
+// - id (path parameter): ID artikel yang direview.
+// - JSON body: status ("approval", "changes requested", "rejected") dan feedback untuk penulis.
+
+func (h *ArticleHandler) ReviewArticle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var data struct {
+		Status   string `json:"status"`
+		Feedback string `json:"feedback"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.Service.ReviewArticle(id, data.Status, data.Feedback, middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		log.Printf("Error reviewing article: %v", err)
+		status := http.StatusBadRequest
+		if errors.Is(err, repository.ErrNotReviewable) {
+			status = http.StatusConflict
+		}
+		http.Error(w, "Error reviewing article: "+err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
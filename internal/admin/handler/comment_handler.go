@@ -2,7 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"go-project/internal/admin/model"
 	"go-project/internal/admin/service"
+	"go-project/pkg/middleware"
+	"go-project/pkg/utils"
 	"net/http"
 	"strconv"
 
@@ -125,8 +129,13 @@ func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	comment.IPAddress = utils.ClientIP(r)
 
 	createdComment, err := h.service.CreateComment(comment)
+	if errors.Is(err, service.ErrInvalidParent) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -160,7 +169,12 @@ func (h *CommentHandler) ReplyComment(w http.ResponseWriter, r *http.Request) {
 
 	// Set ParentID to the comment ID for the reply
 	reply.ParentID = &commentID
+	reply.IPAddress = utils.ClientIP(r)
 	createdReply, err := h.service.CreateComment(reply)
+	if errors.Is(err, service.ErrInvalidParent) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -169,3 +183,75 @@ func (h *CommentHandler) ReplyComment(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(createdReply)
 }
+
+// BulkModerateComments
+// ---------------------
+// Fungsi ini digunakan untuk menyetujui, menolak, atau menghapus banyak komentar sekaligus.
+//
+// Parameter:
+// - JSON body: action ("approve"/"reject"/"delete"), ids, atau filter (status, article_id, email).
+//   Filter minimal berisi satu kolom, dan aksi delete wajib memakai ids.
+
+func (h *CommentHandler) BulkModerateComments(w http.ResponseWriter, r *http.Request) {
+	var req model.BulkActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.BulkModerate(req, middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetReportedComments
+// --------------------
+// Fungsi ini digunakan untuk mengambil antrian komentar yang dilaporkan pembaca.
+
+func (h *CommentHandler) GetReportedComments(w http.ResponseWriter, r *http.Request) {
+	comments, err := h.service.GetReportedComments()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(comments)
+}
+
+// ResolveReports
+// ---------------
+// Fungsi ini digunakan untuk menangani laporan sebuah komentar.
+//
+// Parameter:
+// - id (path parameter): ID komentar yang dilaporkan.
+// - JSON body: action ("dismiss" untuk menutup laporan, "remove" untuk menutup laporan dan menolak komentar).
+
+func (h *CommentHandler) ResolveReports(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	commentID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var data struct {
+		Action string `json:"action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ResolveReports(commentID, data.Action, middleware.GetUserEmail(r.Context())); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
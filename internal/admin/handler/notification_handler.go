@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+	"go-project/internal/admin/service"
+	"go-project/pkg/middleware"
+	"go-project/pkg/notify"
+
+	"github.com/gorilla/mux"
+)
+
+type NotificationHandler struct {
+	service service.NotificationService
+}
+
+// NewNotificationHandler
+// -----------------------
+// Fungsi ini digunakan untuk menginisialisasi handler notifikasi
+// dengan menghubungkan ke layer service.
+//
+// Parameter:
+// - service: Instance dari NotificationService yang menyediakan logika bisnis.
+//
+// Return:
+// - Pointer ke NotificationHandler yang telah diinisialisasi.
+func NewNotificationHandler(service service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// GetOutbox
+// ----------
+// Fungsi ini digunakan untuk memeriksa event notifikasi di outbox.
+//
+// Query Parameter:
+// - status (opsional): pending, dispatched atau dead. Bawaan dead.
+func (h *NotificationHandler) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	outbox, err := h.service.GetOutbox(r.URL.Query().Get("status"))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(outbox)
+}
+
+// RetryOutbox
+// ------------
+// Fungsi ini digunakan untuk mengirim ulang event outbox yang masuk dead-letter.
+//
+// Parameter:
+// - id (path parameter): ID event outbox.
+func (h *NotificationHandler) RetryOutbox(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid outbox ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RetryOutbox(id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notification event queued for retry"})
+}
+
+// GetDeliveries
+// --------------
+// Fungsi ini digunakan untuk memeriksa pengiriman notifikasi per penerima dan kanal.
+//
+// Query Parameter:
+// - status (opsional): pending, sent atau dead. Bawaan dead.
+func (h *NotificationHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.service.GetDeliveries(r.URL.Query().Get("status"))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// RetryDelivery
+// --------------
+// Fungsi ini digunakan untuk mengirim ulang satu pengiriman yang masuk dead-letter.
+//
+// Parameter:
+// - id (path parameter): ID pengiriman notifikasi.
+func (h *NotificationHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid delivery ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.service.RetryDelivery(id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notification delivery queued for retry"})
+}
+
+// RetryDeadDeliveries
+// --------------------
+// Fungsi ini digunakan untuk mengirim ulang semua pengiriman yang masuk dead-letter,
+// misalnya setelah gangguan penyedia WhatsApp atau email selesai.
+func (h *NotificationHandler) RetryDeadDeliveries(w http.ResponseWriter, r *http.Request) {
+	count, err := h.service.RetryDeadDeliveries()
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"message": "Dead notification deliveries queued for retry", "count": count})
+}
+
+// ListTemplates
+// --------------
+// Fungsi ini digunakan untuk mengambil template pesan notifikasi yang berlaku untuk semua event
+// dan bahasa, beserta variabel yang bisa dipakai di setiap template.
+func (h *NotificationHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.ListTemplates()
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templates)
+}
+
+// GetTemplate
+// ------------
+// Fungsi ini digunakan untuk mengambil template pesan satu event dalam satu bahasa.
+//
+// Parameter:
+// - event (path parameter): Nama event, misalnya article.submitted.
+// - locale (path parameter): Bahasa pesan, id atau en.
+func (h *NotificationHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tmpl, err := h.service.GetTemplate(vars["event"], vars["locale"])
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tmpl)
+}
+
+// UpdateTemplate
+// ---------------
+// Fungsi ini digunakan untuk mengubah template pesan tanpa deploy. Template memakai sintaks
+// Go template, misalnya {{.Title}}, dan ditolak jika memakai variabel yang tidak tersedia.
+//
+// Parameter:
+// - event, locale (path parameter): Template yang diubah.
+// - JSON body: {"subject": "...", "body": "..."}.
+func (h *NotificationHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Subject string `json:"subject"`
+		Body    string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	tmpl := notify.Template{Event: vars["event"], Locale: vars["locale"], Subject: req.Subject, Body: req.Body}
+	saved, err := h.service.SaveTemplate(tmpl, middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
+
+// ResetTemplate
+// --------------
+// Fungsi ini digunakan untuk mengembalikan template pesan ke bawaan aplikasi.
+//
+// Parameter:
+// - event, locale (path parameter): Template yang dikembalikan.
+func (h *NotificationHandler) ResetTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tmpl, err := h.service.ResetTemplate(vars["event"], vars["locale"])
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tmpl)
+}
+
+// PreviewTemplate
+// ----------------
+// Fungsi ini digunakan untuk melihat hasil render template sebelum disimpan.
+//
+// Parameter:
+//   - JSON body: event, locale, subject dan body (opsional, bawaan template yang berlaku),
+//     serta vars (opsional, bawaan contoh variabel event).
+func (h *NotificationHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	var req model.TemplatePreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := h.service.PreviewTemplate(req)
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+// writeNotificationError memetakan error outbox notifikasi ke status HTTP
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Notification not found", http.StatusNotFound)
+	case errors.Is(err, notify.ErrUnknownTemplate):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidNotificationStatus), errors.Is(err, notify.ErrInvalidTemplate):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrNotRetryable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"go-project/internal/admin/model"
 	"go-project/internal/admin/service"
+	"go-project/pkg/middleware"
 
 	"github.com/gorilla/mux"
 )
@@ -197,3 +198,28 @@ func (h *TestimonialHandler) RejectTestimonial(w http.ResponseWriter, r *http.Re
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// BulkModerateTestimonials
+// -------------------------
+// Fungsi ini digunakan untuk menyetujui, menolak, atau menghapus banyak testimonial sekaligus.
+//
+// Parameter:
+// - JSON body: action ("approve"/"reject"/"delete"), ids, atau filter (status, category_id).
+//   Filter minimal berisi satu kolom, dan aksi delete wajib memakai ids.
+
+func (h *TestimonialHandler) BulkModerateTestimonials(w http.ResponseWriter, r *http.Request) {
+	var req model.BulkActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.BulkModerate(req, middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
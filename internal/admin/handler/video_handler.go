@@ -2,11 +2,15 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"go-project/internal/admin/repository"
 	"go-project/internal/admin/service"
+	"go-project/pkg/middleware"
 	"log"
 	"net/http"
 	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 // VideoHandler adalah struct yang menyediakan metode untuk menangani permintaan HTTP terkait video.
@@ -137,3 +141,35 @@ func (h *VideoHandler) DeleteVideo(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ReviewVideo menangani review video oleh admin. Fungsi ini membaca status dan feedback dari body
+// permintaan, lalu memperbarui status video sekaligus mencatat feedback ke riwayat review.
+func (h *VideoHandler) ReviewVideo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id <= 0 {
+		http.Error(w, "ID tidak valid", http.StatusBadRequest)
+		return
+	}
+
+	var data struct {
+		Status   string `json:"status"`
+		Feedback string `json:"feedback"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Payload JSON tidak valid", http.StatusBadRequest)
+		return
+	}
+
+	review, err := h.Service.ReviewVideo(id, data.Status, data.Feedback, middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, repository.ErrNotReviewable) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
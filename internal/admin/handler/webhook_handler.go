@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+	"go-project/internal/admin/service"
+	"go-project/pkg/webhook"
+
+	"github.com/gorilla/mux"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+// NewWebhookHandler
+// ------------------
+// Fungsi ini digunakan untuk menginisialisasi handler webhook
+// dengan menghubungkan ke layer service.
+//
+// Parameter:
+// - service: Instance dari WebhookService yang menyediakan logika bisnis.
+//
+// Return:
+// - Pointer ke WebhookHandler yang telah diinisialisasi.
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// GetEvents
+// ----------
+// Fungsi ini digunakan untuk mengambil daftar event yang bisa dilanggan beserta keterangannya.
+func (h *WebhookHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook.Events)
+}
+
+// CreateSubscription
+// -------------------
+// Fungsi ini digunakan untuk mendaftarkan URL penerima webhook.
+//
+// Parameter:
+// - JSON body: url, events, description, active dan secret (opsional, dibuat otomatis jika kosong).
+//
+// Secret hanya ditampilkan pada respons ini; simpan untuk memverifikasi header X-Webhook-Signature.
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	sub := model.WebhookSubscription{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateSubscription(&sub); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sub)
+}
+
+// GetSubscriptions
+// -----------------
+// Fungsi ini digunakan untuk mengambil semua langganan webhook.
+func (h *WebhookHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.service.GetSubscriptions()
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subs)
+}
+
+// GetSubscriptionByID
+// --------------------
+// Fungsi ini digunakan untuk mengambil satu langganan webhook.
+//
+// Parameter:
+// - id (path parameter): ID langganan.
+func (h *WebhookHandler) GetSubscriptionByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	sub, err := h.service.GetSubscriptionByID(id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sub)
+}
+
+// UpdateSubscription
+// -------------------
+// Fungsi ini digunakan untuk memperbarui langganan webhook.
+//
+// Parameter:
+// - id (path parameter): ID langganan.
+// - JSON body: url, events, description, active dan secret (opsional, diganti jika diisi).
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	var sub model.WebhookSubscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	sub.ID = id
+
+	if err := h.service.UpdateSubscription(&sub); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	sub.Secret = ""
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sub)
+}
+
+// DeleteSubscription
+// -------------------
+// Fungsi ini digunakan untuk menghapus langganan webhook beserta log pengirimannya.
+//
+// Parameter:
+// - id (path parameter): ID langganan.
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteSubscription(id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Webhook subscription deleted successfully"})
+}
+
+// GetDeliveries
+// --------------
+// Fungsi ini digunakan untuk melihat log pengiriman sebuah langganan, yang terbaru lebih dulu.
+//
+// Parameter:
+// - id (path parameter): ID langganan.
+// - status (query, opsional): pending, sent atau dead.
+// - limit (query, opsional): jumlah maksimal baris, bawaan 50.
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	deliveries, err := h.service.GetDeliveries(id, r.URL.Query().Get("status"), limit)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// RetryDelivery
+// --------------
+// Fungsi ini digunakan untuk mengirim ulang pengiriman webhook yang masuk dead-letter.
+//
+// Parameter:
+// - id (path parameter): ID pengiriman.
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	if err := h.service.RetryDelivery(id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Webhook delivery queued for retry"})
+}
+
+// SendTestEvent
+// --------------
+// Fungsi ini digunakan untuk mengirim event webhook.test ke sebuah langganan dan melihat
+// hasilnya secara langsung (status respons, isi respons dan error).
+//
+// Parameter:
+// - id (path parameter): ID langganan.
+func (h *WebhookHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r, "id")
+	if !ok {
+		return
+	}
+	delivery, err := h.service.SendTestEvent(id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(delivery)
+}
+
+// webhookID membaca ID dari path; menulis 400 jika tidak valid
+func webhookID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// writeWebhookError memetakan error webhook ke status HTTP
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidWebhook), errors.Is(err, service.ErrInvalidNotificationStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrNotRetryable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"go-project/config"
 	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
 	"go-project/internal/admin/service"
+	"go-project/pkg/webinar"
+
+	"github.com/gorilla/mux"
 )
 
 type WebinarHandler struct {
@@ -25,10 +32,312 @@ func (h *WebinarHandler) CreateWebinar(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.CreateWebinar(&webinar); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeWebinarError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{"message": "Webinar created successfully", "id": strconv.Itoa(webinar.ID)})
 }
+
+// GetAllWebinars
+// ---------------
+// Fungsi ini digunakan untuk mengambil semua webinar.
+//
+// Parameter:
+// - status (query, opsional): draft, scheduled, live, ended atau cancelled.
+func (h *WebinarHandler) GetAllWebinars(w http.ResponseWriter, r *http.Request) {
+	webinars, err := h.service.GetAllWebinars(r.URL.Query().Get("status"))
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webinars)
+}
+
+// GetWebinarByID
+// ---------------
+// Fungsi ini digunakan untuk mengambil detail webinar beserta jumlah pendaftarnya.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+func (h *WebinarHandler) GetWebinarByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	webinar, err := h.service.GetWebinarByID(id)
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webinar)
+}
+
+// UpdateWebinar
+// --------------
+// Fungsi ini digunakan untuk memperbarui detail, jadwal dan kapasitas webinar.
+// Jika kapasitas bertambah, pendaftar di daftar tunggu otomatis mendapat kursi.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - JSON body: data webinar seperti pada CreateWebinar.
+func (h *WebinarHandler) UpdateWebinar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	var webinar model.Webinar
+	if err := json.NewDecoder(r.Body).Decode(&webinar); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	webinar.ID = id
+
+	if err := h.service.UpdateWebinar(&webinar); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Webinar updated successfully"))
+}
+
+// DeleteWebinar
+// --------------
+// Fungsi ini digunakan untuk menghapus webinar. Webinar yang sudah memiliki pendaftar
+// harus dibatalkan terlebih dahulu.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+func (h *WebinarHandler) DeleteWebinar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteWebinar(id); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Webinar deleted successfully"))
+}
+
+// UpdateStatus
+// -------------
+// Fungsi ini digunakan untuk mengubah status webinar
+// (draft -> scheduled -> live -> ended, atau cancelled sebelum dimulai).
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - status (JSON body): Status baru.
+func (h *WebinarHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateStatus(id, requestBody.Status); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Status updated successfully"))
+}
+
+// CancelWebinar
+// --------------
+// Fungsi ini digunakan untuk membatalkan webinar. Semua pendaftar akan menerima pemberitahuan.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - reason (JSON body, opsional): Alasan pembatalan yang disampaikan ke pendaftar.
+func (h *WebinarHandler) CancelWebinar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CancelWebinar(id, requestBody.Reason); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Webinar cancelled successfully"))
+}
+
+// ReassignHost
+// -------------
+// Fungsi ini digunakan untuk mengganti host webinar.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - host_id (JSON body): ID staff yang menjadi host baru.
+func (h *WebinarHandler) ReassignHost(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		HostID int `json:"host_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil || requestBody.HostID <= 0 {
+		http.Error(w, "Invalid host ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ReassignHost(id, requestBody.HostID); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Host reassigned successfully"))
+}
+
+// SetRecording
+// -------------
+// Fungsi ini digunakan untuk menautkan video rekaman ke webinar yang sudah selesai.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - video_id (JSON body): ID video pada tabel videos.
+func (h *WebinarHandler) SetRecording(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	var requestBody struct {
+		VideoID int `json:"video_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetRecording(id, requestBody.VideoID); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Recording linked successfully"))
+}
+
+// GetRegistrations
+// -----------------
+// Fungsi ini digunakan untuk mengambil daftar peserta sebuah webinar.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - status (query, opsional): "registered", "waitlisted" atau "cancelled".
+func (h *WebinarHandler) GetRegistrations(w http.ResponseWriter, r *http.Request) {
+	webinarID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	registrations, err := h.service.GetRegistrations(webinarID, r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(registrations)
+}
+
+// GetAttendance
+// --------------
+// Fungsi ini digunakan untuk mengambil laporan kehadiran webinar.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+// - format (query, opsional): "csv" untuk mengunduh laporan sebagai file CSV.
+func (h *WebinarHandler) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	records, err := h.service.GetAttendance(id)
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	if r.URL.Query().Get("format") == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="attendance-webinar-`+strconv.Itoa(id)+`.csv"`)
+		webinar.WriteAttendanceCSV(w, records, config.Location())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// IssueCertificates
+// ------------------
+// Fungsi ini digunakan untuk menerbitkan sertifikat yang belum ada untuk webinar yang sudah selesai,
+// misalnya setelah data kehadiran diperbaiki.
+//
+// Parameter:
+// - id (path variable): ID webinar.
+func (h *WebinarHandler) IssueCertificates(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	issued, err := h.service.IssueCertificates(id)
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"issued": issued})
+}
+
+// writeWebinarError memetakan error pengelolaan webinar ke status HTTP yang sesuai.
+func writeWebinarError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Webinar not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidWebinar), errors.Is(err, service.ErrHostNotFound),
+		errors.Is(err, service.ErrHostNotStaff), errors.Is(err, repository.ErrVideoNotFound):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, webinar.ErrInvalidTransition), errors.Is(err, service.ErrWebinarClosed),
+		errors.Is(err, repository.ErrWebinarHasRegistrations), errors.Is(err, repository.ErrWebinarNotEnded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -5,19 +5,22 @@ import (
 )
 
 type Appointment struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	PhoneNumber   string    `json:"phone_number"`
-	Email         string    `json:"email"`
-	DateOfBooking time.Time `json:"date_of_booking"`
-	Time          time.Time `json:"time"`
-	LinkMeet      string    `json:"link_meet"`
-	HostID        int       `json:"host_id"`
-	Status        string    `json:"status"`
-	PDFFile       string    `json:"pdf_file"` // New field for PDF file
-	Img           string    `json:"img"`      // New field for image
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	PhoneNumber      string    `json:"phone_number"`
+	Email            string    `json:"email"`
+	DateOfBooking    time.Time `json:"date_of_booking"`
+	Time             time.Time `json:"time"`
+	EndTime          time.Time `json:"end_time"` // Jika kosong diisi dengan durasi bawaan
+	LinkMeet         string    `json:"link_meet"`
+	HostID           int       `json:"host_id"` // 0 berarti host ditentukan otomatis jika strategi aktif
+	CategoryID       int       `json:"category_id,omitempty"`
+	AssignmentMethod string    `json:"assignment_method,omitempty"` // "manual" atau nama strategi yang memilih host
+	Status           string    `json:"status"`
+	PDFFile          string    `json:"pdf_file"` // New field for PDF file
+	Img              string    `json:"img"`      // New field for image
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 type Staff struct {
 	ID    int    `json:"id"`
@@ -25,3 +28,26 @@ type Staff struct {
 	Email string `json:"email"`
 	Role  string `json:"role"`
 }
+
+// AppointmentStatusChange adalah satu entri pada timeline perubahan status appointment
+type AppointmentStatusChange struct {
+	ID            int       `json:"id"`
+	AppointmentID int       `json:"appointment_id"`
+	FromStatus    string    `json:"from_status,omitempty"`
+	ToStatus      string    `json:"to_status"`
+	ChangedBy     string    `json:"changed_by"`
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WhatsAppMessage adalah pesan WhatsApp masuk dari klien yang terhubung ke sebuah appointment
+type WhatsAppMessage struct {
+	ID            int       `json:"id"`
+	AppointmentID int       `json:"appointment_id"`
+	From          string    `json:"from"`
+	Body          string    `json:"body"`
+	Action        string    `json:"action,omitempty"` // "confirm" atau "cancel"
+	Result        string    `json:"result"`
+	Reply         string    `json:"reply,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
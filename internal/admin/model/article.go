@@ -1,6 +1,8 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -27,3 +29,35 @@ type Article struct {
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
+
+// ArticleColumns adalah kolom artikel dengan urutan yang sama dengan ScanArticle, sehingga query tidak
+// bergantung pada urutan kolom di tabel.
+const ArticleColumns = `id, COALESCE(category_id, 0), COALESCE(title, ''), COALESCE(slug, ''), tags, COALESCE(content, ''),
+              COALESCE(message, ''), COALESCE(thumbnail, ''), COALESCE(alt_thumbnail, ''), COALESCE(banner, ''),
+              COALESCE(alt_banner, ''), COALESCE(poster, ''), COALESCE(alt_poster, ''), COALESCE(link_video, ''),
+              COALESCE(status, ''), COALESCE(meta_title, ''), COALESCE(meta_description, ''), COALESCE(author_id, 0),
+              created_at, updated_at`
+
+// ScanArticle membaca satu baris hasil SELECT ArticleColumns dari *sql.Row atau *sql.Rows.
+// Tags disimpan sebagai JSON dan dikembalikan sebagai slice kosong jika tidak diisi.
+func ScanArticle(row interface{ Scan(dest ...any) error }) (Article, error) {
+	var article Article
+	var tags []byte
+	err := row.Scan(
+		&article.ID, &article.CategoryID, &article.Title, &article.Slug, &tags, &article.Content,
+		&article.Message, &article.Thumbnail, &article.AltThumbnail, &article.Banner, &article.AltBanner,
+		&article.Poster, &article.AltPoster, &article.LinkVideo, &article.Status, &article.MetaTitle,
+		&article.MetaDescription, &article.AuthorID, &article.CreatedAt, &article.UpdatedAt,
+	)
+	if err != nil {
+		return Article{}, err
+	}
+
+	article.Tags = []string{}
+	if len(tags) > 0 && string(tags) != "null" {
+		if err := json.Unmarshal(tags, &article.Tags); err != nil {
+			return Article{}, fmt.Errorf("error unmarshaling tags JSON: %w", err)
+		}
+	}
+	return article, nil
+}
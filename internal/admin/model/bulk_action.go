@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// BulkActionRequest adalah permintaan moderasi massal. Jika IDs kosong, item dipilih berdasarkan Filter;
+// aksi delete selalu membutuhkan IDs.
+type BulkActionRequest struct {
+	Action string      `json:"action"` // "approve", "reject" atau "delete"
+	IDs    []int       `json:"ids"`
+	Filter *BulkFilter `json:"filter,omitempty"`
+}
+
+// BulkFilter memilih item yang akan dimoderasi tanpa menyebutkan ID satu per satu
+type BulkFilter struct {
+	Status     string `json:"status,omitempty"`
+	ArticleID  int    `json:"article_id,omitempty"`  // Hanya untuk komentar
+	Email      string `json:"email,omitempty"`       // Hanya untuk komentar
+	CategoryID int    `json:"category_id,omitempty"` // Hanya untuk testimonial
+}
+
+// BulkItemResult adalah hasil moderasi untuk satu item
+type BulkItemResult struct {
+	ID      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkActionResult adalah ringkasan hasil moderasi massal
+type BulkActionResult struct {
+	Action    string           `json:"action"`
+	Total     int              `json:"total"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// ModerationAction mencatat satu tindakan moderasi terhadap komentar atau testimonial
+type ModerationAction struct {
+	ID         int       `json:"id"`
+	EntityType string    `json:"entity_type"` // "comment" atau "testimonial"
+	EntityID   int       `json:"entity_id"`
+	Action     string    `json:"action"`
+	ActorID    *int      `json:"actor_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
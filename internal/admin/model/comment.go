@@ -12,4 +12,16 @@ type Comment struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	IPAddress        string  `json:"ip_address,omitempty"`
+	ModerationScore  float64 `json:"moderation_score"`            // Skor dari pipeline moderasi otomatis
+	ModerationReason string  `json:"moderation_reason,omitempty"` // Alasan skor moderasi
+}
+
+// ReportedComment adalah komentar di antrian moderator karena dilaporkan pembaca
+type ReportedComment struct {
+	Comment
+	OpenReports    int       `json:"open_reports"`     // Jumlah laporan yang belum ditangani
+	Reasons        []string  `json:"reasons"`          // Alasan dari setiap pelapor
+	LastReportedAt time.Time `json:"last_reported_at"` // Waktu laporan terakhir
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationOutbox adalah event notifikasi yang dicatat bersama perubahan konten
+type NotificationOutbox struct {
+	ID            int             `json:"id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"` // pending, dispatched atau dead
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DispatchedAt  *time.Time      `json:"dispatched_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NotificationDelivery adalah pengiriman satu event ke satu penerima lewat satu kanal
+type NotificationDelivery struct {
+	ID            int        `json:"id"`
+	OutboxID      int        `json:"outbox_id"`
+	Event         string     `json:"event"`
+	Channel       string     `json:"channel"`
+	UserID        int        `json:"user_id"`
+	UserName      string     `json:"user_name"`
+	Status        string     `json:"status"` // pending, sent atau dead
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TemplatePreviewRequest adalah permintaan pratinjau template. Subject dan Body kosong berarti
+// memakai template yang sedang berlaku; Vars kosong berarti memakai contoh variabel event.
+type TemplatePreviewRequest struct {
+	Event   string          `json:"event"`
+	Locale  string          `json:"locale"`
+	Subject string          `json:"subject"`
+	Body    string          `json:"body"`
+	Vars    json.RawMessage `json:"vars"`
+}
+
+// TemplatePreview adalah hasil render template
+type TemplatePreview struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -1,11 +1,33 @@
 package model
 
+import "time"
+
 type Webinar struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	LinkMeet    string `json:"link_meet"`
-	HostID      int    `json:"host_id"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID                   int       `json:"id"`
+	Title                string    `json:"title"`
+	Description          string    `json:"description"`
+	LinkMeet             string    `json:"link_meet"`
+	HostID               int       `json:"host_id"`
+	StartTime            time.Time `json:"start_time"`
+	EndTime              time.Time `json:"end_time"`
+	Capacity             int       `json:"capacity,omitempty"`              // 0 berarti tidak dibatasi
+	RegistrationDeadline time.Time `json:"registration_deadline,omitempty"` // Kosong berarti dibuka sampai webinar dimulai
+	Status               string    `json:"status"`                          // draft, scheduled, live, ended atau cancelled
+	CancellationReason   string    `json:"cancellation_reason,omitempty"`
+	RecordingVideoID     int       `json:"recording_video_id,omitempty"` // Video rekaman, diisi setelah webinar selesai
+	CertificateThreshold int       `json:"certificate_threshold"`        // Persentase kehadiran minimal untuk sertifikat
+	RegisteredCount      int       `json:"registered_count"`
+	WaitlistCount        int       `json:"waitlist_count"`
+	CreatedAt            string    `json:"created_at"`
+	UpdatedAt            string    `json:"updated_at"`
+}
+
+// WebinarRegistration adalah satu pendaftar webinar pada daftar peserta
+type WebinarRegistration struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	PhoneNumber string    `json:"phone_number,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
 }
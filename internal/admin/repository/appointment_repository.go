@@ -2,7 +2,12 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"go-project/internal/admin/model"
+	"go-project/pkg/appointment"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // AppointmentRepository adalah struct yang menyediakan metode untuk berinteraksi dengan tabel
@@ -38,29 +43,252 @@ func (r *AppointmentRepository) GetStaffList() ([]model.Staff, error) {
 	return StaffList, nil
 }
 
+var (
+	// ErrHostBusy dikembalikan jika host sudah memiliki appointment lain pada slot yang sama.
+	ErrHostBusy = errors.New("host already has an appointment at that time")
+	// ErrHostNotFound dikembalikan jika host yang dipilih tidak ada
+	ErrHostNotFound = errors.New("host not found")
+	// ErrHostNotStaff dikembalikan jika host yang dipilih bukan staff aktif
+	ErrHostNotStaff = errors.New("host must be an active staff member")
+)
+
 // CreateAppointment menyimpan data janji temu ke dalam tabel `appointments` dan mengembalikan
 // error jika terjadi masalah saat penyimpanan. Fungsi ini juga mengembalikan ID janji temu yang baru
-// yang disisipkan ke dalam tabel.
-func (r *AppointmentRepository) CreateAppointment(appointment *model.Appointment) error {
-	query := `INSERT INTO appointments (name, phone_number, email, date_of_booking, time, link_meet, host_id, status, pdf_file, img, created_at, updated_at) 
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9 NOW(), NOW()) RETURNING id`
-	err := r.DB.QueryRow(query, appointment.Name, appointment.PhoneNumber, appointment.Email, appointment.DateOfBooking,
-		appointment.Time, appointment.LinkMeet, appointment.HostID, appointment.Status, appointment.PDFFile, appointment.Img).Scan(&appointment.ID)
+// yang disisipkan ke dalam tabel. Jika host diisi, host diperiksa dan dikunci di transaksi yang sama.
+// Status awal dicatat pada timeline atas nama changedBy.
+func (r *AppointmentRepository) CreateAppointment(a *model.Appointment, changedBy string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if a.HostID > 0 {
+		if err := lockAvailableHost(tx, a.HostID, a.Time, a.EndTime, 0); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO appointments (name, phone_number, email, date_of_booking, time, end_time, link_meet, host_id, category_id,
+			  assignment_method, host_assigned_at, status, pdf_file, img, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, 0), NULLIF($9, 0), NULLIF($10, ''),
+			  CASE WHEN $8 > 0 THEN NOW() END, $11, $12, $13, NOW(), NOW()) RETURNING id`
+	err = tx.QueryRow(query, a.Name, a.PhoneNumber, a.Email, a.DateOfBooking,
+		a.Time, a.EndTime, a.LinkMeet, a.HostID, a.CategoryID,
+		a.AssignmentMethod, a.Status, a.PDFFile, a.Img).Scan(&a.ID)
+	if err != nil {
+		return mapHostConflict(err)
+	}
+	if err := insertStatusHistory(tx, a.ID, "", a.Status, changedBy, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockAvailableHost mengunci baris host dengan SELECT ... FOR UPDATE lalu memastikan host adalah staff aktif
+// yang tidak memiliki janji temu lain di rentang [start, end). Karena baris host tetap terkunci sampai
+// transaksi selesai, penetapan lain untuk host yang sama menunggu dan tidak bisa lolos pemeriksaan bersamaan.
+func lockAvailableHost(tx *sql.Tx, hostID int, start, end time.Time, excludeID int) error {
+	var role, status sql.NullString
+	err := tx.QueryRow("SELECT role, status FROM users WHERE id = $1 FOR UPDATE", hostID).Scan(&role, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHostNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role.String != "staff" || (status.Valid && status.String != "active") {
+		return ErrHostNotStaff
+	}
+
+	var busy bool
+	query := `SELECT EXISTS (SELECT 1 FROM appointments
+			  WHERE host_id = $1 AND status <> 'cancelled' AND id <> $4
+			  AND time < $3 AND COALESCE(end_time, time + $5 * interval '1 minute') > $2)`
+	if err := tx.QueryRow(query, hostID, start, end, excludeID, int(appointment.DefaultDuration/time.Minute)).Scan(&busy); err != nil {
+		return err
+	}
+	if busy {
+		return ErrHostBusy
+	}
+	return nil
+}
+
+// GetAppointmentByID mengambil janji temu berdasarkan ID. Jika janji temu tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows.
+func (r *AppointmentRepository) GetAppointmentByID(appointmentID int) (model.Appointment, error) {
+	query := `SELECT id, COALESCE(name, ''), COALESCE(phone_number, ''), COALESCE(email, ''), date_of_booking, time,
+			  COALESCE(end_time, time + $2 * interval '1 minute'), COALESCE(link_meet, ''), COALESCE(host_id, 0),
+			  COALESCE(category_id, 0), COALESCE(assignment_method, ''), status, COALESCE(pdf_file, ''), COALESCE(img, ''),
+			  created_at, updated_at
+			  FROM appointments WHERE id = $1`
+	var a model.Appointment
+	err := r.DB.QueryRow(query, appointmentID, int(appointment.DefaultDuration/time.Minute)).Scan(&a.ID, &a.Name,
+		&a.PhoneNumber, &a.Email, &a.DateOfBooking, &a.Time, &a.EndTime, &a.LinkMeet, &a.HostID, &a.CategoryID,
+		&a.AssignmentMethod, &a.Status, &a.PDFFile, &a.Img, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// GetUserRole mengambil peran pengguna berdasarkan ID. Jika pengguna tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows.
+func (r *AppointmentRepository) GetUserRole(userID int) (string, error) {
+	var role sql.NullString
+	err := r.DB.QueryRow("SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+	return role.String, err
+}
+
+// GetUserEmail mengambil email pengguna berdasarkan ID. Jika pengguna tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows.
+func (r *AppointmentRepository) GetUserEmail(userID int) (string, error) {
+	var email sql.NullString
+	err := r.DB.QueryRow("SELECT email FROM users WHERE id = $1", userID).Scan(&email)
+	return email.String, err
+}
+
+// UpdateMeetingLink memperbarui link meeting pada janji temu tertentu.
+func (r *AppointmentRepository) UpdateMeetingLink(appointmentID int, link string) error {
+	_, err := r.DB.Exec("UPDATE appointments SET link_meet = $1, updated_at = NOW() WHERE id = $2", link, appointmentID)
 	return err
 }
 
+// GetHostCandidates mengambil semua staff aktif beserta beban kerja dan status bentrok jadwalnya
+// untuk rentang waktu janji temu.
+func (r *AppointmentRepository) GetHostCandidates(start, end time.Time, day string, excludeID int) ([]appointment.Candidate, error) {
+	return appointment.LoadCandidates(r.DB, start, end, day, excludeID)
+}
+
 // UpdateAppointmentHost memperbarui ID host pada janji temu tertentu berdasarkan appointmentID.
-// Fungsi ini juga memperbarui waktu pembaruan (updated_at).
+// Host yang ditetapkan langsung oleh admin dicatat dengan metode "manual". Fungsi ini juga
+// memperbarui waktu pembaruan (updated_at). Janji temu dan host dikunci, lalu host diperiksa
+// dan disimpan dalam satu transaksi.
 func (r *AppointmentRepository) UpdateAppointmentHost(appointmentID, hostID int) error {
-	query := "UPDATE appointments SET host_id = $1, updated_at = NOW() WHERE id = $2"
-	_, err := r.DB.Exec(query, hostID, appointmentID)
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var start, end time.Time
+	err = tx.QueryRow(`SELECT time, COALESCE(end_time, time + $2 * interval '1 minute') FROM appointments WHERE id = $1 FOR UPDATE`,
+		appointmentID, int(appointment.DefaultDuration/time.Minute)).Scan(&start, &end)
+	if err != nil {
+		return err
+	}
+	if err := lockAvailableHost(tx, hostID, start, end, appointmentID); err != nil {
+		return err
+	}
+
+	query := `UPDATE appointments SET host_id = $1, assignment_method = $2, host_assigned_at = NOW(), updated_at = NOW()
+			  WHERE id = $3`
+	if _, err := tx.Exec(query, hostID, appointment.AssignmentManual, appointmentID); err != nil {
+		return mapHostConflict(err)
+	}
+	return tx.Commit()
+}
+
+// SetStaffSpecialties mengganti daftar kategori yang ditangani seorang staff.
+func (r *AppointmentRepository) SetStaffSpecialties(staffID int, categoryIDs []int) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM staff_specialties WHERE staff_id = $1", staffID); err != nil {
+		return err
+	}
+	for _, categoryID := range categoryIDs {
+		query := "INSERT INTO staff_specialties (staff_id, category_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+		if _, err := tx.Exec(query, staffID, categoryID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// mapHostConflict mengubah pelanggaran exclusion constraint excl_appointments_host_overlap menjadi ErrHostBusy.
+func mapHostConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23P01" {
+		return ErrHostBusy
+	}
 	return err
 }
 
-// UpdateAppointmentStatus memperbarui status janji temu berdasarkan appointmentID dan status yang
-// diberikan. Fungsi ini juga memperbarui waktu pembaruan (updated_at).
-func (r *AppointmentRepository) UpdateAppointmentStatus(appointmentID int, status string) error {
-	query := "UPDATE appointments SET status = $1, updated_at = NOW() WHERE id = $2"
-	_, err := r.DB.Exec(query, status, appointmentID)
+// UpdateAppointmentStatus memindahkan status janji temu sesuai aturan lifecycle dan mencatatnya
+// pada timeline appointment_status_history. Baris appointment dikunci selama transaksi agar dua
+// perubahan status yang bersamaan tidak saling menimpa.
+func (r *AppointmentRepository) UpdateAppointmentStatus(appointmentID int, status, changedBy, note string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	if err := tx.QueryRow("SELECT status FROM appointments WHERE id = $1 FOR UPDATE", appointmentID).Scan(&current); err != nil {
+		return err
+	}
+	if err := appointment.ValidateTransition(current, status); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE appointments SET status = $1, updated_at = NOW() WHERE id = $2", status, appointmentID); err != nil {
+		return err
+	}
+	if err := insertStatusHistory(tx, appointmentID, current, status, changedBy, note); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertStatusHistory mencatat satu perubahan status pada timeline; from kosong menandai status awal
+func insertStatusHistory(tx *sql.Tx, appointmentID int, from, to, changedBy, note string) error {
+	query := `INSERT INTO appointment_status_history (appointment_id, from_status, to_status, changed_by, note)
+			  VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''))`
+	_, err := tx.Exec(query, appointmentID, from, to, changedBy, note)
 	return err
 }
+
+// GetStatusHistory mengambil timeline perubahan status janji temu, diurutkan dari yang paling lama.
+func (r *AppointmentRepository) GetStatusHistory(appointmentID int) ([]model.AppointmentStatusChange, error) {
+	query := `SELECT id, appointment_id, COALESCE(from_status, ''), to_status, changed_by, COALESCE(note, ''), created_at
+			  FROM appointment_status_history WHERE appointment_id = $1 ORDER BY created_at, id`
+	rows, err := r.DB.Query(query, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []model.AppointmentStatusChange{}
+	for rows.Next() {
+		var change model.AppointmentStatusChange
+		if err := rows.Scan(&change.ID, &change.AppointmentID, &change.FromStatus, &change.ToStatus,
+			&change.ChangedBy, &change.Note, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+// GetWhatsAppMessages mengambil pesan WhatsApp masuk dari klien untuk janji temu, diurutkan dari yang paling lama.
+func (r *AppointmentRepository) GetWhatsAppMessages(appointmentID int) ([]model.WhatsAppMessage, error) {
+	query := `SELECT id, appointment_id, from_number, body, COALESCE(action, ''), result, COALESCE(reply, ''), created_at
+			  FROM whatsapp_messages WHERE appointment_id = $1 ORDER BY created_at, id`
+	rows, err := r.DB.Query(query, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []model.WhatsAppMessage{}
+	for rows.Next() {
+		var m model.WhatsAppMessage
+		if err := rows.Scan(&m.ID, &m.AppointmentID, &m.From, &m.Body, &m.Action, &m.Result, &m.Reply, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"go-project/internal/admin/model"
@@ -16,6 +15,9 @@ type ArticleRepository interface {
 	UpdateArticle(article *model.Article) error    // Memperbarui data artikel yang sudah ada
 	DeleteArticle(id int) error                    // Menghapus artikel berdasarkan ID
 	GetAllArticles() ([]model.Article, error)      // Mengambil semua artikel
+
+	// ReviewArticle mengubah status artikel dan mencatat feedback reviewer
+	ReviewArticle(review *model.ContentReview, reviewerEmail string) error
 }
 
 // articleRepository adalah implementasi dari ArticleRepository, menyimpan koneksi ke database.
@@ -30,7 +32,7 @@ func NewArticleRepository(db *sql.DB) ArticleRepository {
 
 // validStatus memeriksa apakah status artikel yang diberikan valid (di antara status yang sudah ditentukan).
 func validStatus(status string) bool {
-	validStatuses := []string{"approval", "pending approval", "rejected"}
+	validStatuses := []string{"approval", "pending approval", "changes requested", "rejected", "withdrawn"}
 	for _, s := range validStatuses {
 		if status == s {
 			return true
@@ -88,39 +90,14 @@ func (r *articleRepository) CreateArticle(article *model.Article) error {
 
 // GetArticleByID mengambil artikel berdasarkan ID dari database. Jika artikel tidak ditemukan, mengembalikan error.
 func (r *articleRepository) GetArticleByID(id int) (*model.Article, error) {
-	query := `SELECT * FROM articles WHERE id = $1`
-	row := r.db.QueryRow(query, id)
-	var article model.Article
-
-	// Menangani tags sebagai JSON
-	var tags json.RawMessage
-	err := row.Scan(
-		&article.ID, &article.CategoryID, &article.Title, &article.Slug,
-		&tags, // Menyimpan dalam json.RawMessage
-		&article.Content, &article.Message, &article.Thumbnail,
-		&article.AltThumbnail, &article.Banner, &article.AltBanner,
-		&article.Poster, &article.AltPoster, &article.LinkVideo,
-		&article.Status, &article.MetaTitle, &article.MetaDescription,
-		&article.AuthorID, &article.CreatedAt, &article.UpdatedAt,
-	)
+	query := `SELECT ` + model.ArticleColumns + ` FROM articles WHERE id = $1`
+	article, err := model.ScanArticle(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("article with id %d not found", id)
 		}
 		return nil, fmt.Errorf("database error: %v", err)
 	}
-
-	// Jika tags valid dalam format JSON, lakukan unmarshal ke dalam slice string
-	if len(tags) > 0 {
-		var tagList []string
-		if err := json.Unmarshal(tags, &tagList); err != nil {
-			return nil, fmt.Errorf("error unmarshaling tags JSON: %v", err)
-		}
-		article.Tags = tagList
-	} else {
-		article.Tags = []string{}
-	}
-
 	return &article, nil
 }
 
@@ -159,7 +136,7 @@ func (r *articleRepository) DeleteArticle(id int) error {
 
 // GetAllArticles mengambil semua artikel dari database dan mengembalikannya dalam bentuk slice dari model.Article.
 func (r *articleRepository) GetAllArticles() ([]model.Article, error) {
-	query := `SELECT * FROM articles`
+	query := `SELECT ` + model.ArticleColumns + ` FROM articles ORDER BY created_at DESC, id DESC`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		log.Printf("Error retrieving articles: %v", err)
@@ -169,23 +146,12 @@ func (r *articleRepository) GetAllArticles() ([]model.Article, error) {
 
 	var articles []model.Article
 	for rows.Next() {
-		var article model.Article
-		var tagsData []byte // Scan data byte mentah terlebih dahulu
-		if err := rows.Scan(
-			&article.ID, &article.CategoryID, &article.Title, &article.Slug, &tagsData, &article.Content,
-			&article.Message, &article.Thumbnail, &article.AltThumbnail, &article.Banner, &article.AltBanner,
-			&article.Poster, &article.AltPoster, &article.LinkVideo, &article.Status, &article.MetaTitle,
-			&article.MetaDescription, &article.AuthorID, &article.CreatedAt, &article.UpdatedAt,
-		); err != nil {
+		article, err := model.ScanArticle(rows)
+		if err != nil {
 			log.Printf("Error scanning article: %v", err)
 			continue
 		}
 
-		// Jika tagsData berisi array yang diserialisasi, kita bisa melakukan unmarshal ke dalam []string
-		if err := json.Unmarshal(tagsData, &article.Tags); err != nil {
-			log.Printf("Error unmarshaling tags data: %v", err)
-		}
-
 		articles = append(articles, article)
 	}
 
@@ -196,3 +162,26 @@ func (r *articleRepository) GetAllArticles() ([]model.Article, error) {
 
 	return articles, nil
 }
+
+// ReviewArticle memperbarui status artikel dan mencatat feedback reviewer dalam satu transaksi.
+// Reviewer dicari berdasarkan email dari token; jika tidak ditemukan reviewer_id dibiarkan NULL.
+func (r *articleRepository) ReviewArticle(review *model.ContentReview, reviewerEmail string) error {
+	if !validStatus(review.Status) {
+		return errors.New("invalid status")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateReviewStatus(tx, "articles", review); err != nil {
+		return err
+	}
+
+	if err := insertContentReview(tx, review, reviewerEmail); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
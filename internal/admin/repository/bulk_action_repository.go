@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-project/internal/admin/model"
+)
+
+// MaxBulkItems membatasi jumlah item yang dipilih lewat IDs atau filter dalam satu permintaan
+const MaxBulkItems = 500
+
+// bulkStatuses memetakan aksi moderasi ke status baru
+var bulkStatuses = map[string]string{"approve": "approved", "reject": "rejected"}
+
+// applyBulkAction menjalankan aksi moderasi untuk setiap ID di dalam satu transaksi. Setiap item
+// dibungkus savepoint sehingga kegagalan satu item tidak membatalkan item lainnya, dan setiap aksi
+// yang berhasil dicatat ke tabel `moderation_actions`.
+func applyBulkAction(tx *sql.Tx, table, entityType string, ids []int, action, actorEmail string) ([]model.BulkItemResult, error) {
+	results := make([]model.BulkItemResult, 0, len(ids))
+	for _, id := range ids {
+		if _, err := tx.Exec("SAVEPOINT bulk_item"); err != nil {
+			return nil, err
+		}
+
+		itemErr := applyBulkItem(tx, table, entityType, id, action, actorEmail)
+		if itemErr != nil {
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT bulk_item"); err != nil {
+				return nil, err
+			}
+			results = append(results, model.BulkItemResult{ID: id, Error: itemErr.Error()})
+			continue
+		}
+
+		if _, err := tx.Exec("RELEASE SAVEPOINT bulk_item"); err != nil {
+			return nil, err
+		}
+		results = append(results, model.BulkItemResult{ID: id, Success: true})
+	}
+	return results, nil
+}
+
+func applyBulkItem(tx *sql.Tx, table, entityType string, id int, action, actorEmail string) error {
+	var result sql.Result
+	var err error
+	if action == "delete" {
+		result, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1", table), id)
+	} else {
+		result, err = tx.Exec(fmt.Sprintf("UPDATE %s SET status = $1, updated_at = NOW() WHERE id = $2", table), bulkStatuses[action], id)
+	}
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return fmt.Errorf("%s with id %d not found", entityType, id)
+	}
+
+	query := `INSERT INTO moderation_actions (entity_type, entity_id, action, actor_id, created_at)
+			  VALUES ($1, $2, $3, (SELECT id FROM users WHERE email = $4), NOW())`
+	_, err = tx.Exec(query, entityType, id, action, actorEmail)
+	return err
+}
+
+// selectIDs menjalankan query pemilihan ID di dalam transaksi
+func selectIDs(tx *sql.Tx, query string, args ...interface{}) ([]int, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
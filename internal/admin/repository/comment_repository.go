@@ -2,6 +2,8 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"go-project/internal/admin/model"
 )
 
@@ -18,6 +20,18 @@ type CommentRepository interface {
 
 	// CreateComment membuat komentar baru dan mengembalikannya.
 	CreateComment(comment *model.Comment) (*model.Comment, error)
+
+	// GetCommentArticleID mengambil ID artikel dari sebuah komentar.
+	GetCommentArticleID(commentID int) (int, error)
+
+	// GetReportedComments mengambil komentar yang memiliki laporan terbuka dari pembaca.
+	GetReportedComments() ([]model.ReportedComment, error)
+
+	// ResolveReports menutup laporan sebuah komentar sebagai "upheld" atau "dismissed".
+	ResolveReports(commentID int, reportStatus, actorEmail string) error
+
+	// BulkModerate menjalankan aksi moderasi untuk banyak komentar dalam satu transaksi.
+	BulkModerate(ids []int, filter *model.BulkFilter, action, actorEmail string) ([]model.BulkItemResult, error)
 }
 
 // commentRepository adalah implementasi konkret dari CommentRepository.
@@ -33,7 +47,8 @@ func NewCommentRepository(db *sql.DB) CommentRepository {
 // GetAllComments mengambil semua komentar dari database dan mengembalikannya dalam bentuk slice.
 func (r *commentRepository) GetAllComments() ([]model.Comment, error) {
 	// Query untuk mengambil semua komentar
-	query := `SELECT id, article_id, username, email, comment, parent_id, status, created_at, updated_at FROM comments`
+	query := `SELECT id, article_id, username, email, comment, parent_id, status, created_at, updated_at,
+			  COALESCE(ip_address, ''), COALESCE(moderation_score, 0), COALESCE(moderation_reason, '') FROM comments`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, err // Mengembalikan error jika terjadi kesalahan saat query
@@ -46,7 +61,8 @@ func (r *commentRepository) GetAllComments() ([]model.Comment, error) {
 		var comment model.Comment
 		var parentID sql.NullInt32 // Menggunakan sql.NullInt32 untuk menangani nilai null
 		if err := rows.Scan(&comment.ID, &comment.ArticleID, &comment.Username, &comment.Email,
-			&comment.Comment, &parentID, &comment.Status, &comment.CreatedAt, &comment.UpdatedAt); err != nil {
+			&comment.Comment, &parentID, &comment.Status, &comment.CreatedAt, &comment.UpdatedAt,
+			&comment.IPAddress, &comment.ModerationScore, &comment.ModerationReason); err != nil {
 			return nil, err // Mengembalikan error jika terjadi kesalahan saat pemindaian data
 		}
 		// Jika parentID valid, set nilai parentID di comment
@@ -76,10 +92,11 @@ func (r *commentRepository) DeleteComment(commentID int) error {
 
 // CreateComment menambahkan komentar baru ke database dan mengembalikan objek komentar yang baru dibuat.
 func (r *commentRepository) CreateComment(comment *model.Comment) (*model.Comment, error) {
-	query := `INSERT INTO comments (article_id, username, email, comment, parent_id, status, created_at, updated_at) 
-			  VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW()) RETURNING id, created_at, updated_at`
+	query := `INSERT INTO comments (article_id, username, email, comment, parent_id, status, ip_address, moderation_score, moderation_reason, created_at, updated_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()) RETURNING id, created_at, updated_at`
 	// Menjalankan query dan mengembalikan ID, created_at, dan updated_at dari komentar yang baru dibuat
-	err := r.db.QueryRow(query, comment.ArticleID, comment.Username, comment.Email, comment.Comment, comment.ParentID, comment.Status).
+	err := r.db.QueryRow(query, comment.ArticleID, comment.Username, comment.Email, comment.Comment, comment.ParentID, comment.Status,
+		comment.IPAddress, comment.ModerationScore, comment.ModerationReason).
 		Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
 	if err != nil {
 		return nil, err // Mengembalikan error jika terjadi kesalahan saat menambah data
@@ -87,3 +104,121 @@ func (r *commentRepository) CreateComment(comment *model.Comment) (*model.Commen
 
 	return comment, nil // Mengembalikan komentar yang baru dibuat
 }
+
+// GetCommentArticleID mengambil ID artikel dari sebuah komentar, dipakai untuk memeriksa komentar induk balasan
+func (r *commentRepository) GetCommentArticleID(commentID int) (int, error) {
+	var articleID int
+	err := r.db.QueryRow(`SELECT article_id FROM comments WHERE id = $1`, commentID).Scan(&articleID)
+	return articleID, err
+}
+
+// BulkModerate menyetujui, menolak, atau menghapus banyak komentar dalam satu transaksi.
+// Jika ids kosong, komentar dipilih berdasarkan filter (status, article_id, email).
+func (r *commentRepository) BulkModerate(ids []int, filter *model.BulkFilter, action, actorEmail string) ([]model.BulkItemResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if len(ids) == 0 && filter != nil {
+		query := `SELECT id FROM comments
+				  WHERE ($1 = '' OR status = $1) AND ($2 = 0 OR article_id = $2) AND ($3 = '' OR email = $3)
+				  ORDER BY id LIMIT $4 FOR UPDATE`
+		ids, err = selectIDs(tx, query, filter.Status, filter.ArticleID, filter.Email, MaxBulkItems)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := applyBulkAction(tx, "comments", "comment", ids, action, actorEmail)
+	if err != nil {
+		return nil, err
+	}
+	return results, tx.Commit()
+}
+
+// GetReportedComments mengambil komentar dengan laporan terbuka, diurutkan dari yang paling banyak dilaporkan.
+func (r *commentRepository) GetReportedComments() ([]model.ReportedComment, error) {
+	query := `SELECT c.id, c.article_id, c.username, c.email, c.comment, c.parent_id, c.status, c.created_at, c.updated_at,
+			  COUNT(cr.id), JSON_AGG(cr.reason ORDER BY cr.created_at), MAX(cr.created_at)
+			  FROM comments c
+			  JOIN comment_reports cr ON cr.comment_id = c.id AND cr.status = 'open'
+			  GROUP BY c.id
+			  ORDER BY COUNT(cr.id) DESC, MAX(cr.created_at) DESC`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err // Mengembalikan error jika terjadi kesalahan saat query
+	}
+	defer rows.Close()
+
+	var reported []model.ReportedComment
+	for rows.Next() {
+		var item model.ReportedComment
+		var parentID sql.NullInt32
+		var reasons []byte // Alasan laporan dalam format JSON array
+		if err := rows.Scan(&item.ID, &item.ArticleID, &item.Username, &item.Email, &item.Comment.Comment, &parentID,
+			&item.Status, &item.CreatedAt, &item.UpdatedAt, &item.OpenReports, &reasons, &item.LastReportedAt); err != nil {
+			return nil, err // Mengembalikan error jika terjadi kesalahan saat pemindaian data
+		}
+		if parentID.Valid {
+			id := int(parentID.Int32)
+			item.ParentID = &id
+		}
+		if err := json.Unmarshal(reasons, &item.Reasons); err != nil {
+			return nil, err
+		}
+		reported = append(reported, item)
+	}
+
+	return reported, rows.Err()
+}
+
+// ResolveReports menutup semua laporan terbuka sebuah komentar. Laporan "upheld" menolak komentar, sedangkan
+// laporan "dismissed" hanya menampilkan kembali komentar yang disembunyikan otomatis karena laporan; status
+// lain dibiarkan. Aksi moderator dicatat hanya jika status komentar berubah.
+func (r *commentRepository) ResolveReports(commentID int, reportStatus, actorEmail string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM comments WHERE id = $1 FOR UPDATE`, commentID).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("comment not found")
+		}
+		return err
+	}
+
+	// Komentar "hidden" selalu berasal dari komentar "approved" yang disembunyikan otomatis karena laporan
+	newStatus, action := status, ""
+	switch {
+	case reportStatus == "upheld" && status != "rejected":
+		newStatus, action = "rejected", "reject"
+	case reportStatus == "dismissed" && status == "hidden":
+		newStatus, action = "approved", "approve"
+	}
+
+	if newStatus != status {
+		if _, err := tx.Exec(`UPDATE comments SET status = $1, updated_at = NOW() WHERE id = $2`, newStatus, commentID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE comment_reports SET status = $1, resolved_by = (SELECT id FROM users WHERE email = $2), resolved_at = NOW()
+			  WHERE comment_id = $3 AND status = 'open'`
+	if _, err := tx.Exec(query, reportStatus, actorEmail, commentID); err != nil {
+		return err
+	}
+
+	if action != "" {
+		query = `INSERT INTO moderation_actions (entity_type, entity_id, action, actor_id, created_at)
+				 VALUES ('comment', $1, $2, (SELECT id FROM users WHERE email = $3), NOW())`
+		if _, err := tx.Exec(query, commentID, action, actorEmail); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-project/internal/admin/model"
+)
+
+// ErrNotReviewable dikembalikan jika konten tidak sedang menunggu review, misalnya sudah ditarik atau disetujui
+var ErrNotReviewable = errors.New("only content pending approval can be reviewed")
+
+// updateReviewStatus mengubah status konten yang masih menunggu review di tabel `table` (articles atau videos).
+// Konten yang sudah ditarik, disetujui atau ditolak tidak bisa direview ulang.
+func updateReviewStatus(tx *sql.Tx, table string, review *model.ContentReview) error {
+	result, err := tx.Exec(`UPDATE `+table+` SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status = 'pending approval'`, review.Status, review.ContentID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		return nil
+	}
+
+	var status string
+	err = tx.QueryRow(`SELECT COALESCE(status, '') FROM `+table+` WHERE id = $1`, review.ContentID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s with id %d not found", review.ContentType, review.ContentID)
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: current status is %q", ErrNotReviewable, status)
+}
+
+// insertContentReview menyimpan catatan review ke tabel `content_reviews` di dalam transaksi yang sedang berjalan.
+func insertContentReview(tx *sql.Tx, review *model.ContentReview, reviewerEmail string) error {
+	query := `INSERT INTO content_reviews (content_type, content_id, reviewer_id, status, feedback, created_at)
+			  VALUES ($1, $2, (SELECT id FROM users WHERE email = $3), $4, $5, NOW()) RETURNING id, created_at`
+	return tx.QueryRow(query, review.ContentType, review.ContentID, reviewerEmail, review.Status, review.Feedback).
+		Scan(&review.ID, &review.CreatedAt)
+}
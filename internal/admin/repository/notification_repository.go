@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/admin/model"
+)
+
+// ErrNotRetryable dikembalikan jika notifikasi yang diminta dikirim ulang tidak berstatus dead
+var ErrNotRetryable = errors.New("only dead notifications can be retried")
+
+// NotificationRepository adalah interface untuk memeriksa dan mengirim ulang notifikasi dari outbox.
+type NotificationRepository interface {
+	// GetOutbox mengambil event di outbox berdasarkan status.
+	GetOutbox(status string) ([]model.NotificationOutbox, error)
+
+	// RetryOutbox menjadwalkan ulang event yang gagal dipecah menjadi pengiriman.
+	RetryOutbox(id int) error
+
+	// GetDeliveries mengambil pengiriman notifikasi berdasarkan status.
+	GetDeliveries(status string) ([]model.NotificationDelivery, error)
+
+	// RetryDelivery menjadwalkan ulang satu pengiriman yang masuk dead-letter.
+	RetryDelivery(id int) error
+
+	// RetryDeadDeliveries menjadwalkan ulang semua pengiriman yang masuk dead-letter.
+	RetryDeadDeliveries() (int64, error)
+}
+
+// notificationRepository adalah implementasi konkret dari NotificationRepository.
+type notificationRepository struct {
+	db *sql.DB // Koneksi ke database
+}
+
+// NewNotificationRepository adalah konstruktor untuk membuat instance baru dari notificationRepository.
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// GetOutbox mengambil event di outbox berdasarkan status, yang terbaru lebih dulu.
+func (r *notificationRepository) GetOutbox(status string) ([]model.NotificationOutbox, error) {
+	rows, err := r.db.Query(`SELECT id, event, payload, status, attempts, last_error, next_attempt_at, dispatched_at, created_at
+		FROM notification_outbox WHERE status = $1 ORDER BY id DESC`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outbox := []model.NotificationOutbox{}
+	for rows.Next() {
+		var o model.NotificationOutbox
+		if err := rows.Scan(&o.ID, &o.Event, &o.Payload, &o.Status, &o.Attempts, &o.LastError,
+			&o.NextAttemptAt, &o.DispatchedAt, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		outbox = append(outbox, o)
+	}
+	return outbox, rows.Err()
+}
+
+// RetryOutbox mengembalikan event berstatus dead ke antrean dengan hitungan percobaan dari nol.
+func (r *notificationRepository) RetryOutbox(id int) error {
+	return r.retry("notification_outbox", id)
+}
+
+// GetDeliveries mengambil pengiriman notifikasi berdasarkan status beserta nama penerimanya.
+func (r *notificationRepository) GetDeliveries(status string) ([]model.NotificationDelivery, error) {
+	rows, err := r.db.Query(`SELECT d.id, d.outbox_id, o.event, d.channel, d.user_id, COALESCE(u.name, ''), d.status,
+			d.attempts, d.last_error, d.next_attempt_at, d.sent_at, d.created_at, d.updated_at
+		FROM notification_deliveries d
+		JOIN notification_outbox o ON o.id = d.outbox_id
+		JOIN users u ON u.id = d.user_id
+		WHERE d.status = $1 ORDER BY d.updated_at DESC, d.id DESC`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []model.NotificationDelivery{}
+	for rows.Next() {
+		var d model.NotificationDelivery
+		if err := rows.Scan(&d.ID, &d.OutboxID, &d.Event, &d.Channel, &d.UserID, &d.UserName, &d.Status,
+			&d.Attempts, &d.LastError, &d.NextAttemptAt, &d.SentAt, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// RetryDelivery mengembalikan pengiriman berstatus dead ke antrean dengan hitungan percobaan dari nol.
+func (r *notificationRepository) RetryDelivery(id int) error {
+	return r.retry("notification_deliveries", id)
+}
+
+// RetryDeadDeliveries mengembalikan semua pengiriman berstatus dead ke antrean dan
+// mengembalikan jumlah pengiriman yang dijadwalkan ulang.
+func (r *notificationRepository) RetryDeadDeliveries() (int64, error) {
+	result, err := r.db.Exec(`UPDATE notification_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE status = 'dead'`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// retry menjadwalkan ulang satu baris dead. Jika baris tidak ditemukan, fungsi ini mengembalikan
+// sql.ErrNoRows; jika statusnya bukan dead, ErrNotRetryable.
+func (r *notificationRepository) retry(table string, id int) error {
+	result, err := r.db.Exec(`UPDATE `+table+`
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'dead'`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+	return ErrNotRetryable
+}
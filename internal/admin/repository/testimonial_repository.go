@@ -25,6 +25,9 @@ type TestimonialRepository interface {
 
 	// UpdateStatus memperbarui status testimonial berdasarkan ID.
 	UpdateStatus(id int, status string) error
+
+	// BulkModerate menjalankan aksi moderasi untuk banyak testimonial dalam satu transaksi.
+	BulkModerate(ids []int, filter *model.BulkFilter, action, actorEmail string) ([]model.BulkItemResult, error)
 }
 
 // testimonialRepository adalah implementasi konkret dari TestimonialRepository.
@@ -98,3 +101,29 @@ func (r *testimonialRepository) UpdateStatus(id int, status string) error {
 	_, err := r.db.Exec(query, status, id)
 	return err // Mengembalikan error jika terjadi kesalahan saat eksekusi query
 }
+
+// BulkModerate menyetujui, menolak, atau menghapus banyak testimonial dalam satu transaksi.
+// Jika ids kosong, testimonial dipilih berdasarkan filter (status, category_id).
+func (r *testimonialRepository) BulkModerate(ids []int, filter *model.BulkFilter, action, actorEmail string) ([]model.BulkItemResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if len(ids) == 0 && filter != nil {
+		query := `SELECT id FROM testimonials
+				  WHERE ($1 = '' OR status = $1) AND ($2 = 0 OR category_id = $2)
+				  ORDER BY id LIMIT $3 FOR UPDATE`
+		ids, err = selectIDs(tx, query, filter.Status, filter.CategoryID, MaxBulkItems)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := applyBulkAction(tx, "testimonials", "testimonial", ids, action, actorEmail)
+	if err != nil {
+		return nil, err
+	}
+	return results, tx.Commit()
+}
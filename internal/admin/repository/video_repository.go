@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"go-project/internal/admin/model"
 	"time"
 )
 
@@ -33,7 +34,7 @@ func NewVideoRepository(db *sql.DB) *VideoRepository {
 
 // validVideoStatus memeriksa apakah status yang diberikan valid untuk video.
 func validVideoStatus(status string) bool {
-	validStatuses := []string{"approval", "pending approval", "rejected"}
+	validStatuses := []string{"approval", "pending approval", "changes requested", "rejected", "withdrawn"}
 	for _, s := range validStatuses {
 		if status == s {
 			return true
@@ -122,3 +123,25 @@ func (repo *VideoRepository) Delete(id int) error {
 	_, err := repo.DB.Exec(query, id) // Eksekusi query untuk menghapus video berdasarkan ID
 	return err
 }
+
+// ReviewVideo memperbarui status video dan mencatat feedback reviewer dalam satu transaksi.
+func (repo *VideoRepository) ReviewVideo(review *model.ContentReview, reviewerEmail string) error {
+	if !validVideoStatus(review.Status) {
+		return errors.New("invalid status") // Mengembalikan error jika status tidak valid
+	}
+
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateReviewStatus(tx, "videos", review); err != nil {
+		return err
+	}
+
+	if err := insertContentReview(tx, review, reviewerEmail); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"go-project/internal/admin/model"
+	"go-project/pkg/webhook"
+)
+
+// WebhookRepository adalah interface untuk langganan webhook dan log pengirimannya.
+type WebhookRepository interface {
+	// CreateSubscription menyimpan langganan baru.
+	CreateSubscription(sub *model.WebhookSubscription) error
+
+	// GetSubscriptions mengambil semua langganan tanpa secret.
+	GetSubscriptions() ([]model.WebhookSubscription, error)
+
+	// GetSubscriptionByID mengambil satu langganan termasuk secret-nya untuk pengiriman.
+	GetSubscriptionByID(id int) (*model.WebhookSubscription, error)
+
+	// UpdateSubscription memperbarui URL, event, deskripsi dan status aktif. Secret diganti jika diisi.
+	UpdateSubscription(sub *model.WebhookSubscription) error
+
+	// DeleteSubscription menghapus langganan beserta log pengirimannya.
+	DeleteSubscription(id int) error
+
+	// GetDeliveries mengambil log pengiriman sebuah langganan, opsional difilter status.
+	GetDeliveries(subscriptionID int, status string, limit int) ([]model.WebhookDelivery, error)
+
+	// CreateTestDelivery mencatat pengiriman event uji untuk sebuah langganan.
+	CreateTestDelivery(ctx context.Context, subscriptionID int, payload []byte) (int, error)
+
+	// RecordTestResult menyimpan hasil pengiriman event uji.
+	RecordTestResult(ctx context.Context, id int, result webhook.Result, sendErr error) error
+
+	// GetDeliveryByID mengambil satu pengiriman.
+	GetDeliveryByID(id int) (*model.WebhookDelivery, error)
+
+	// RetryDelivery menjadwalkan ulang pengiriman yang masuk dead-letter.
+	RetryDelivery(id int) error
+}
+
+// webhookRepository adalah implementasi konkret dari WebhookRepository.
+type webhookRepository struct {
+	db *sql.DB // Koneksi ke database
+}
+
+// NewWebhookRepository adalah konstruktor untuk membuat instance baru dari webhookRepository.
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// subscriptionColumns adalah kolom yang dibaca scanSubscription. Array event dibaca sebagai JSON.
+const subscriptionColumns = `id, url, secret, COALESCE(array_to_json(events), '[]'), description, active, created_at, updated_at`
+
+func scanSubscription(row interface{ Scan(dest ...any) error }) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	var events []byte
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &events, &sub.Description, &sub.Active, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(events, &sub.Events); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// CreateSubscription menyimpan langganan baru. Slice event dikirim langsung sebagai array Postgres.
+func (r *webhookRepository) CreateSubscription(sub *model.WebhookSubscription) error {
+	return r.db.QueryRow(`INSERT INTO webhook_subscriptions (url, secret, events, description, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW()) RETURNING id, created_at, updated_at`,
+		sub.URL, sub.Secret, sub.Events, sub.Description, sub.Active).Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+}
+
+// GetSubscriptions mengambil semua langganan. Secret dikosongkan agar tidak ikut tampil di daftar.
+func (r *webhookRepository) GetSubscriptions() ([]model.WebhookSubscription, error) {
+	rows, err := r.db.Query(`SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []model.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		sub.Secret = ""
+		subs = append(subs, *sub)
+	}
+	return subs, rows.Err()
+}
+
+// GetSubscriptionByID mengambil satu langganan. Jika tidak ditemukan, fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webhookRepository) GetSubscriptionByID(id int) (*model.WebhookSubscription, error) {
+	return scanSubscription(r.db.QueryRow(`SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, id))
+}
+
+// UpdateSubscription memperbarui langganan. Jika tidak ditemukan, fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webhookRepository) UpdateSubscription(sub *model.WebhookSubscription) error {
+	return r.db.QueryRow(`UPDATE webhook_subscriptions
+		SET url = $1, events = $2, description = $3, active = $4, secret = COALESCE(NULLIF($5, ''), secret), updated_at = NOW()
+		WHERE id = $6 RETURNING created_at, updated_at`,
+		sub.URL, sub.Events, sub.Description, sub.Active, sub.Secret, sub.ID).Scan(&sub.CreatedAt, &sub.UpdatedAt)
+}
+
+// DeleteSubscription menghapus langganan. Jika tidak ditemukan, fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webhookRepository) DeleteSubscription(id int) error {
+	result, err := r.db.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+const deliveryColumns = `id, subscription_id, event, payload, status, attempts, last_error, response_status, duration_ms,
+	next_attempt_at, sent_at, created_at, updated_at`
+
+func scanDelivery(row interface{ Scan(dest ...any) error }) (*model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.LastError,
+		&d.ResponseStatus, &d.DurationMS, &d.NextAttemptAt, &d.SentAt, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetDeliveries mengambil log pengiriman terbaru sebuah langganan
+func (r *webhookRepository) GetDeliveries(subscriptionID int, status string, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.Query(`SELECT `+deliveryColumns+` FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC LIMIT $3`, subscriptionID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []model.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+// CreateTestDelivery mencatat pengiriman event uji sebagai percobaan pertama. Worker tidak mengambilnya
+// karena langsung dikirim oleh service; next_attempt_at diisi jauh ke depan sampai hasilnya disimpan.
+func (r *webhookRepository) CreateTestDelivery(ctx context.Context, subscriptionID int, payload []byte) (int, error) {
+	var id int
+	err := r.db.QueryRowContext(ctx, `INSERT INTO webhook_deliveries (subscription_id, event, payload, attempts, next_attempt_at)
+		VALUES ($1, 'webhook.test', $2, 1, 'infinity') RETURNING id`, subscriptionID, payload).Scan(&id)
+	return id, err
+}
+
+// RecordTestResult menyimpan hasil pengiriman event uji. Event uji tidak dikirim ulang,
+// jadi kegagalan langsung berstatus dead.
+func (r *webhookRepository) RecordTestResult(ctx context.Context, id int, result webhook.Result, sendErr error) error {
+	return webhook.Record(ctx, r.db, id, 1, webhook.Config{MaxAttempts: 1}, result, sendErr, false)
+}
+
+// GetDeliveryByID mengambil satu pengiriman. Jika tidak ditemukan, fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webhookRepository) GetDeliveryByID(id int) (*model.WebhookDelivery, error) {
+	return scanDelivery(r.db.QueryRow(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+}
+
+// RetryDelivery mengembalikan pengiriman berstatus dead ke antrean. Jika pengiriman tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows; jika statusnya bukan dead, ErrNotRetryable.
+func (r *webhookRepository) RetryDelivery(id int) error {
+	result, err := r.db.Exec(`UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'dead' AND event <> 'webhook.test'`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+	if _, err := r.GetDeliveryByID(id); err != nil {
+		return err
+	}
+	return ErrNotRetryable
+}
@@ -4,10 +4,33 @@ import (
 	"database/sql"
 	"errors"
 	"go-project/internal/admin/model"
+	"go-project/pkg/webinar"
+	"time"
+)
+
+var (
+	// ErrWebinarHasRegistrations dikembalikan jika webinar yang akan dihapus masih memiliki pendaftar aktif
+	ErrWebinarHasRegistrations = errors.New("webinar still has registrants, cancel it instead")
+	// ErrWebinarNotEnded dikembalikan jika rekaman atau sertifikat diproses untuk webinar yang belum selesai
+	ErrWebinarNotEnded = errors.New("webinar has not ended yet")
+	// ErrVideoNotFound dikembalikan jika video rekaman tidak ada
+	ErrVideoNotFound = errors.New("recording video not found")
 )
 
 type WebinarRepository interface {
 	CreateWebinar(webinar *model.Webinar) error
+	GetAllWebinars(status string) ([]model.Webinar, error)
+	GetWebinarByID(id int) (*model.Webinar, error)
+	UpdateWebinar(webinar *model.Webinar) error
+	DeleteWebinar(id int) error
+	UpdateStatus(id int, status string) error
+	CancelWebinar(id int, reason string) error
+	UpdateHost(id, hostID int) error
+	SetRecording(id, videoID int) error
+	GetUserRole(userID int) (string, error)
+	GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error)
+	GetAttendance(webinarID int) ([]webinar.Attendance, error)
+	IssueCertificates(webinarID int) (int, error)
 }
 
 type webinarRepository struct {
@@ -18,12 +41,243 @@ func NewWebinarRepository(db *sql.DB) WebinarRepository {
 	return &webinarRepository{db: db}
 }
 
+// webinarColumns adalah kolom yang dibaca scanWebinar
+const webinarColumns = `w.id, COALESCE(w.title, ''), COALESCE(w.description, ''), COALESCE(w.link_meet, ''), COALESCE(w.host_id, 0),
+			w.start_time, w.end_time, COALESCE(w.capacity, 0), w.registration_deadline, w.status, COALESCE(w.cancellation_reason, ''),
+			COALESCE(w.recording_video_id, 0), w.certificate_threshold,
+			(SELECT COUNT(*) FROM webinar_registrations r WHERE r.webinar_id = w.id AND r.status = 'registered'),
+			(SELECT COUNT(*) FROM webinar_registrations r WHERE r.webinar_id = w.id AND r.status = 'waitlisted'),
+			w.created_at::text, w.updated_at::text`
+
+func scanWebinar(row interface{ Scan(dest ...any) error }) (*model.Webinar, error) {
+	var w model.Webinar
+	var start, end, deadline sql.NullTime
+	err := row.Scan(&w.ID, &w.Title, &w.Description, &w.LinkMeet, &w.HostID, &start, &end, &w.Capacity, &deadline,
+		&w.Status, &w.CancellationReason, &w.RecordingVideoID, &w.CertificateThreshold, &w.RegisteredCount, &w.WaitlistCount, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	w.StartTime, w.EndTime, w.RegistrationDeadline = start.Time, end.Time, deadline.Time
+	return &w, nil
+}
+
 func (r *webinarRepository) CreateWebinar(webinar *model.Webinar) error {
-	query := `INSERT INTO webinars (title, description, link_meet, host_id, created_at, updated_at) 
-			VALUES ($1, $2, $3, $4, NOW(), NOW()) RETURNING id`
-	err := r.db.QueryRow(query, webinar.Title, webinar.Description, webinar.LinkMeet, webinar.HostID).Scan(&webinar.ID)
+	query := `INSERT INTO webinars (title, description, link_meet, host_id, start_time, end_time, capacity, registration_deadline, status,
+			certificate_threshold, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8, $9, $10, NOW(), NOW()) RETURNING id`
+	err := r.db.QueryRow(query, webinar.Title, webinar.Description, webinar.LinkMeet, webinar.HostID,
+		nullTime(webinar.StartTime), nullTime(webinar.EndTime), webinar.Capacity, nullTime(webinar.RegistrationDeadline),
+		webinar.Status, webinar.CertificateThreshold).Scan(&webinar.ID)
 	if err != nil {
 		return errors.New("failed to create webinar: " + err.Error())
 	}
 	return nil
 }
+
+// GetAllWebinars mengambil semua webinar, opsional difilter berdasarkan status
+func (r *webinarRepository) GetAllWebinars(status string) ([]model.Webinar, error) {
+	query := `SELECT ` + webinarColumns + ` FROM webinars w
+			WHERE ($1 = '' OR w.status = $1)
+			ORDER BY w.start_time DESC NULLS FIRST, w.id DESC`
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, errors.New("failed to fetch webinars: " + err.Error())
+	}
+	defer rows.Close()
+
+	webinars := []model.Webinar{}
+	for rows.Next() {
+		w, err := scanWebinar(rows)
+		if err != nil {
+			return nil, errors.New("failed to scan webinar: " + err.Error())
+		}
+		webinars = append(webinars, *w)
+	}
+	return webinars, rows.Err()
+}
+
+// GetWebinarByID mengambil satu webinar. Jika webinar tidak ditemukan, fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webinarRepository) GetWebinarByID(id int) (*model.Webinar, error) {
+	return scanWebinar(r.db.QueryRow(`SELECT `+webinarColumns+` FROM webinars w WHERE w.id = $1`, id))
+}
+
+// UpdateWebinar memperbarui detail dan jadwal webinar. Jika kapasitas bertambah, pendaftar di daftar
+// tunggu langsung mendapat kursi sesuai urutan mendaftar.
+func (r *webinarRepository) UpdateWebinar(webinar *model.Webinar) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE webinars SET title = $1, description = $2, link_meet = $3, start_time = $4, end_time = $5,
+			capacity = NULLIF($6, 0), registration_deadline = $7, certificate_threshold = $8, updated_at = NOW()
+			WHERE id = $9`
+	result, err := tx.Exec(query, webinar.Title, webinar.Description, webinar.LinkMeet, nullTime(webinar.StartTime),
+		nullTime(webinar.EndTime), webinar.Capacity, nullTime(webinar.RegistrationDeadline), webinar.CertificateThreshold, webinar.ID)
+	if err != nil {
+		return errors.New("failed to update webinar: " + err.Error())
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+
+	// Baris webinar sudah terkunci oleh UPDATE di atas sehingga kapasitas tidak berubah di tengah jalan
+	promote := `UPDATE webinar_registrations SET status = 'registered', promoted_at = NOW(), updated_at = NOW()
+			WHERE id IN (
+				SELECT id FROM webinar_registrations WHERE webinar_id = $1 AND status = 'waitlisted'
+				ORDER BY created_at, id
+				LIMIT (SELECT CASE WHEN w.capacity IS NULL THEN NULL
+				              ELSE GREATEST(w.capacity - (SELECT COUNT(*) FROM webinar_registrations
+				                                          WHERE webinar_id = w.id AND status = 'registered'), 0) END
+				       FROM webinars w WHERE w.id = $1))`
+	if _, err := tx.Exec(promote, webinar.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteWebinar menghapus webinar yang belum memiliki pendaftar aktif. Webinar yang sudah dibatalkan
+// boleh dihapus beserta data pendaftarnya.
+func (r *webinarRepository) DeleteWebinar(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM webinars WHERE id = $1 FOR UPDATE`, id).Scan(&status); err != nil {
+		return err
+	}
+	var active int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM webinar_registrations WHERE webinar_id = $1 AND status <> 'cancelled'`, id).Scan(&active)
+	if err != nil {
+		return err
+	}
+	if active > 0 && status != webinar.StatusCancelled {
+		return ErrWebinarHasRegistrations
+	}
+	if _, err := tx.Exec(`DELETE FROM webinars WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateStatus memindahkan status webinar sesuai aturan webinar.ValidateTransition
+func (r *webinarRepository) UpdateStatus(id int, status string) error {
+	return r.transition(id, status, `UPDATE webinars SET status = $1, updated_at = NOW() WHERE id = $2`, status, id)
+}
+
+// CancelWebinar membatalkan webinar beserta alasannya. Pendaftar diberi tahu oleh job notifikasi.
+func (r *webinarRepository) CancelWebinar(id int, reason string) error {
+	return r.transition(id, webinar.StatusCancelled, `UPDATE webinars
+			SET status = 'cancelled', cancelled_at = NOW(), cancellation_reason = NULLIF($1, ''), updated_at = NOW()
+			WHERE id = $2`, reason, id)
+}
+
+// transition mengunci baris webinar, memvalidasi perpindahan status lalu menjalankan query update
+func (r *webinarRepository) transition(id int, to, query string, args ...any) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	if err := tx.QueryRow(`SELECT status FROM webinars WHERE id = $1 FOR UPDATE`, id).Scan(&current); err != nil {
+		return err
+	}
+	if err := webinar.ValidateTransition(current, to); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateHost mengganti host webinar
+func (r *webinarRepository) UpdateHost(id, hostID int) error {
+	result, err := r.db.Exec(`UPDATE webinars SET host_id = $1, updated_at = NOW() WHERE id = $2`, hostID, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SetRecording menautkan video rekaman ke webinar yang sudah selesai
+func (r *webinarRepository) SetRecording(id, videoID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM webinars WHERE id = $1 FOR UPDATE`, id).Scan(&status); err != nil {
+		return err
+	}
+	if status != webinar.StatusEnded {
+		return ErrWebinarNotEnded
+	}
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM videos WHERE id = $1)`, videoID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrVideoNotFound
+	}
+	if _, err := tx.Exec(`UPDATE webinars SET recording_video_id = $1, updated_at = NOW() WHERE id = $2`, videoID, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetUserRole mengambil peran pengguna berdasarkan ID. Jika pengguna tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows.
+func (r *webinarRepository) GetUserRole(userID int) (string, error) {
+	var role sql.NullString
+	err := r.db.QueryRow("SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+	return role.String, err
+}
+
+// GetRegistrations mengambil daftar pendaftar webinar, opsional difilter berdasarkan status
+func (r *webinarRepository) GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error) {
+	query := `SELECT id, name, email, COALESCE(phone_number, ''), status, created_at
+			FROM webinar_registrations WHERE webinar_id = $1 AND ($2 = '' OR status = $2)
+			ORDER BY created_at, id`
+	rows, err := r.db.Query(query, webinarID, status)
+	if err != nil {
+		return nil, errors.New("failed to fetch registrations: " + err.Error())
+	}
+	defer rows.Close()
+
+	registrations := []model.WebinarRegistration{}
+	for rows.Next() {
+		var reg model.WebinarRegistration
+		if err := rows.Scan(&reg.ID, &reg.Name, &reg.Email, &reg.PhoneNumber, &reg.Status, &reg.CreatedAt); err != nil {
+			return nil, errors.New("failed to scan registration: " + err.Error())
+		}
+		registrations = append(registrations, reg)
+	}
+	return registrations, rows.Err()
+}
+
+// GetAttendance mengambil laporan kehadiran pendaftar webinar
+func (r *webinarRepository) GetAttendance(webinarID int) ([]webinar.Attendance, error) {
+	return webinar.LoadAttendance(r.db, webinarID)
+}
+
+// IssueCertificates menerbitkan sertifikat untuk peserta yang memenuhi batas kehadiran
+func (r *webinarRepository) IssueCertificates(webinarID int) (int, error) {
+	return webinar.IssueCertificates(r.db, webinarID)
+}
+
+// nullTime menyimpan waktu kosong sebagai NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
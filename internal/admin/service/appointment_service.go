@@ -1,21 +1,35 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"go-project/internal/admin/model"
 	"go-project/internal/admin/repository"
+	lifecycle "go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"log"
 	"time"
 )
 
+var (
+	// ErrHostNotFound dikembalikan jika host yang dipilih tidak ada
+	ErrHostNotFound = repository.ErrHostNotFound
+	// ErrHostNotStaff dikembalikan jika host yang dipilih bukan staff aktif
+	ErrHostNotStaff = repository.ErrHostNotStaff
+)
+
 // AppointmentService adalah layanan yang menyediakan logika bisnis untuk janji temu.
 type AppointmentService struct {
-	Repo *repository.AppointmentRepository // Repositori untuk operasi database terkait janji temu
+	Repo     *repository.AppointmentRepository // Repositori untuk operasi database terkait janji temu
+	Meetings calendar.MeetingProvider          // Pembuat link meeting otomatis, nil jika tidak aktif
+	Strategy string                            // Strategi penentuan host otomatis, kosong jika tidak aktif
 }
 
 // NewAppointmentService adalah konstruktor untuk membuat instance baru dari AppointmentService.
-func NewAppointmentService(repo *repository.AppointmentRepository) *AppointmentService {
-	return &AppointmentService{Repo: repo}
+func NewAppointmentService(repo *repository.AppointmentRepository, meetings calendar.MeetingProvider, strategy string) *AppointmentService {
+	return &AppointmentService{Repo: repo, Meetings: meetings, Strategy: strategy}
 }
 
 // ListStaff mengambil daftar staf yang tersedia dari repositori.
@@ -24,8 +38,9 @@ func (s *AppointmentService) ListStaff() ([]model.Staff, error) {
 	return s.Repo.GetStaffList()
 }
 
-// CreateAppointment membuat janji temu baru dan menyimpannya ke dalam repositori.
-func (s *AppointmentService) CreateAppointment(appointment *model.Appointment) error {
+// CreateAppointment membuat janji temu baru dan menyimpannya ke dalam repositori. Status awal "pending"
+// dicatat pada timeline atas nama admin yang membuatnya, sama seperti booking dari user.
+func (s *AppointmentService) CreateAppointment(appointment *model.Appointment, adminEmail string) error {
 	// Memastikan format tanggal dan waktu valid
 	parsedDateOfBooking, err := time.Parse(time.RFC3339, appointment.DateOfBooking.Format(time.RFC3339))
 	if err != nil {
@@ -44,25 +59,185 @@ func (s *AppointmentService) CreateAppointment(appointment *model.Appointment) e
 		return errors.New("all fields are required") // Mengembalikan error jika ada kolom wajib yang kosong
 	}
 
+	// Menggunakan durasi bawaan jika waktu selesai tidak diisi
+	if appointment.EndTime.IsZero() {
+		appointment.EndTime = appointment.Time.Add(lifecycle.DefaultDuration)
+	}
+	if !appointment.EndTime.After(appointment.Time) {
+		return errors.New("end_time must be after time")
+	}
+
+	// Menentukan host: host pilihan admin, atau pilih otomatis sesuai strategi
+	if err := s.resolveHost(appointment); err != nil {
+		return err
+	}
+
 	appointment.Status = "pending" // Menetapkan status janji temu menjadi "pending"
-	// Menyimpan janji temu ke repositori
-	return s.Repo.CreateAppointment(appointment)
+	// Menyimpan janji temu ke repositori; host diperiksa dan dikunci di transaksi yang sama
+	changedBy := "admin"
+	if adminEmail != "" {
+		changedBy += ":" + adminEmail
+	}
+	if err := s.Repo.CreateAppointment(appointment, changedBy); err != nil {
+		return err
+	}
+
+	// Membuat link meeting otomatis jika host sudah ada dan admin tidak mengisi link
+	if appointment.HostID > 0 && appointment.LinkMeet == "" {
+		link, err := s.GenerateMeetingLink(appointment.ID)
+		if err != nil {
+			// Janji temu sudah tersimpan; link bisa dibuat ulang lewat endpoint meeting-link
+			log.Printf("Failed to generate meeting link for appointment %d: %v", appointment.ID, err)
+		}
+		appointment.LinkMeet = link
+	}
+	return nil
+}
+
+// resolveHost menandai host yang dipilih admin sebagai penetapan manual, atau memilih host otomatis
+// dengan strategi appointments.assignment_strategy jika host belum diisi. Jika strategi tidak aktif,
+// janji temu disimpan tanpa host dan admin menetapkannya lewat AssignHost. Host diperiksa ulang oleh
+// repositori di dalam transaksi penyimpanan.
+func (s *AppointmentService) resolveHost(a *model.Appointment) error {
+	day := a.DateOfBooking.Format("2006-01-02")
+	if a.HostID > 0 {
+		a.AssignmentMethod = lifecycle.AssignmentManual
+		return nil
+	}
+
+	strategy := s.Strategy
+	if strategy == "" {
+		return nil
+	}
+	candidates, err := s.Repo.GetHostCandidates(a.Time, a.EndTime, day, 0)
+	if err != nil {
+		return err
+	}
+	host, err := lifecycle.PickHost(strategy, candidates, a.CategoryID)
+	if err != nil {
+		return err
+	}
+	a.HostID = host.StaffID
+	a.AssignmentMethod = strategy
+	return nil
 }
 
 // AssignHost menetapkan host (pemandu) untuk janji temu berdasarkan ID janji temu dan ID host.
+// Penetapan oleh admin selalu menggantikan host yang dipilih otomatis.
 func (s *AppointmentService) AssignHost(appointmentID, hostID int) error {
-	// Memperbarui janji temu dengan host yang ditugaskan
-	return s.Repo.UpdateAppointmentHost(appointmentID, hostID)
+	current, err := s.Repo.GetAppointmentByID(appointmentID)
+	if err != nil {
+		return err
+	}
+	// Memperbarui janji temu dengan host yang ditugaskan; host divalidasi dan dikunci di transaksi yang sama
+	if err := s.Repo.UpdateAppointmentHost(appointmentID, hostID); err != nil {
+		return err
+	}
+	// Link meeting dibuat saat host pertama kali ditetapkan. Host sudah tersimpan, jadi kegagalan hanya
+	// dicatat; link bisa dibuat ulang lewat endpoint meeting-link.
+	if current.LinkMeet == "" {
+		if _, err := s.GenerateMeetingLink(appointmentID); err != nil {
+			log.Printf("Failed to generate meeting link for appointment %d: %v", appointmentID, err)
+		}
+	}
+	return nil
 }
 
-// UpdateStatus memperbarui status janji temu berdasarkan ID janji temu dan status yang baru.
-func (s *AppointmentService) UpdateStatus(appointmentID int, status string) error {
-	// Daftar status yang valid untuk janji temu
-	validStatuses := map[string]bool{"confirmed": true, "pending": true, "cancelled": true}
-	// Memeriksa apakah status yang diberikan valid
-	if !validStatuses[status] {
+// GenerateMeetingLink membuat ulang link meeting janji temu dengan MeetingProvider dan menyimpannya.
+// Jika pembuatan link otomatis tidak aktif, link tidak diubah.
+func (s *AppointmentService) GenerateMeetingLink(appointmentID int) (string, error) {
+	current, err := s.Repo.GetAppointmentByID(appointmentID)
+	if err != nil {
+		return "", err
+	}
+	link, err := s.newMeetingLink(current)
+	if err != nil || link == "" {
+		return current.LinkMeet, err
+	}
+	return link, s.Repo.UpdateMeetingLink(appointmentID, link)
+}
+
+// newMeetingLink meminta link meeting baru dari provider. Mengembalikan string kosong jika provider tidak aktif.
+func (s *AppointmentService) newMeetingLink(a model.Appointment) (string, error) {
+	if s.Meetings == nil {
+		return "", nil
+	}
+	hostEmail, err := s.Repo.GetUserEmail(a.HostID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	meeting, err := s.Meetings.CreateMeeting(context.Background(), calendar.MeetingRequest{
+		Title:     "Appointment " + a.Name,
+		Start:     a.Time,
+		End:       a.EndTime,
+		HostEmail: hostEmail,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create meeting link: %w", err)
+	}
+	return meeting.URL, nil
+}
+
+// GetCalendar membuat file iCalendar untuk janji temu.
+func (s *AppointmentService) GetCalendar(appointmentID int) (calendar.Calendar, error) {
+	a, err := s.Repo.GetAppointmentByID(appointmentID)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	hostEmail, err := s.Repo.GetUserEmail(a.HostID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return calendar.Calendar{}, err
+	}
+
+	return calendar.Calendar{
+		Name: "Appointment " + a.Name,
+		Events: []calendar.Event{{
+			UID:         calendar.AppointmentUID(a.ID),
+			Summary:     "Appointment " + a.Name,
+			Description: "Link meeting: " + a.LinkMeet,
+			Location:    a.LinkMeet,
+			URL:         a.LinkMeet,
+			Start:       a.Time,
+			End:         a.EndTime,
+			Status:      calendar.AppointmentStatus(a.Status),
+			Organizer:   hostEmail,
+			Attendees:   []string{a.Email},
+			Updated:     a.UpdatedAt,
+		}},
+	}, nil
+}
+
+// SetStaffSpecialties mengatur kategori yang ditangani staff untuk strategi "specialty".
+func (s *AppointmentService) SetStaffSpecialties(staffID int, categoryIDs []int) error {
+	role, err := s.Repo.GetUserRole(staffID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHostNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role != "staff" {
+		return ErrHostNotStaff
+	}
+	return s.Repo.SetStaffSpecialties(staffID, categoryIDs)
+}
+
+// UpdateStatus memindahkan status janji temu sesuai aturan lifecycle. Perubahan dicatat pada
+// timeline beserta admin yang melakukannya dan catatan opsional.
+func (s *AppointmentService) UpdateStatus(appointmentID int, status, note, adminEmail string) error {
+	if !lifecycle.IsValidStatus(status) {
 		return errors.New("invalid status") // Mengembalikan error jika status tidak valid
 	}
 	// Memperbarui status janji temu di repositori
-	return s.Repo.UpdateAppointmentStatus(appointmentID, status)
+	return s.Repo.UpdateAppointmentStatus(appointmentID, status, "admin:"+adminEmail, note)
+}
+
+// GetTimeline mengambil riwayat perubahan status janji temu.
+func (s *AppointmentService) GetTimeline(appointmentID int) ([]model.AppointmentStatusChange, error) {
+	return s.Repo.GetStatusHistory(appointmentID)
+}
+
+// GetWhatsAppMessages mengambil balasan WhatsApp klien untuk janji temu.
+func (s *AppointmentService) GetWhatsAppMessages(appointmentID int) ([]model.WhatsAppMessage, error) {
+	return s.Repo.GetWhatsAppMessages(appointmentID)
 }
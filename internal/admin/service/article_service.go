@@ -14,6 +14,9 @@ type ArticleService interface {
 	UpdateArticle(article *model.Article) error    // Fungsi untuk memperbarui artikel yang ada
 	DeleteArticle(id int) error                    // Fungsi untuk menghapus artikel berdasarkan ID
 	GetAllArticles() ([]model.Article, error)      // Fungsi untuk mengambil semua artikel
+
+	// ReviewArticle menyetujui, menolak, atau meminta revisi artikel beserta feedback untuk penulis
+	ReviewArticle(id int, status, feedback, reviewerEmail string) (*model.ContentReview, error)
 }
 
 type articleService struct {
@@ -67,3 +70,35 @@ func (s *articleService) DeleteArticle(id int) error {
 func (s *articleService) GetAllArticles() ([]model.Article, error) {
 	return s.repo.GetAllArticles() // Memanggil repositori untuk mendapatkan semua artikel
 }
+
+// reviewStatuses adalah status yang boleh diberikan reviewer kepada artikel/video
+var reviewStatuses = map[string]bool{"approval": true, "changes requested": true, "rejected": true}
+
+// validateReview memastikan status review valid dan feedback diisi jika konten tidak disetujui
+func validateReview(status, feedback string) error {
+	if !reviewStatuses[status] {
+		return errors.New("invalid review status") // Status hanya boleh approval, changes requested, atau rejected
+	}
+	if status != "approval" && feedback == "" {
+		return errors.New("feedback is required when requesting changes or rejecting") // Penulis perlu tahu alasannya
+	}
+	return nil
+}
+
+// ReviewArticle mengubah status artikel dan menyimpan feedback reviewer ke riwayat review
+func (s *articleService) ReviewArticle(id int, status, feedback, reviewerEmail string) (*model.ContentReview, error) {
+	if err := validateReview(status, feedback); err != nil {
+		return nil, err
+	}
+
+	review := &model.ContentReview{
+		ContentType: "article",
+		ContentID:   id,
+		Status:      status,
+		Feedback:    feedback,
+	}
+	if err := s.repo.ReviewArticle(review, reviewerEmail); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
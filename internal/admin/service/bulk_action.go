@@ -0,0 +1,59 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+)
+
+// validateBulkRequest memastikan aksi valid dan item dipilih lewat IDs atau filter yang benar-benar membatasi.
+// filterSet melaporkan apakah filter mengisi minimal satu kolom yang dipakai entitas tersebut, sehingga
+// filter kosong seperti {} atau kolom milik entitas lain tidak memilih semua item. Hapus selalu butuh IDs,
+// dan jumlah IDs dibatasi sama seperti item yang dipilih lewat filter.
+func validateBulkRequest(req model.BulkActionRequest, filterSet func(*model.BulkFilter) bool) error {
+	switch req.Action {
+	case "approve", "reject", "delete":
+	default:
+		return errors.New("invalid action: must be approve, reject or delete")
+	}
+	if len(req.IDs) > repository.MaxBulkItems {
+		return fmt.Errorf("too many ids: at most %d per request", repository.MaxBulkItems)
+	}
+	if len(req.IDs) > 0 {
+		return nil
+	}
+	if req.Action == "delete" {
+		return errors.New("ids is required for delete")
+	}
+	if req.Filter == nil || !filterSet(req.Filter) {
+		return errors.New("ids or at least one filter field is required")
+	}
+	return nil
+}
+
+// commentFilterSet melaporkan apakah filter komentar mengisi status, article_id atau email
+func commentFilterSet(f *model.BulkFilter) bool {
+	return f.Status != "" || f.ArticleID != 0 || f.Email != ""
+}
+
+// testimonialFilterSet melaporkan apakah filter testimonial mengisi status atau category_id
+func testimonialFilterSet(f *model.BulkFilter) bool {
+	return f.Status != "" || f.CategoryID != 0
+}
+
+// newBulkActionResult menyusun ringkasan dari hasil per item
+func newBulkActionResult(action string, results []model.BulkItemResult) *model.BulkActionResult {
+	summary := &model.BulkActionResult{Action: action, Total: len(results), Results: results}
+	for _, result := range results {
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+	if summary.Results == nil {
+		summary.Results = []model.BulkItemResult{}
+	}
+	return summary
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+	"testing"
+)
+
+func TestValidateBulkRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       model.BulkActionRequest
+		filterSet func(*model.BulkFilter) bool
+		wantErr   bool
+	}{
+		{"approve by ids", model.BulkActionRequest{Action: "approve", IDs: []int{1, 2}}, commentFilterSet, false},
+		{"delete by ids", model.BulkActionRequest{Action: "delete", IDs: []int{1}}, commentFilterSet, false},
+		{"reject by comment filter", model.BulkActionRequest{Action: "reject", Filter: &model.BulkFilter{ArticleID: 3}}, commentFilterSet, false},
+		{"approve by testimonial filter", model.BulkActionRequest{Action: "approve", Filter: &model.BulkFilter{CategoryID: 2}}, testimonialFilterSet, false},
+		{"unknown action", model.BulkActionRequest{Action: "archive", IDs: []int{1}}, commentFilterSet, true},
+		{"no ids and no filter", model.BulkActionRequest{Action: "approve"}, commentFilterSet, true},
+		{"empty filter", model.BulkActionRequest{Action: "approve", Filter: &model.BulkFilter{}}, commentFilterSet, true},
+		{"delete by filter", model.BulkActionRequest{Action: "delete", Filter: &model.BulkFilter{Status: "pending"}}, commentFilterSet, true},
+		{"delete with empty filter", model.BulkActionRequest{Action: "delete", Filter: &model.BulkFilter{}}, commentFilterSet, true},
+		{"ids at the limit", model.BulkActionRequest{Action: "approve", IDs: make([]int, repository.MaxBulkItems)}, commentFilterSet, false},
+		{"too many ids", model.BulkActionRequest{Action: "reject", IDs: make([]int, repository.MaxBulkItems+1)}, commentFilterSet, true},
+		{"filter field of another entity", model.BulkActionRequest{Action: "approve", Filter: &model.BulkFilter{ArticleID: 3}}, testimonialFilterSet, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBulkRequest(tt.req, tt.filterSet)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBulkRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"go-project/internal/admin/model"
 	"go-project/internal/admin/repository"
+	"go-project/pkg/moderation"
 )
 
+// ErrInvalidParent dikembalikan jika komentar induk balasan tidak ada atau berada di artikel lain
+var ErrInvalidParent = errors.New("parent comment not found in this article")
+
 // CommentService menyediakan layanan terkait komentar
 type CommentService interface {
 	GetAllComments() ([]model.Comment, error)                        // Mengambil semua komentar
@@ -12,15 +18,25 @@ type CommentService interface {
 	RejectComment(commentID int) error                               // Menolak komentar
 	DeleteComment(commentID int) error                               // Menghapus komentar
 	CreateComment(comment NewCommentRequest) (*model.Comment, error) // Membuat komentar baru
+
+	// GetReportedComments mengambil antrian komentar yang dilaporkan pembaca
+	GetReportedComments() ([]model.ReportedComment, error)
+
+	// ResolveReports menangani laporan: "dismiss" menutup laporan, "remove" juga menolak komentarnya
+	ResolveReports(commentID int, action, actorEmail string) error
+
+	// BulkModerate menyetujui, menolak, atau menghapus banyak komentar sekaligus
+	BulkModerate(req model.BulkActionRequest, actorEmail string) (*model.BulkActionResult, error)
 }
 
 type commentService struct {
-	repo repository.CommentRepository // Repositori yang digunakan untuk operasi database komentar
+	repo      repository.CommentRepository // Repositori yang digunakan untuk operasi database komentar
+	moderator *moderation.Pipeline         // Pipeline moderasi otomatis, nil berarti semua komentar masuk antrian
 }
 
 // NewCommentService membuat instance baru dari CommentService
-func NewCommentService(repo repository.CommentRepository) CommentService {
-	return &commentService{repo: repo}
+func NewCommentService(repo repository.CommentRepository, moderator *moderation.Pipeline) CommentService {
+	return &commentService{repo: repo, moderator: moderator}
 }
 
 // NewCommentRequest adalah struktur yang digunakan untuk permintaan pembuatan komentar baru
@@ -30,6 +46,7 @@ type NewCommentRequest struct {
 	Email     string `json:"email"`               // Email pengguna
 	Comment   string `json:"comment"`             // Isi komentar
 	ParentID  *int   `json:"parent_id,omitempty"` // ID komentar induk, jika ada
+	IPAddress string `json:"-"`                   // IP pengirim, diisi oleh handler
 }
 
 // GetAllComments mengambil semua komentar
@@ -54,6 +71,18 @@ func (s *commentService) DeleteComment(commentID int) error {
 
 // CreateComment membuat komentar baru
 func (s *commentService) CreateComment(req NewCommentRequest) (*model.Comment, error) {
+	// Balasan harus berada di artikel yang sama dengan komentar induknya; artikel diambil dari induk jika kosong
+	if req.ParentID != nil {
+		articleID, err := s.repo.GetCommentArticleID(*req.ParentID)
+		if errors.Is(err, sql.ErrNoRows) || err == nil && req.ArticleID != 0 && articleID != req.ArticleID {
+			return nil, ErrInvalidParent
+		}
+		if err != nil {
+			return nil, err
+		}
+		req.ArticleID = articleID
+	}
+
 	// Membuat objek komentar dari permintaan
 	comment := model.Comment{
 		ArticleID: req.ArticleID, // ID artikel yang terkait dengan komentar
@@ -62,8 +91,58 @@ func (s *commentService) CreateComment(req NewCommentRequest) (*model.Comment, e
 		Comment:   req.Comment,   // Isi komentar
 		ParentID:  req.ParentID,  // ID komentar induk (jika ada)
 		Status:    "pending",     // Status komentar awal adalah "pending"
+		IPAddress: req.IPAddress, // IP pengirim untuk rate limit
+	}
+
+	// Menjalankan pipeline moderasi untuk menentukan status awal komentar
+	if s.moderator != nil {
+		result, err := s.moderator.Evaluate(moderation.Input{
+			ArticleID: req.ArticleID,
+			Username:  req.Username,
+			Email:     req.Email,
+			IPAddress: req.IPAddress,
+			Content:   req.Comment,
+		})
+		if err != nil {
+			return nil, err
+		}
+		comment.Status = result.Decision
+		comment.ModerationScore = result.Score
+		comment.ModerationReason = result.Reason()
 	}
 
 	// Memanggil repositori untuk menyimpan komentar
 	return s.repo.CreateComment(&comment)
 }
+
+// BulkModerate menjalankan aksi moderasi massal untuk komentar
+func (s *commentService) BulkModerate(req model.BulkActionRequest, actorEmail string) (*model.BulkActionResult, error) {
+	if err := validateBulkRequest(req, commentFilterSet); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.BulkModerate(req.IDs, req.Filter, req.Action, actorEmail)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkActionResult(req.Action, results), nil
+}
+
+// GetReportedComments mengambil antrian komentar yang dilaporkan pembaca
+func (s *commentService) GetReportedComments() ([]model.ReportedComment, error) {
+	return s.repo.GetReportedComments()
+}
+
+// ResolveReports menangani laporan komentar oleh moderator
+func (s *commentService) ResolveReports(commentID int, action, actorEmail string) error {
+	switch action {
+	case "dismiss":
+		// Laporan tidak terbukti; komentar yang disembunyikan karena laporan ditampilkan kembali
+		return s.repo.ResolveReports(commentID, "dismissed", actorEmail)
+	case "remove":
+		// Laporan terbukti, komentar ditolak
+		return s.repo.ResolveReports(commentID, "upheld", actorEmail)
+	default:
+		return errors.New("invalid action: must be dismiss or remove")
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+	"go-project/pkg/notify"
+)
+
+// ErrInvalidNotificationStatus dikembalikan jika filter status tidak dikenal
+var ErrInvalidNotificationStatus = errors.New("invalid notification status")
+
+// Status yang valid untuk filter outbox dan pengiriman
+var (
+	outboxStatuses   = map[string]bool{"pending": true, "dispatched": true, "dead": true}
+	deliveryStatuses = map[string]bool{"pending": true, "sent": true, "dead": true}
+)
+
+// NotificationTemplate adalah template pesan yang berlaku beserta variabel yang bisa dipakai.
+// Didefinisikan di sini karena package model tidak boleh bergantung pada pkg/notify.
+type NotificationTemplate struct {
+	notify.Template
+	Variables []notify.Variable `json:"variables"`
+}
+
+type NotificationService interface {
+	GetOutbox(status string) ([]model.NotificationOutbox, error)       // Mengambil event di outbox; bawaan status dead
+	RetryOutbox(id int) error                                          // Mengirim ulang event dari dead-letter
+	GetDeliveries(status string) ([]model.NotificationDelivery, error) // Mengambil pengiriman; bawaan status dead
+	RetryDelivery(id int) error                                        // Mengirim ulang satu pengiriman dari dead-letter
+	RetryDeadDeliveries() (int64, error)                               // Mengirim ulang semua pengiriman dari dead-letter
+
+	ListTemplates() ([]NotificationTemplate, error)                                      // Mengambil template yang berlaku untuk semua event dan bahasa
+	GetTemplate(event, locale string) (*NotificationTemplate, error)                     // Mengambil template satu event dan bahasa
+	SaveTemplate(tmpl notify.Template, actorEmail string) (*NotificationTemplate, error) // Menyimpan ubahan template setelah divalidasi
+	ResetTemplate(event, locale string) (*NotificationTemplate, error)                   // Mengembalikan template ke bawaan
+	PreviewTemplate(req model.TemplatePreviewRequest) (*model.TemplatePreview, error)    // Merender template tanpa menyimpan
+}
+
+type notificationService struct {
+	repo      repository.NotificationRepository // Repositori untuk outbox dan pengiriman notifikasi
+	templates *notify.Templates                 // Registry template pesan per event dan bahasa
+}
+
+// NewNotificationService membuat instance baru dari NotificationService
+func NewNotificationService(repo repository.NotificationRepository, templates *notify.Templates) NotificationService {
+	return &notificationService{repo: repo, templates: templates}
+}
+
+// GetOutbox mengambil event di outbox berdasarkan status. Tanpa status, yang diambil adalah dead-letter.
+func (s *notificationService) GetOutbox(status string) ([]model.NotificationOutbox, error) {
+	if status == "" {
+		status = "dead"
+	}
+	if !outboxStatuses[status] {
+		return nil, ErrInvalidNotificationStatus
+	}
+	return s.repo.GetOutbox(status)
+}
+
+// RetryOutbox menjadwalkan ulang event yang masuk dead-letter
+func (s *notificationService) RetryOutbox(id int) error {
+	return s.repo.RetryOutbox(id)
+}
+
+// GetDeliveries mengambil pengiriman berdasarkan status. Tanpa status, yang diambil adalah dead-letter.
+func (s *notificationService) GetDeliveries(status string) ([]model.NotificationDelivery, error) {
+	if status == "" {
+		status = "dead"
+	}
+	if !deliveryStatuses[status] {
+		return nil, ErrInvalidNotificationStatus
+	}
+	return s.repo.GetDeliveries(status)
+}
+
+// RetryDelivery menjadwalkan ulang satu pengiriman yang masuk dead-letter
+func (s *notificationService) RetryDelivery(id int) error {
+	return s.repo.RetryDelivery(id)
+}
+
+// RetryDeadDeliveries menjadwalkan ulang semua pengiriman yang masuk dead-letter
+func (s *notificationService) RetryDeadDeliveries() (int64, error) {
+	return s.repo.RetryDeadDeliveries()
+}
+
+// ListTemplates mengambil template yang berlaku untuk semua event dan bahasa
+func (s *notificationService) ListTemplates() ([]NotificationTemplate, error) {
+	list, err := s.templates.List(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]NotificationTemplate, 0, len(list))
+	for _, tmpl := range list {
+		t, err := s.withVariables(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *t)
+	}
+	return templates, nil
+}
+
+// GetTemplate mengambil template satu event dan bahasa
+func (s *notificationService) GetTemplate(event, locale string) (*NotificationTemplate, error) {
+	tmpl, err := s.templates.Get(context.Background(), event, locale)
+	if err != nil {
+		return nil, err
+	}
+	return s.withVariables(tmpl)
+}
+
+// SaveTemplate menyimpan ubahan template. Template yang tidak bisa dirender dengan variabel event ditolak.
+func (s *notificationService) SaveTemplate(tmpl notify.Template, actorEmail string) (*NotificationTemplate, error) {
+	saved, err := s.templates.Save(context.Background(), tmpl, actorEmail)
+	if err != nil {
+		return nil, err
+	}
+	return s.withVariables(saved)
+}
+
+// ResetTemplate menghapus ubahan admin sehingga template kembali ke bawaan
+func (s *notificationService) ResetTemplate(event, locale string) (*NotificationTemplate, error) {
+	tmpl, err := s.templates.Reset(context.Background(), event, locale)
+	if err != nil {
+		return nil, err
+	}
+	return s.withVariables(tmpl)
+}
+
+// PreviewTemplate merender template yang dikirim, atau template yang berlaku jika subjek dan isi kosong
+func (s *notificationService) PreviewTemplate(req model.TemplatePreviewRequest) (*model.TemplatePreview, error) {
+	tmpl := notify.Template{Event: req.Event, Locale: req.Locale, Subject: req.Subject, Body: req.Body}
+	if req.Subject == "" && req.Body == "" {
+		current, err := s.templates.Get(context.Background(), req.Event, req.Locale)
+		if err != nil {
+			return nil, err
+		}
+		tmpl = current
+	}
+	subject, body, err := s.templates.Preview(tmpl, req.Vars)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TemplatePreview{Subject: subject, Body: body}, nil
+}
+
+// withVariables melengkapi template dengan daftar variabel event-nya
+func (s *notificationService) withVariables(tmpl notify.Template) (*NotificationTemplate, error) {
+	spec, err := s.templates.Spec(tmpl.Event)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationTemplate{Template: tmpl, Variables: spec.Variables()}, nil
+}
@@ -13,6 +13,9 @@ type TestimonialService interface {
 	DeleteTestimonial(id int) error                                // Menghapus testimonial berdasarkan ID
 	ApproveTestimonial(id int) error                               // Menyetujui testimonial
 	RejectedTestimonial(id int) error                              // Menolak testimonial
+
+	// BulkModerate menyetujui, menolak, atau menghapus banyak testimonial sekaligus
+	BulkModerate(req model.BulkActionRequest, actorEmail string) (*model.BulkActionResult, error)
 }
 
 type testimonialService struct {
@@ -58,3 +61,16 @@ func (s *testimonialService) ApproveTestimonial(id int) error {
 func (s *testimonialService) RejectedTestimonial(id int) error {
 	return s.repo.UpdateStatus(id, "rejected")
 }
+
+// BulkModerate menjalankan aksi moderasi massal untuk testimonial
+func (s *testimonialService) BulkModerate(req model.BulkActionRequest, actorEmail string) (*model.BulkActionResult, error) {
+	if err := validateBulkRequest(req, testimonialFilterSet); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.BulkModerate(req.IDs, req.Filter, req.Action, actorEmail)
+	if err != nil {
+		return nil, err
+	}
+	return newBulkActionResult(req.Action, results), nil
+}
@@ -1,6 +1,9 @@
 package service
 
-import "go-project/internal/admin/repository"
+import (
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+)
 
 // VideoService adalah struktur yang menyediakan logika bisnis terkait video
 // Struktur ini berkomunikasi dengan lapisan repository untuk menangani operasi basis data terkait video.
@@ -37,3 +40,21 @@ func (s *VideoService) UpdateVideo(video repository.Video) error {
 func (s *VideoService) DeleteVideo(id int) error {
 	return s.Repo.Delete(id)
 }
+
+// Fungsi ini mengubah status video dan menyimpan feedback reviewer ke riwayat review.
+func (s *VideoService) ReviewVideo(id int, status, feedback, reviewerEmail string) (*model.ContentReview, error) {
+	if err := validateReview(status, feedback); err != nil {
+		return nil, err
+	}
+
+	review := &model.ContentReview{
+		ContentType: "video",
+		ContentID:   id,
+		Status:      status,
+		Feedback:    feedback,
+	}
+	if err := s.Repo.ReviewVideo(review, reviewerEmail); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-project/internal/admin/model"
+	"go-project/internal/admin/repository"
+	"go-project/pkg/webhook"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidWebhook dikembalikan jika URL atau daftar event langganan webhook tidak valid
+var ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type WebhookService interface {
+	CreateSubscription(sub *model.WebhookSubscription) error                                     // Membuat langganan; secret dibuat otomatis jika kosong
+	GetSubscriptions() ([]model.WebhookSubscription, error)                                      // Mengambil semua langganan
+	GetSubscriptionByID(id int) (*model.WebhookSubscription, error)                              // Mengambil satu langganan tanpa secret
+	UpdateSubscription(sub *model.WebhookSubscription) error                                     // Memperbarui langganan
+	DeleteSubscription(id int) error                                                             // Menghapus langganan
+	GetDeliveries(subscriptionID int, status string, limit int) ([]model.WebhookDelivery, error) // Mengambil log pengiriman
+	RetryDelivery(id int) error                                                                  // Mengirim ulang pengiriman dari dead-letter
+	SendTestEvent(subscriptionID int) (*model.WebhookDelivery, error)                            // Mengirim event uji dan mengembalikan hasilnya
+}
+
+type webhookService struct {
+	repo   repository.WebhookRepository // Repositori langganan dan log pengiriman webhook
+	sender *webhook.Sender              // Pengirim request untuk event uji
+}
+
+// NewWebhookService membuat instance baru dari WebhookService
+func NewWebhookService(repo repository.WebhookRepository, sender *webhook.Sender) WebhookService {
+	return &webhookService{repo: repo, sender: sender}
+}
+
+// CreateSubscription memvalidasi lalu menyimpan langganan baru. Secret dikembalikan sekali di sini.
+func (s *webhookService) CreateSubscription(sub *model.WebhookSubscription) error {
+	if err := validateSubscription(sub); err != nil {
+		return err
+	}
+	if sub.Secret == "" {
+		secret, err := webhook.NewSecret()
+		if err != nil {
+			return err
+		}
+		sub.Secret = secret
+	}
+	return s.repo.CreateSubscription(sub)
+}
+
+// GetSubscriptions mengambil semua langganan webhook
+func (s *webhookService) GetSubscriptions() ([]model.WebhookSubscription, error) {
+	return s.repo.GetSubscriptions()
+}
+
+// GetSubscriptionByID mengambil satu langganan tanpa menampilkan secret
+func (s *webhookService) GetSubscriptionByID(id int) (*model.WebhookSubscription, error) {
+	sub, err := s.repo.GetSubscriptionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Secret = ""
+	return sub, nil
+}
+
+// UpdateSubscription memperbarui langganan. Secret hanya diganti jika diisi.
+func (s *webhookService) UpdateSubscription(sub *model.WebhookSubscription) error {
+	if err := validateSubscription(sub); err != nil {
+		return err
+	}
+	return s.repo.UpdateSubscription(sub)
+}
+
+// DeleteSubscription menghapus langganan beserta log pengirimannya
+func (s *webhookService) DeleteSubscription(id int) error {
+	return s.repo.DeleteSubscription(id)
+}
+
+// GetDeliveries mengambil log pengiriman terbaru sebuah langganan
+func (s *webhookService) GetDeliveries(subscriptionID int, status string, limit int) ([]model.WebhookDelivery, error) {
+	if _, err := s.repo.GetSubscriptionByID(subscriptionID); err != nil {
+		return nil, err
+	}
+	if status != "" && !deliveryStatuses[status] {
+		return nil, ErrInvalidNotificationStatus
+	}
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	return s.repo.GetDeliveries(subscriptionID, status, limit)
+}
+
+// RetryDelivery menjadwalkan ulang pengiriman yang masuk dead-letter
+func (s *webhookService) RetryDelivery(id int) error {
+	return s.repo.RetryDelivery(id)
+}
+
+// SendTestEvent mengirim event webhook.test ke langganan secara langsung, mencatatnya di log
+// pengiriman dan mengembalikan hasilnya. Langganan nonaktif juga bisa diuji.
+func (s *webhookService) SendTestEvent(subscriptionID int) (*model.WebhookDelivery, error) {
+	sub, err := s.repo.GetSubscriptionByID(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"event":       webhook.EventTest,
+		"occurred_at": time.Now(),
+		"data": map[string]any{
+			"subscription_id": sub.ID,
+			"message":         "Ini adalah event uji dari Edukasi.",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	id, err := s.repo.CreateTestDelivery(ctx, sub.ID, payload)
+	if err != nil {
+		return nil, err
+	}
+	result, sendErr := s.sender.Send(ctx, webhook.Request{
+		DeliveryID: id,
+		Event:      webhook.EventTest,
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		Payload:    payload,
+	})
+	if err := s.repo.RecordTestResult(ctx, id, result, sendErr); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveryByID(id)
+}
+
+// validateSubscription memastikan URL absolut http/https ke alamat publik dan event yang dipilih dikenal.
+// Nama domain baru diperiksa alamatnya saat dikirim oleh webhook.Sender.
+func validateSubscription(sub *model.WebhookSubscription) error {
+	sub.URL = strings.TrimSpace(sub.URL)
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); strings.EqualFold(u.Hostname(), "localhost") || (err == nil && !webhook.AllowedIP(ip)) {
+		return fmt.Errorf("%w: url must not point to a loopback or private address", ErrInvalidWebhook)
+	}
+	if len(sub.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	seen := map[string]bool{}
+	events := sub.Events[:0]
+	for _, event := range sub.Events {
+		event = strings.TrimSpace(event)
+		if _, ok := webhook.Events[event]; !ok {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	sub.Events = events
+	return nil
+}
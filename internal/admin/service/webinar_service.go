@@ -1,26 +1,250 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"go-project/internal/admin/model"
 	"go-project/internal/admin/repository"
+	"go-project/pkg/calendar"
+	"go-project/pkg/webinar"
+)
+
+var (
+	// ErrInvalidWebinar dikembalikan jika data webinar tidak valid
+	ErrInvalidWebinar = errors.New("invalid webinar data")
+	// ErrWebinarClosed dikembalikan jika webinar yang sudah selesai atau dibatalkan akan diubah
+	ErrWebinarClosed = errors.New("webinar has ended or been cancelled and can no longer be changed")
 )
 
 type WebinarService interface {
 	CreateWebinar(webinar *model.Webinar) error
+	GetAllWebinars(status string) ([]model.Webinar, error)
+	GetWebinarByID(id int) (*model.Webinar, error)
+	UpdateWebinar(webinar *model.Webinar) error
+	DeleteWebinar(id int) error
+	UpdateStatus(id int, status string) error
+	CancelWebinar(id int, reason string) error
+	ReassignHost(id, hostID int) error
+	SetRecording(id, videoID int) error
+	GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error)
+	GetAttendance(webinarID int) ([]webinar.Attendance, error)
+	IssueCertificates(webinarID int) (int, error)
 }
 
 type webinarService struct {
-	repo repository.WebinarRepository
+	repo     repository.WebinarRepository
+	meetings calendar.MeetingProvider
 }
 
-func NewWebinarService(repo repository.WebinarRepository) WebinarService {
-	return &webinarService{repo: repo}
+func NewWebinarService(repo repository.WebinarRepository, meetings calendar.MeetingProvider) WebinarService {
+	return &webinarService{repo: repo, meetings: meetings}
 }
 
-func (s *webinarService) CreateWebinar(webinar *model.Webinar) error {
-	if webinar.Title == "" || webinar.Description == "" || webinar.HostID == 0 {
-		return errors.New("invalid webinar data")
+func (s *webinarService) CreateWebinar(w *model.Webinar) error {
+	if w.HostID == 0 {
+		return fmt.Errorf("%w: host_id is required", ErrInvalidWebinar)
+	}
+	// Webinar tanpa jadwal disimpan sebagai draft
+	if w.Status == "" {
+		w.Status = webinar.StatusDraft
+		if !w.StartTime.IsZero() {
+			w.Status = webinar.StatusScheduled
+		}
+	}
+	if w.Status != webinar.StatusDraft && w.Status != webinar.StatusScheduled {
+		return fmt.Errorf("%w: new webinars must be draft or scheduled", ErrInvalidWebinar)
+	}
+	if w.CertificateThreshold == 0 {
+		w.CertificateThreshold = webinar.DefaultCertificateThreshold
+	}
+	if err := validateWebinar(w); err != nil {
+		return err
+	}
+	if err := s.validateHost(w.HostID); err != nil {
+		return err
+	}
+	if err := s.generateMeetingLink(w); err != nil {
+		return err
+	}
+	return s.repo.CreateWebinar(w)
+}
+
+// GetAllWebinars mengambil semua webinar, opsional difilter berdasarkan status
+func (s *webinarService) GetAllWebinars(status string) ([]model.Webinar, error) {
+	if status != "" && !webinar.IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidWebinar, status)
+	}
+	return s.repo.GetAllWebinars(status)
+}
+
+// GetWebinarByID mengambil detail webinar beserta jumlah pendaftarnya
+func (s *webinarService) GetWebinarByID(id int) (*model.Webinar, error) {
+	return s.repo.GetWebinarByID(id)
+}
+
+// UpdateWebinar memperbarui detail, jadwal dan kapasitas webinar yang belum selesai atau dibatalkan
+func (s *webinarService) UpdateWebinar(w *model.Webinar) error {
+	current, err := s.repo.GetWebinarByID(w.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status == webinar.StatusEnded || current.Status == webinar.StatusCancelled {
+		return ErrWebinarClosed
+	}
+	w.Status = current.Status
+	if w.CertificateThreshold == 0 {
+		w.CertificateThreshold = current.CertificateThreshold
+	}
+	if err := validateWebinar(w); err != nil {
+		return err
+	}
+	if w.LinkMeet == "" {
+		w.LinkMeet = current.LinkMeet
+	}
+	if err := s.generateMeetingLink(w); err != nil {
+		return err
+	}
+	return s.repo.UpdateWebinar(w)
+}
+
+// DeleteWebinar menghapus webinar yang belum memiliki pendaftar aktif
+func (s *webinarService) DeleteWebinar(id int) error {
+	return s.repo.DeleteWebinar(id)
+}
+
+// UpdateStatus mengubah status webinar (draft, scheduled, live, ended). Pembatalan memakai CancelWebinar.
+// Saat webinar selesai, sertifikat langsung diterbitkan untuk peserta yang memenuhi batas kehadiran.
+func (s *webinarService) UpdateStatus(id int, status string) error {
+	if status == webinar.StatusCancelled {
+		return s.CancelWebinar(id, "")
+	}
+	if status == webinar.StatusScheduled {
+		current, err := s.repo.GetWebinarByID(id)
+		if err != nil {
+			return err
+		}
+		if current.StartTime.IsZero() || current.EndTime.IsZero() {
+			return fmt.Errorf("%w: start_time and end_time are required to schedule a webinar", ErrInvalidWebinar)
+		}
+	}
+	if err := s.repo.UpdateStatus(id, status); err != nil {
+		return err
+	}
+	if status == webinar.StatusEnded {
+		if _, err := s.repo.IssueCertificates(id); err != nil {
+			return errors.New("webinar ended but issuing certificates failed: " + err.Error())
+		}
+	}
+	return nil
+}
+
+// CancelWebinar membatalkan webinar. Semua pendaftar dan daftar tunggu akan menerima pemberitahuan.
+func (s *webinarService) CancelWebinar(id int, reason string) error {
+	return s.repo.CancelWebinar(id, reason)
+}
+
+// ReassignHost mengganti host webinar dengan staff lain
+func (s *webinarService) ReassignHost(id, hostID int) error {
+	current, err := s.repo.GetWebinarByID(id)
+	if err != nil {
+		return err
+	}
+	if current.Status == webinar.StatusEnded || current.Status == webinar.StatusCancelled {
+		return ErrWebinarClosed
+	}
+	if err := s.validateHost(hostID); err != nil {
+		return err
+	}
+	return s.repo.UpdateHost(id, hostID)
+}
+
+// SetRecording menautkan video rekaman ke webinar yang sudah selesai
+func (s *webinarService) SetRecording(id, videoID int) error {
+	if videoID <= 0 {
+		return fmt.Errorf("%w: video_id is required", ErrInvalidWebinar)
+	}
+	return s.repo.SetRecording(id, videoID)
+}
+
+// GetRegistrations mengambil daftar peserta dan daftar tunggu webinar
+func (s *webinarService) GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error) {
+	return s.repo.GetRegistrations(webinarID, status)
+}
+
+// GetAttendance mengambil laporan kehadiran webinar
+func (s *webinarService) GetAttendance(webinarID int) ([]webinar.Attendance, error) {
+	if _, err := s.repo.GetWebinarByID(webinarID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAttendance(webinarID)
+}
+
+// IssueCertificates menerbitkan ulang sertifikat yang belum ada, misalnya setelah host
+// memperbaiki data kehadiran. Hanya untuk webinar yang sudah selesai.
+func (s *webinarService) IssueCertificates(webinarID int) (int, error) {
+	current, err := s.repo.GetWebinarByID(webinarID)
+	if err != nil {
+		return 0, err
+	}
+	if current.Status != webinar.StatusEnded {
+		return 0, repository.ErrWebinarNotEnded
+	}
+	return s.repo.IssueCertificates(webinarID)
+}
+
+// validateWebinar memeriksa data wajib dan urutan jadwal webinar
+func validateWebinar(w *model.Webinar) error {
+	if w.Title == "" || w.Description == "" {
+		return fmt.Errorf("%w: title and description are required", ErrInvalidWebinar)
+	}
+	if w.Status != webinar.StatusDraft && (w.StartTime.IsZero() || w.EndTime.IsZero()) {
+		return fmt.Errorf("%w: start_time and end_time are required", ErrInvalidWebinar)
+	}
+	if !w.StartTime.IsZero() && !w.EndTime.After(w.StartTime) {
+		return fmt.Errorf("%w: end_time must be after start_time", ErrInvalidWebinar)
+	}
+	if w.CertificateThreshold < 1 || w.CertificateThreshold > 100 {
+		return fmt.Errorf("%w: certificate_threshold must be between 1 and 100", ErrInvalidWebinar)
+	}
+	if w.Capacity < 0 {
+		return fmt.Errorf("%w: capacity must not be negative", ErrInvalidWebinar)
+	}
+	if !w.RegistrationDeadline.IsZero() && !w.StartTime.IsZero() && w.RegistrationDeadline.After(w.StartTime) {
+		return fmt.Errorf("%w: registration_deadline must not be after start_time", ErrInvalidWebinar)
+	}
+	return nil
+}
+
+// validateHost memastikan host webinar adalah staff
+func (s *webinarService) validateHost(hostID int) error {
+	role, err := s.repo.GetUserRole(hostID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHostNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role != "staff" {
+		return ErrHostNotStaff
+	}
+	return nil
+}
+
+// generateMeetingLink membuat link meeting otomatis jika tidak diisi dan MeetingProvider aktif
+func (s *webinarService) generateMeetingLink(w *model.Webinar) error {
+	if w.LinkMeet != "" || s.meetings == nil || w.StartTime.IsZero() {
+		return nil
+	}
+	meeting, err := s.meetings.CreateMeeting(context.Background(), calendar.MeetingRequest{
+		Title: w.Title,
+		Start: w.StartTime,
+		End:   w.EndTime,
+	})
+	if err != nil {
+		return errors.New("failed to create meeting link: " + err.Error())
 	}
-	return s.repo.CreateWebinar(webinar)
+	w.LinkMeet = meeting.URL
+	return nil
 }
@@ -1,11 +1,19 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/service"
+	"go-project/pkg/appointment"
+	"go-project/pkg/middleware"
+	"go-project/pkg/storage"
 	"log"
 	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 type AppointmentHandler struct {
@@ -35,12 +43,141 @@ func (h *AppointmentHandler) CreateAppointment(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(appointment)
 }
 
+// ListAppointments menampilkan appointment yang dipandu staff yang sedang login.
+// Query opsional: range (day|week), date (YYYY-MM-DD) dan status.
 func (h *AppointmentHandler) ListAppointments(w http.ResponseWriter, r *http.Request) {
-	appointments, err := h.Service.ListAppointments()
+	query := r.URL.Query()
+	filter := model.AppointmentFilter{
+		Date:   query.Get("date"),
+		Range:  query.Get("range"),
+		Status: query.Get("status"),
+	}
+
+	appointments, err := h.Service.ListAppointments(middleware.GetUserEmail(r.Context()), filter)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeAppointmentError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(appointments)
 }
+
+// GetAppointment menampilkan satu appointment yang dipandu beserta catatan pribadi dan dokumen tindak lanjutnya
+func (h *AppointmentHandler) GetAppointment(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	detail, err := h.Service.GetAppointment(middleware.GetUserEmail(r.Context()), id)
+	if err != nil {
+		writeAppointmentError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// AddNote menambahkan catatan sesi pribadi ke appointment yang dipandu
+func (h *AppointmentHandler) AddNote(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	var body struct {
+		Note string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	note, err := h.Service.AddNote(middleware.GetUserEmail(r.Context()), id, body.Note)
+	if err != nil {
+		writeAppointmentError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(note)
+}
+
+// CompleteAppointment menandai sesi yang dipandu sebagai selesai
+func (h *AppointmentHandler) CompleteAppointment(w http.ResponseWriter, r *http.Request) {
+	h.closeSession(w, r, h.Service.CompleteAppointment)
+}
+
+// MarkNoShow menandai klien tidak hadir pada sesi yang dipandu
+func (h *AppointmentHandler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
+	h.closeSession(w, r, h.Service.MarkNoShow)
+}
+
+func (h *AppointmentHandler) closeSession(w http.ResponseWriter, r *http.Request, close func(email string, id int, note string) error) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	var body struct {
+		Note string `json:"note"`
+	}
+	// Body bersifat opsional
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := close(middleware.GetUserEmail(r.Context()), id, body.Note); err != nil {
+		writeAppointmentError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Status updated successfully"))
+}
+
+// UploadDocument mengunggah dokumen tindak lanjut untuk klien dari field multipart "file"
+func (h *AppointmentHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxDocumentSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing or too large file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	doc, err := h.Service.UploadDocument(middleware.GetUserEmail(r.Context()), id, header.Filename, file)
+	if err != nil {
+		writeAppointmentError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+// DownloadDocument mengunduh dokumen tindak lanjut dari appointment yang dipandu
+func (h *AppointmentHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, _ := strconv.Atoi(vars["id"])
+	documentID, _ := strconv.Atoi(vars["documentId"])
+
+	doc, err := h.Service.GetDocument(middleware.GetUserEmail(r.Context()), id, documentID)
+	if err != nil {
+		writeAppointmentError(w, err)
+		return
+	}
+	if err := storage.Serve(w, h.Service.Files, doc.StorageKey, doc.Filename, doc.ContentType); err != nil {
+		writeAppointmentError(w, err)
+	}
+}
+
+// writeAppointmentError memetakan error dashboard appointment ke status HTTP yang sesuai
+func writeAppointmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotStaff):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, storage.ErrNotFound):
+		http.Error(w, "Appointment not found", http.StatusNotFound)
+	case errors.Is(err, appointment.ErrInvalidTransition), errors.Is(err, service.ErrSessionNotStarted):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrDocumentTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, service.ErrDocumentType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -2,10 +2,16 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"go-project/internal/staff/model"
+	"go-project/internal/staff/repository"
 	"go-project/internal/staff/service"
+	"go-project/pkg/middleware"
+	"log"
 	"net/http"
 	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 // ArticleHandler handles HTTP requests for articles
@@ -21,9 +27,9 @@ func (h *ArticleHandler) UploadArticle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Buat artikel melalui service
-	if err := h.Service.CreateArticle(article); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	// Buat artikel melalui service; penulis diambil dari token, bukan dari body
+	if err := h.Service.CreateArticle(middleware.GetUserEmail(r.Context()), article); err != nil {
+		writeContentError(w, err)
 		return
 	}
 
@@ -65,3 +71,93 @@ func (h *ArticleHandler) GetAllArticles(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(articles)
 }
+
+// GetMyArticles mengambil artikel yang ditulis staff yang sedang login
+func (h *ArticleHandler) GetMyArticles(w http.ResponseWriter, r *http.Request) {
+	email := middleware.GetUserEmail(r.Context())
+	articles, err := h.Service.GetMyArticles(email, r.URL.Query().Get("status"))
+	if err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(articles)
+}
+
+// UpdateMyArticle mengubah artikel sendiri selama masih pending atau diminta revisi
+func (h *ArticleHandler) UpdateMyArticle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var article model.Article
+	if err := json.NewDecoder(r.Body).Decode(&article); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	article.ID = id
+
+	email := middleware.GetUserEmail(r.Context())
+	if err := h.Service.UpdateMyArticle(email, article); err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Article updated and resubmitted for approval"})
+}
+
+// WithdrawMyArticle menarik artikel sendiri dari review atau publikasi
+func (h *ArticleHandler) WithdrawMyArticle(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	email := middleware.GetUserEmail(r.Context())
+	if err := h.Service.WithdrawMyArticle(email, id); err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Article withdrawn"})
+}
+
+// GetMyArticleReviews mengambil riwayat masukan reviewer untuk artikel sendiri
+func (h *ArticleHandler) GetMyArticleReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	email := middleware.GetUserEmail(r.Context())
+	reviews, err := h.Service.GetMyArticleReviews(email, id)
+	if err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviews)
+}
+
+// writeContentError memetakan error konten milik staff ke status HTTP
+func writeContentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotStaff):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrInvalidStatus):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, repository.ErrNotEditable), errors.Is(err, repository.ErrNotWithdrawable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Printf("staff content: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
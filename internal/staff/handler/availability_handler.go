@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"go-project/internal/staff/model"
+	"go-project/internal/staff/service"
+	"go-project/pkg/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type AvailabilityHandler struct {
+	Service *service.AvailabilityService
+}
+
+func NewAvailabilityHandler(service *service.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{Service: service}
+}
+
+// GetAvailability menampilkan jadwal ketersediaan mingguan staff yang sedang login
+func (h *AvailabilityHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.Service.GetMyAvailability(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(availability)
+}
+
+// SetAvailability mengganti jadwal ketersediaan mingguan staff yang sedang login
+func (h *AvailabilityHandler) SetAvailability(w http.ResponseWriter, r *http.Request) {
+	var availability []model.Availability
+	if err := json.NewDecoder(r.Body).Decode(&availability); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	saved, err := h.Service.SetMyAvailability(middleware.GetUserEmail(r.Context()), availability)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
+
+// GetExceptions menampilkan pengecualian ketersediaan mendatang milik staff yang sedang login
+func (h *AvailabilityHandler) GetExceptions(w http.ResponseWriter, r *http.Request) {
+	exceptions, err := h.Service.GetMyExceptions(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exceptions)
+}
+
+// CreateException menambahkan hari libur atau jam khusus untuk tanggal tertentu
+func (h *AvailabilityHandler) CreateException(w http.ResponseWriter, r *http.Request) {
+	var exception model.AvailabilityException
+	if err := json.NewDecoder(r.Body).Decode(&exception); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.Service.AddMyException(middleware.GetUserEmail(r.Context()), exception)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// DeleteException menghapus pengecualian ketersediaan
+func (h *AvailabilityHandler) DeleteException(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteMyException(middleware.GetUserEmail(r.Context()), id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-project/internal/staff/service"
+	"go-project/pkg/calendar"
+	"go-project/pkg/middleware"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type CalendarHandler struct {
+	Service *service.CalendarService
+}
+
+func NewCalendarHandler(service *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{Service: service}
+}
+
+// GetMyFeed mengembalikan URL feed iCal pribadi milik staff yang sedang login
+func (h *CalendarHandler) GetMyFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.Service.GetMyFeed(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feed)
+}
+
+// ResetMyFeed membuat URL feed iCal baru dan menonaktifkan URL lama
+func (h *CalendarHandler) ResetMyFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.Service.ResetMyFeed(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feed)
+}
+
+// GetFeed menyajikan feed iCal yang bisa di-subscribe aplikasi kalender. Token di URL berfungsi sebagai kredensial.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	cal, err := h.Service.GetFeed(mux.Vars(r)["token"])
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Calendar not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Write(cal.Bytes())
+}
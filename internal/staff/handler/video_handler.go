@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/service"
+	"go-project/pkg/middleware"
 	"net/http"
 	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 // VideoHandler handles HTTP requests for videos
@@ -21,9 +24,9 @@ func (h *VideoHandler) UploadVideo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Buat video melalui service
-	if err := h.Service.CreateVideo(video); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	// Buat video melalui service; penulis diambil dari token, bukan dari body
+	if err := h.Service.CreateVideo(middleware.GetUserEmail(r.Context()), video); err != nil {
+		writeContentError(w, err)
 		return
 	}
 
@@ -65,3 +68,78 @@ func (h *VideoHandler) GetAllVideos(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(videos)
 }
+
+// GetMyVideos mengambil video yang diunggah staff yang sedang login
+func (h *VideoHandler) GetMyVideos(w http.ResponseWriter, r *http.Request) {
+	email := middleware.GetUserEmail(r.Context())
+	videos, err := h.Service.GetMyVideos(email, r.URL.Query().Get("status"))
+	if err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(videos)
+}
+
+// UpdateMyVideo mengubah video sendiri selama masih pending atau diminta revisi
+func (h *VideoHandler) UpdateMyVideo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var video model.Video
+	if err := json.NewDecoder(r.Body).Decode(&video); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	video.ID = id
+
+	email := middleware.GetUserEmail(r.Context())
+	if err := h.Service.UpdateMyVideo(email, video); err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Video updated and resubmitted for approval"})
+}
+
+// WithdrawMyVideo menarik video sendiri dari review atau publikasi
+func (h *VideoHandler) WithdrawMyVideo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	email := middleware.GetUserEmail(r.Context())
+	if err := h.Service.WithdrawMyVideo(email, id); err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Video withdrawn"})
+}
+
+// GetMyVideoReviews mengambil riwayat masukan reviewer untuk video sendiri
+func (h *VideoHandler) GetMyVideoReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	email := middleware.GetUserEmail(r.Context())
+	reviews, err := h.Service.GetMyVideoReviews(email, id)
+	if err != nil {
+		writeContentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reviews)
+}
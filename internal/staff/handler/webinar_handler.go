@@ -1,11 +1,19 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"go-project/config"
 	"go-project/internal/staff/service"
+	"go-project/pkg/calendar"
+	"go-project/pkg/middleware"
+	"go-project/pkg/webinar"
+
+	"github.com/gorilla/mux"
 )
 
 type WebinarHandler struct {
@@ -49,3 +57,98 @@ func (h *WebinarHandler) GetWebinarByID(w http.ResponseWriter, r *http.Request)
 
 	json.NewEncoder(w).Encode(webinar)
 }
+
+// DownloadWebinarCalendar mengunduh jadwal webinar sebagai file .ics
+func (h *WebinarHandler) DownloadWebinarCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	cal, err := h.service.GetWebinarCalendar(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	calendar.Serve(w, "webinar-"+strconv.Itoa(id), cal)
+}
+
+// GetMyRegistrations menampilkan peserta dan daftar tunggu webinar untuk host-nya
+func (h *WebinarHandler) GetMyRegistrations(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	registrations, err := h.service.GetMyRegistrations(middleware.GetUserEmail(r.Context()), id, r.URL.Query().Get("status"))
+	if err != nil {
+		writeHostWebinarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(registrations)
+}
+
+// OpenCheckIn membuat kode check-in mandiri yang dibagikan host selama webinar berlangsung
+func (h *WebinarHandler) OpenCheckIn(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	code, err := h.service.OpenCheckIn(middleware.GetUserEmail(r.Context()), id)
+	if err != nil {
+		writeHostWebinarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"check_in_code": code})
+}
+
+// MarkAttendance dipakai host webinar untuk menandai peserta hadir atau tidak hadir
+func (h *WebinarHandler) MarkAttendance(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	registrationID, _ := strconv.Atoi(mux.Vars(r)["registrationId"])
+
+	var req struct {
+		Attended *bool `json:"attended"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Attended == nil {
+		http.Error(w, "attended is required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.MarkAttendance(middleware.GetUserEmail(r.Context()), id, registrationID, *req.Attended)
+	if err != nil {
+		writeHostWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMyAttendance menampilkan laporan kehadiran webinar untuk host-nya (?format=csv untuk ekspor)
+func (h *WebinarHandler) GetMyAttendance(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	records, err := h.service.GetMyAttendance(middleware.GetUserEmail(r.Context()), id)
+	if err != nil {
+		writeHostWebinarError(w, err)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="attendance-webinar-`+strconv.Itoa(id)+`.csv"`)
+		webinar.WriteAttendanceCSV(w, records, config.Location())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+func writeHostWebinarError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotWebinarHost):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrWebinarNotLive), errors.Is(err, service.ErrAttendanceClosed):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Registration not found", http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	}
+}
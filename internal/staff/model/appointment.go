@@ -4,6 +4,7 @@ import "time"
 
 type Appointment struct {
 	ID            int       `json:"id"`
+	ReferenceCode string    `json:"reference_code,omitempty"`
 	Name          string    `json:"name"`
 	PhoneNumber   string    `json:"phone_number"`
 	Email         string    `json:"email"`
@@ -12,7 +13,42 @@ type Appointment struct {
 	PDFFile       string    `json:"pdf_file"`
 	Img           string    `json:"img"`
 	Time          time.Time `json:"time"`
+	EndTime       time.Time `json:"end_time"`
+	HostID        int       `json:"host_id,omitempty"`
 	Status        string    `json:"status"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
+
+// AppointmentFilter adalah filter dashboard appointment staff
+type AppointmentFilter struct {
+	Date   string // "YYYY-MM-DD", bawaan hari ini jika Range diisi
+	Range  string // "day", "week" atau kosong untuk semua
+	Status string
+}
+
+// AppointmentNote adalah catatan sesi privat yang hanya terlihat oleh staff host
+type AppointmentNote struct {
+	ID            int       `json:"id"`
+	AppointmentID int       `json:"appointment_id"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AppointmentDocument adalah dokumen tindak lanjut yang diunggah staff untuk klien
+type AppointmentDocument struct {
+	ID            int       `json:"id"`
+	AppointmentID int       `json:"appointment_id"`
+	Filename      string    `json:"filename"`
+	ContentType   string    `json:"content_type"`
+	Size          int64     `json:"size"`
+	StorageKey    string    `json:"-"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AppointmentDetail adalah appointment beserta catatan dan dokumennya di dashboard staff
+type AppointmentDetail struct {
+	Appointment Appointment           `json:"appointment"`
+	Notes       []AppointmentNote     `json:"notes"`
+	Documents   []AppointmentDocument `json:"documents"`
+}
@@ -0,0 +1,22 @@
+package model
+
+// Availability adalah template jadwal mingguan staff untuk appointment
+type Availability struct {
+	ID          int    `json:"id"`
+	StaffID     int    `json:"staff_id"`
+	Weekday     int    `json:"weekday"`    // 0 = Minggu ... 6 = Sabtu
+	StartTime   string `json:"start_time"` // Format "HH:MM"
+	EndTime     string `json:"end_time"`   // Format "HH:MM"
+	SlotMinutes int    `json:"slot_minutes"`
+}
+
+// AvailabilityException adalah pengecualian jadwal pada tanggal tertentu.
+// Jika StartTime dan EndTime kosong, staff tidak tersedia sepanjang hari.
+type AvailabilityException struct {
+	ID        int     `json:"id"`
+	StaffID   int     `json:"staff_id"`
+	Date      string  `json:"date"` // Format "YYYY-MM-DD"
+	StartTime *string `json:"start_time,omitempty"`
+	EndTime   *string `json:"end_time,omitempty"`
+	Reason    string  `json:"reason"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// CalendarSession adalah appointment atau webinar yang dipandu seorang staff, untuk feed iCal
+type CalendarSession struct {
+	Kind          string // "appointment" atau "webinar"
+	ID            int
+	Title         string
+	Description   string
+	LinkMeet      string
+	Start         time.Time
+	End           time.Time
+	Status        string
+	AttendeeEmail string
+	UpdatedAt     time.Time
+}
+
+// CalendarFeed berisi URL feed iCal pribadi milik staff
+type CalendarFeed struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// ContentReview menyimpan satu catatan feedback reviewer untuk artikel atau video.
+type ContentReview struct {
+	ID          int       `json:"id"`
+	ContentType string    `json:"content_type"` // "article" atau "video"
+	ContentID   int       `json:"content_id"`
+	ReviewerID  *int      `json:"reviewer_id,omitempty"`
+	Status      string    `json:"status"`
+	Feedback    string    `json:"feedback"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"go-project/internal/staff/model"
+	"go-project/pkg/appointment"
+	"time"
+)
+
+// hostAppointmentColumns adalah kolom appointment yang ditampilkan di dashboard staff
+const hostAppointmentColumns = `id, COALESCE(reference_code, ''), COALESCE(name, ''), COALESCE(phone_number, ''), COALESCE(email, ''),
+              date_of_booking, time, COALESCE(end_time, time), COALESCE(link_meet, ''), COALESCE(img, ''), COALESCE(pdf_file, ''),
+              host_id, status, created_at, updated_at`
+
+func scanHostAppointment(row interface{ Scan(dest ...any) error }) (model.Appointment, error) {
+	var a model.Appointment
+	err := row.Scan(&a.ID, &a.ReferenceCode, &a.Name, &a.PhoneNumber, &a.Email, &a.DateOfBooking, &a.Time, &a.EndTime,
+		&a.LinkMeet, &a.Img, &a.PDFFile, &a.HostID, &a.Status, &a.CreatedAt, &a.UpdatedAt)
+	return a, err
+}
+
+// GetHostAppointments mengambil appointment milik host, opsional dibatasi rentang waktu dan status
+func (r *AppointmentRepository) GetHostAppointments(hostID int, from, to *time.Time, status string) ([]model.Appointment, error) {
+	query := `SELECT ` + hostAppointmentColumns + ` FROM appointments
+              WHERE host_id = $1 AND ($2::timestamptz IS NULL OR time >= $2) AND ($3::timestamptz IS NULL OR time < $3)
+                AND ($4 = '' OR status = $4)
+              ORDER BY time`
+	rows, err := r.DB.Query(query, hostID, from, to, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appointments := []model.Appointment{}
+	for rows.Next() {
+		a, err := scanHostAppointment(rows)
+		if err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, a)
+	}
+	return appointments, rows.Err()
+}
+
+// GetHostAppointment mengambil satu appointment, sql.ErrNoRows jika appointment bukan milik host
+func (r *AppointmentRepository) GetHostAppointment(hostID, id int) (model.Appointment, error) {
+	query := `SELECT ` + hostAppointmentColumns + ` FROM appointments WHERE id = $1 AND host_id = $2`
+	return scanHostAppointment(r.DB.QueryRow(query, id, hostID))
+}
+
+// GetNotes mengambil catatan sesi sebuah appointment
+func (r *AppointmentRepository) GetNotes(appointmentID int) ([]model.AppointmentNote, error) {
+	rows, err := r.DB.Query(`SELECT id, appointment_id, note, created_at FROM appointment_notes
+              WHERE appointment_id = $1 ORDER BY created_at, id`, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []model.AppointmentNote{}
+	for rows.Next() {
+		var n model.AppointmentNote
+		if err := rows.Scan(&n.ID, &n.AppointmentID, &n.Note, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// AddNote menyimpan catatan sesi baru
+func (r *AppointmentRepository) AddNote(staffID int, note *model.AppointmentNote) error {
+	query := `INSERT INTO appointment_notes (appointment_id, staff_id, note) VALUES ($1, $2, $3) RETURNING id, created_at`
+	return r.DB.QueryRow(query, note.AppointmentID, staffID, note.Note).Scan(&note.ID, &note.CreatedAt)
+}
+
+// GetDocuments mengambil dokumen tindak lanjut sebuah appointment
+func (r *AppointmentRepository) GetDocuments(appointmentID int) ([]model.AppointmentDocument, error) {
+	rows, err := r.DB.Query(`SELECT id, appointment_id, filename, content_type, size_bytes, storage_key, created_at
+              FROM appointment_documents WHERE appointment_id = $1 ORDER BY created_at, id`, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []model.AppointmentDocument{}
+	for rows.Next() {
+		var d model.AppointmentDocument
+		if err := rows.Scan(&d.ID, &d.AppointmentID, &d.Filename, &d.ContentType, &d.Size, &d.StorageKey, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	return documents, rows.Err()
+}
+
+// GetDocument mengambil satu dokumen milik appointment tertentu
+func (r *AppointmentRepository) GetDocument(appointmentID, documentID int) (model.AppointmentDocument, error) {
+	var d model.AppointmentDocument
+	err := r.DB.QueryRow(`SELECT id, appointment_id, filename, content_type, size_bytes, storage_key, created_at
+              FROM appointment_documents WHERE id = $1 AND appointment_id = $2`, documentID, appointmentID).
+		Scan(&d.ID, &d.AppointmentID, &d.Filename, &d.ContentType, &d.Size, &d.StorageKey, &d.CreatedAt)
+	return d, err
+}
+
+// AddDocument menyimpan metadata dokumen yang sudah diunggah ke storage
+func (r *AppointmentRepository) AddDocument(staffID int, doc *model.AppointmentDocument) error {
+	query := `INSERT INTO appointment_documents (appointment_id, uploaded_by, filename, content_type, size_bytes, storage_key)
+              VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+	return r.DB.QueryRow(query, doc.AppointmentID, staffID, doc.Filename, doc.ContentType, doc.Size, doc.StorageKey).
+		Scan(&doc.ID, &doc.CreatedAt)
+}
+
+// UpdateStatus memindahkan status appointment sesuai lifecycle dan mencatatnya di timeline
+func (r *AppointmentRepository) UpdateStatus(id int, to, changedBy, note string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var current string
+	if err := tx.QueryRow("SELECT status FROM appointments WHERE id = $1 FOR UPDATE", id).Scan(&current); err != nil {
+		return err
+	}
+	if err := appointment.ValidateTransition(current, to); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE appointments SET status = $1, updated_at = NOW() WHERE id = $2", to, id); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO appointment_status_history (appointment_id, from_status, to_status, changed_by, note)
+              VALUES ($1, $2, $3, $4, NULLIF($5, ''))`, id, current, to, changedBy, note)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -15,8 +15,8 @@ func NewAppointmentRepository(db *sql.DB) *AppointmentRepository {
 
 func (r *AppointmentRepository) CreateAppointment(appointment *model.Appointment) error {
 	query := `INSERT INTO appointments 
-		(name, phone_number, email, date_of_booking, time, status, pdf_file, img, link_meet, created_at, updated_at) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()) RETURNING id`
+		(name, phone_number, email, date_of_booking, time, end_time, status, pdf_file, img, link_meet, created_at, updated_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW()) RETURNING id`
 
 	return r.DB.QueryRow(query,
 		appointment.Name,
@@ -24,38 +24,9 @@ func (r *AppointmentRepository) CreateAppointment(appointment *model.Appointment
 		appointment.Email,
 		appointment.DateOfBooking,
 		appointment.Time,
+		appointment.EndTime,
 		appointment.Status,
 		appointment.PDFFile,
 		appointment.Img,
 		appointment.LinkMeet).Scan(&appointment.ID)
 }
-
-func (r *AppointmentRepository) GetAppointments() ([]model.Appointment, error) {
-	query := `SELECT id, name, email, date_of_booking, time, link_meet, img, pdf_file, status 
-			  FROM appointments WHERE status = 'pending'`
-	rows, err := r.DB.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var appointments []model.Appointment
-	for rows.Next() {
-		var appointment model.Appointment
-		if err := rows.Scan(
-			&appointment.ID,
-			&appointment.Name,
-			&appointment.Email,
-			&appointment.DateOfBooking,
-			&appointment.Time,
-			&appointment.LinkMeet,
-			&appointment.Img,
-			&appointment.PDFFile,
-			&appointment.Status,
-		); err != nil {
-			return nil, err
-		}
-		appointments = append(appointments, appointment)
-	}
-	return appointments, nil
-}
@@ -1,17 +1,37 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	adminmodel "go-project/internal/admin/model"
 	"go-project/internal/staff/model"
+	"go-project/pkg/notify"
+)
+
+var (
+	// ErrNotEditable dikembalikan jika konten tidak ditemukan, bukan milik penulis, atau statusnya bukan
+	// pending/changes requested
+	ErrNotEditable = errors.New("only own pending or changes requested content can be edited")
+	// ErrNotWithdrawable dikembalikan jika konten tidak ditemukan, bukan milik penulis, atau sudah ditarik/ditolak
+	ErrNotWithdrawable = errors.New("only own content under review or published can be withdrawn")
 )
 
 type ArticleRepository struct {
 	DB *sql.DB
 }
 
-func (r *ArticleRepository) SaveArticle(article model.Article) error {
+// SaveArticle menyimpan artikel baru dan mencatat event notifikasinya dalam satu transaksi,
+// sehingga notifikasi hanya terkirim untuk artikel yang benar-benar tersimpan
+func (r *ArticleRepository) SaveArticle(article model.Article, event notify.Event) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         INSERT INTO articles (
             category_id, title, slug, tags, content, message, thumbnail, alt_thumbnail, banner, 
@@ -21,53 +41,58 @@ func (r *ArticleRepository) SaveArticle(article model.Article) error {
             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
         )
     `
-	_, err := r.DB.Exec(query, article.CategoryID, article.Title, article.Slug, article.Tags, article.Content,
+	_, err = tx.Exec(query, article.CategoryID, article.Title, article.Slug, article.Tags, article.Content,
 		article.Message, article.Thumbnail, article.AltThumbnail, article.Banner, article.AltBanner, article.Poster,
 		article.AltPoster, article.LinkVideo, article.Status, article.MetaTitle, article.MetaDescription, article.AuthorID,
 		article.CreatedAt, article.UpdatedAt)
-	return err
+	if err != nil {
+		return err
+	}
+	if err := notify.Enqueue(context.Background(), tx, event); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
+// GetArticleByID mengambil artikel berdasarkan ID. Jika artikel tidak ditemukan, mengembalikan error.
 func (r *ArticleRepository) GetArticleByID(id int) (*model.Article, error) {
-	query := `SELECT * FROM articles WHERE id = $1`
-	row := r.DB.QueryRow(query, id)
-	var article model.Article
-
-	// Handle tags as JSON
-	var tags json.RawMessage
-	err := row.Scan(
-		&article.ID, &article.CategoryID, &article.Title, &article.Slug,
-		&tags, // Scanning into a json.RawMessage
-		&article.Content, &article.Message, &article.Thumbnail,
-		&article.AltThumbnail, &article.Banner, &article.AltBanner,
-		&article.Poster, &article.AltPoster, &article.LinkVideo,
-		&article.Status, &article.MetaTitle, &article.MetaDescription,
-		&article.AuthorID, &article.CreatedAt, &article.UpdatedAt,
-	)
+	query := `SELECT ` + adminmodel.ArticleColumns + ` FROM articles WHERE id = $1`
+	article, err := adminmodel.ScanArticle(r.DB.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("article with id %d not found", id)
 		}
 		return nil, fmt.Errorf("database error: %v", err)
 	}
+	return &article, nil
+}
+
+// GetAllArticles mengambil semua artikel dari database
+func (r *ArticleRepository) GetAllArticles() ([]model.Article, error) {
+	query := `SELECT ` + adminmodel.ArticleColumns + ` FROM articles`
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	// If tags are valid JSON, unmarshal it into a string slice
-	if len(tags) > 0 {
-		var tagList []string
-		if err := json.Unmarshal(tags, &tagList); err != nil {
-			return nil, fmt.Errorf("error unmarshaling tags JSON: %v", err)
+	var articles []model.Article
+	for rows.Next() {
+		article, err := adminmodel.ScanArticle(rows)
+		if err != nil {
+			return nil, err
 		}
-		article.Tags = tagList
-	} else {
-		article.Tags = []string{}
+		articles = append(articles, article)
 	}
 
-	return &article, nil
+	return articles, rows.Err()
 }
 
-func (r *ArticleRepository) GetAllArticles() ([]model.Article, error) {
-	query := `SELECT id, title, content, category_id, status, author_id, meta_title, meta_description, created_at, updated_at FROM articles`
-	rows, err := r.DB.Query(query)
+// GetArticlesByAuthor mengambil artikel yang ditulis oleh satu penulis, opsional difilter berdasarkan status
+func (r *ArticleRepository) GetArticlesByAuthor(authorID int, status string) ([]model.Article, error) {
+	query := `SELECT id, title, content, category_id, status, author_id, meta_title, meta_description, created_at, updated_at
+		FROM articles WHERE author_id = $1 AND ($2 = '' OR status = $2) ORDER BY updated_at DESC`
+	rows, err := r.DB.Query(query, authorID, status)
 	if err != nil {
 		return nil, err
 	}
@@ -93,5 +118,60 @@ func (r *ArticleRepository) GetAllArticles() ([]model.Article, error) {
 		articles = append(articles, article)
 	}
 
-	return articles, nil
+	return articles, rows.Err()
+}
+
+// UpdateOwnArticle memperbarui artikel milik penulis selama masih menunggu review atau diminta revisi.
+// Artikel kembali ke status "pending approval" agar bisa direview ulang oleh admin.
+func (r *ArticleRepository) UpdateOwnArticle(article model.Article) error {
+	tags, err := json.Marshal(article.Tags)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE articles SET
+			category_id = $1, title = $2, slug = $3, tags = $4, content = $5, message = $6, thumbnail = $7,
+			alt_thumbnail = $8, banner = $9, alt_banner = $10, poster = $11, alt_poster = $12, link_video = $13,
+			meta_title = $14, meta_description = $15, status = 'pending approval', updated_at = NOW()
+		WHERE id = $16 AND author_id = $17 AND status IN ('pending approval', 'changes requested')
+	`
+	result, err := r.DB.Exec(query, article.CategoryID, article.Title, article.Slug, tags, article.Content,
+		article.Message, article.Thumbnail, article.AltThumbnail, article.Banner, article.AltBanner, article.Poster,
+		article.AltPoster, article.LinkVideo, article.MetaTitle, article.MetaDescription, article.ID, article.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no editable article found: %w", ErrNotEditable)
+	}
+	return nil
+}
+
+// WithdrawArticle menarik artikel milik penulis dari proses review atau publikasi
+func (r *ArticleRepository) WithdrawArticle(id, authorID int) error {
+	query := `UPDATE articles SET status = 'withdrawn', updated_at = NOW()
+		WHERE id = $1 AND author_id = $2 AND status IN ('pending approval', 'changes requested', 'approval')`
+	result, err := r.DB.Exec(query, id, authorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no article found to withdraw: %w", ErrNotWithdrawable)
+	}
+	return nil
+}
+
+// GetArticleReviews mengambil riwayat feedback reviewer untuk artikel milik penulis
+func (r *ArticleRepository) GetArticleReviews(id, authorID int) ([]model.ContentReview, error) {
+	query := `SELECT cr.id, cr.content_type, cr.content_id, cr.reviewer_id, cr.status, COALESCE(cr.feedback, ''), cr.created_at
+		FROM content_reviews cr
+		JOIN articles a ON a.id = cr.content_id
+		WHERE cr.content_type = 'article' AND cr.content_id = $1 AND a.author_id = $2
+		ORDER BY cr.created_at DESC`
+	return queryContentReviews(r.DB, query, id, authorID)
 }
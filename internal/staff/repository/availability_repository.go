@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/staff/model"
+)
+
+type AvailabilityRepository struct {
+	DB *sql.DB
+}
+
+func NewAvailabilityRepository(db *sql.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{DB: db}
+}
+
+// GetWeeklyAvailability mengambil template jadwal mingguan milik staff
+func (r *AvailabilityRepository) GetWeeklyAvailability(staffID int) ([]model.Availability, error) {
+	query := `SELECT id, staff_id, weekday, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), slot_minutes
+		FROM staff_availability WHERE staff_id = $1 ORDER BY weekday, start_time`
+	rows, err := r.DB.Query(query, staffID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var availability []model.Availability
+	for rows.Next() {
+		var a model.Availability
+		if err := rows.Scan(&a.ID, &a.StaffID, &a.Weekday, &a.StartTime, &a.EndTime, &a.SlotMinutes); err != nil {
+			return nil, err
+		}
+		availability = append(availability, a)
+	}
+	return availability, rows.Err()
+}
+
+// ReplaceWeeklyAvailability mengganti seluruh template jadwal mingguan staff dalam satu transaksi
+func (r *AvailabilityRepository) ReplaceWeeklyAvailability(staffID int, availability []model.Availability) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM staff_availability WHERE staff_id = $1`, staffID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO staff_availability (staff_id, weekday, start_time, end_time, slot_minutes, created_at, updated_at)
+		VALUES ($1, $2, $3::time, $4::time, $5, NOW(), NOW())`
+	for _, a := range availability {
+		if _, err := tx.Exec(query, staffID, a.Weekday, a.StartTime, a.EndTime, a.SlotMinutes); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetExceptions mengambil pengecualian jadwal staff mulai tanggal tertentu
+func (r *AvailabilityRepository) GetExceptions(staffID int, from string) ([]model.AvailabilityException, error) {
+	query := `SELECT id, staff_id, to_char(date, 'YYYY-MM-DD'), to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), COALESCE(reason, '')
+		FROM availability_exceptions WHERE staff_id = $1 AND date >= $2::date ORDER BY date, start_time`
+	rows, err := r.DB.Query(query, staffID, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exceptions []model.AvailabilityException
+	for rows.Next() {
+		var e model.AvailabilityException
+		var start, end sql.NullString
+		if err := rows.Scan(&e.ID, &e.StaffID, &e.Date, &start, &end, &e.Reason); err != nil {
+			return nil, err
+		}
+		if start.Valid {
+			e.StartTime = &start.String
+		}
+		if end.Valid {
+			e.EndTime = &end.String
+		}
+		exceptions = append(exceptions, e)
+	}
+	return exceptions, rows.Err()
+}
+
+// CreateException menyimpan pengecualian jadwal baru
+func (r *AvailabilityRepository) CreateException(exception *model.AvailabilityException) error {
+	query := `INSERT INTO availability_exceptions (staff_id, date, start_time, end_time, reason, created_at)
+		VALUES ($1, $2::date, $3::time, $4::time, $5, NOW()) RETURNING id`
+	return r.DB.QueryRow(query, exception.StaffID, exception.Date, exception.StartTime, exception.EndTime, exception.Reason).
+		Scan(&exception.ID)
+}
+
+// DeleteException menghapus pengecualian jadwal milik staff
+func (r *AvailabilityRepository) DeleteException(id, staffID int) error {
+	result, err := r.DB.Exec(`DELETE FROM availability_exceptions WHERE id = $1 AND staff_id = $2`, id, staffID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return errors.New("availability exception not found")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+	"go-project/internal/staff/model"
+	"time"
+)
+
+type CalendarRepository struct {
+	DB *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{DB: db}
+}
+
+// GetCalendarToken mengambil token feed iCal milik staff, string kosong jika belum dibuat
+func (r *CalendarRepository) GetCalendarToken(staffID int) (string, error) {
+	var token sql.NullString
+	err := r.DB.QueryRow(`SELECT calendar_token FROM users WHERE id = $1`, staffID).Scan(&token)
+	return token.String, err
+}
+
+// SetCalendarToken menyimpan token feed iCal baru sehingga URL feed lama tidak berlaku lagi
+func (r *CalendarRepository) SetCalendarToken(staffID int, token string) error {
+	_, err := r.DB.Exec(`UPDATE users SET calendar_token = $1, updated_at = NOW() WHERE id = $2`, token, staffID)
+	return err
+}
+
+// GetStaffByCalendarToken mencari staff pemilik token feed iCal
+func (r *CalendarRepository) GetStaffByCalendarToken(token string) (model.User, error) {
+	var user model.User
+	query := `SELECT id, COALESCE(name, ''), email FROM users WHERE calendar_token = $1 AND role = 'staff'`
+	err := r.DB.QueryRow(query, token).Scan(&user.ID, &user.Name, &user.Email)
+	return user, err
+}
+
+// GetHostSessions mengambil appointment dan webinar yang dipandu staff sejak waktu tertentu
+func (r *CalendarRepository) GetHostSessions(staffID int, since time.Time) ([]model.CalendarSession, error) {
+	query := `SELECT 'appointment', id, 'Appointment ' || COALESCE(name, ''),
+              TRIM('Klien: ' || COALESCE(name, '') || ' ' || COALESCE(phone_number, '')), COALESCE(link_meet, ''),
+              time, COALESCE(end_time, time + interval '60 minutes'), status, COALESCE(email, ''), updated_at
+              FROM appointments
+              WHERE host_id = $1 AND time >= $2
+              UNION ALL
+              SELECT 'webinar', id, COALESCE(title, ''), COALESCE(description, ''), COALESCE(link_meet, ''),
+              start_time, end_time,
+              CASE status WHEN 'cancelled' THEN 'cancelled' WHEN 'draft' THEN 'pending' ELSE 'confirmed' END, '', updated_at
+              FROM webinars
+              WHERE host_id = $1 AND start_time IS NOT NULL AND end_time IS NOT NULL AND start_time >= $2
+              ORDER BY 6`
+	rows, err := r.DB.Query(query, staffID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []model.CalendarSession
+	for rows.Next() {
+		var s model.CalendarSession
+		if err := rows.Scan(&s.Kind, &s.ID, &s.Title, &s.Description, &s.LinkMeet, &s.Start, &s.End,
+			&s.Status, &s.AttendeeEmail, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
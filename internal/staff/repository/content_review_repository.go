@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"database/sql"
+	"go-project/internal/staff/model"
+)
+
+// queryContentReviews menjalankan query ke tabel content_reviews dan membaca setiap barisnya
+func queryContentReviews(db *sql.DB, query string, args ...interface{}) ([]model.ContentReview, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []model.ContentReview
+	for rows.Next() {
+		var review model.ContentReview
+		var reviewerID sql.NullInt32
+		if err := rows.Scan(&review.ID, &review.ContentType, &review.ContentID, &reviewerID,
+			&review.Status, &review.Feedback, &review.CreatedAt); err != nil {
+			return nil, err
+		}
+		if reviewerID.Valid {
+			id := int(reviewerID.Int32)
+			review.ReviewerID = &id
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
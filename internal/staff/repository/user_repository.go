@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"go-project/internal/staff/model"
+)
+
+type UserRepository struct {
+	DB *sql.DB
+}
+
+// GetUserByEmail mengambil user berdasarkan email yang tersimpan di token JWT
+func (r *UserRepository) GetUserByEmail(email string) (model.User, error) {
+	var user model.User
+	query := `SELECT id, role, COALESCE(name, ''), email FROM users WHERE email = $1`
+	err := r.DB.QueryRow(query, email).Scan(&user.ID, &user.Role, &user.Name, &user.Email)
+	if err != nil {
+		return user, fmt.Errorf("failed to get user with email %s: %w", email, err)
+	}
+	return user, nil
+}
+
+// GetUserByID mengambil user berdasarkan ID
+func (r *UserRepository) GetUserByID(id int) (model.User, error) {
+	var user model.User
+	query := `SELECT id, role, COALESCE(name, ''), email FROM users WHERE id = $1`
+	err := r.DB.QueryRow(query, id).Scan(&user.ID, &user.Role, &user.Name, &user.Email)
+	if err != nil {
+		return user, fmt.Errorf("failed to get user with id %d: %w", id, err)
+	}
+	return user, nil
+}
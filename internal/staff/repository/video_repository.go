@@ -1,23 +1,39 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"go-project/internal/staff/model"
+	"go-project/pkg/notify"
 )
 
 type VideoRepository struct {
 	DB *sql.DB
 }
 
-func (r *VideoRepository) SaveVideo(video model.Video) error {
+// SaveVideo menyimpan video baru dan mencatat event notifikasinya dalam satu transaksi
+func (r *VideoRepository) SaveVideo(video model.Video, event notify.Event) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
     INSERT INTO videos (
         title, description, link_video, category_id, status, author_id, meta_title, meta_description, created_at, updated_at
     ) VALUES (
         $1, $2, $3, $4, 'pending approval', $5, $6, $7, NOW(), NOW()
     ) RETURNING id`
-	err := r.DB.QueryRow(query, video.Title, video.Description, video.LinkVideo, video.CategoryID, video.AuthorID, video.MetaTitle, video.MetaDescription).Scan(&video.ID)
-	return err
+	err = tx.QueryRow(query, video.Title, video.Description, video.LinkVideo, video.CategoryID, video.AuthorID, video.MetaTitle, video.MetaDescription).Scan(&video.ID)
+	if err != nil {
+		return err
+	}
+	if err := notify.Enqueue(context.Background(), tx, event); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *VideoRepository) GetVideoByID(id int) (*model.Video, error) {
@@ -76,3 +92,82 @@ func (r *VideoRepository) GetAllVideos() ([]model.Video, error) {
 
 	return videos, nil
 }
+
+// GetVideosByAuthor mengambil video yang diunggah oleh satu penulis, opsional difilter berdasarkan status
+func (r *VideoRepository) GetVideosByAuthor(authorID int, status string) ([]model.Video, error) {
+	query := `SELECT id, title, description, link_video, category_id, status, author_id, meta_title, meta_description, created_at, updated_at
+		FROM videos WHERE author_id = $1 AND ($2 = '' OR status = $2) ORDER BY updated_at DESC`
+	rows, err := r.DB.Query(query, authorID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var videos []model.Video
+	for rows.Next() {
+		var video model.Video
+		if err := rows.Scan(
+			&video.ID,
+			&video.Title,
+			&video.Description,
+			&video.LinkVideo,
+			&video.CategoryID,
+			&video.Status,
+			&video.AuthorID,
+			&video.MetaTitle,
+			&video.MetaDescription,
+			&video.CreatedAt,
+			&video.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		videos = append(videos, video)
+	}
+
+	return videos, rows.Err()
+}
+
+// UpdateOwnVideo memperbarui video milik penulis selama masih menunggu review atau diminta revisi.
+// Video kembali ke status "pending approval" agar bisa direview ulang oleh admin.
+func (r *VideoRepository) UpdateOwnVideo(video model.Video) error {
+	query := `UPDATE videos SET title = $1, description = $2, link_video = $3, category_id = $4, meta_title = $5,
+		meta_description = $6, status = 'pending approval', updated_at = NOW()
+		WHERE id = $7 AND author_id = $8 AND status IN ('pending approval', 'changes requested')`
+	result, err := r.DB.Exec(query, video.Title, video.Description, video.LinkVideo, video.CategoryID,
+		video.MetaTitle, video.MetaDescription, video.ID, video.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no editable video found: %w", ErrNotEditable)
+	}
+	return nil
+}
+
+// WithdrawVideo menarik video milik penulis dari proses review atau publikasi
+func (r *VideoRepository) WithdrawVideo(id, authorID int) error {
+	query := `UPDATE videos SET status = 'withdrawn', updated_at = NOW()
+		WHERE id = $1 AND author_id = $2 AND status IN ('pending approval', 'changes requested', 'approval')`
+	result, err := r.DB.Exec(query, id, authorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no video found to withdraw: %w", ErrNotWithdrawable)
+	}
+	return nil
+}
+
+// GetVideoReviews mengambil riwayat feedback reviewer untuk video milik penulis
+func (r *VideoRepository) GetVideoReviews(id, authorID int) ([]model.ContentReview, error) {
+	query := `SELECT cr.id, cr.content_type, cr.content_id, cr.reviewer_id, cr.status, COALESCE(cr.feedback, ''), cr.created_at
+		FROM content_reviews cr
+		JOIN videos v ON v.id = cr.content_id
+		WHERE cr.content_type = 'video' AND cr.content_id = $1 AND v.author_id = $2
+		ORDER BY cr.created_at DESC`
+	return queryContentReviews(r.DB, query, id, authorID)
+}
@@ -4,11 +4,19 @@ import (
 	"database/sql"
 	"errors"
 	"go-project/internal/staff/model"
+	"go-project/pkg/webinar"
+	"time"
 )
 
 type WebinarRepository interface {
 	GetAllWebinars() ([]model.Webinar, error)
 	GetWebinarByID(id int) (*model.Webinar, error)
+	GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error)
+	SetCheckInCode(webinarID int, code string) error
+	MarkAttendance(webinarID, registrationID, staffID int) error
+	UnmarkAttendance(webinarID, registrationID int) error
+	GetAttendance(webinarID int) ([]webinar.Attendance, error)
+	IssueCertificates(webinarID int) (int, error)
 }
 
 type webinarRepository struct {
@@ -20,7 +28,7 @@ func NewWebinarRepository(db *sql.DB) WebinarRepository {
 }
 
 func (r *webinarRepository) GetAllWebinars() ([]model.Webinar, error) {
-	query := `SELECT id, title, description, link_meet, host_id, created_at, updated_at FROM webinars`
+	query := `SELECT id, title, description, link_meet, host_id, start_time, end_time, COALESCE(capacity, 0), registration_deadline, status, created_at, updated_at FROM webinars`
 	rows, err := r.db.Query(query)
 	if err != nil {
 		return nil, errors.New("failed to fetch webinars: " + err.Error())
@@ -30,9 +38,11 @@ func (r *webinarRepository) GetAllWebinars() ([]model.Webinar, error) {
 	var webinars []model.Webinar
 	for rows.Next() {
 		var webinar model.Webinar
-		if err := rows.Scan(&webinar.ID, &webinar.Title, &webinar.Description, &webinar.LinkMeet, &webinar.HostID, &webinar.CreatedAt, &webinar.UpdatedAt); err != nil {
+		var start, end, deadline sql.NullTime
+		if err := rows.Scan(&webinar.ID, &webinar.Title, &webinar.Description, &webinar.LinkMeet, &webinar.HostID, &start, &end, &webinar.Capacity, &deadline, &webinar.Status, &webinar.CreatedAt, &webinar.UpdatedAt); err != nil {
 			return nil, errors.New("failed to scan webinar: " + err.Error())
 		}
+		webinar.StartTime, webinar.EndTime, webinar.RegistrationDeadline = timePtr(start), timePtr(end), timePtr(deadline)
 		webinars = append(webinars, webinar)
 	}
 
@@ -40,13 +50,84 @@ func (r *webinarRepository) GetAllWebinars() ([]model.Webinar, error) {
 }
 
 func (r *webinarRepository) GetWebinarByID(id int) (*model.Webinar, error) {
-	query := `SELECT id, title, description, link_meet, host_id, created_at, updated_at FROM webinars WHERE id = $1`
+	query := `SELECT id, title, description, link_meet, host_id, start_time, end_time, COALESCE(capacity, 0), registration_deadline, status, created_at, updated_at FROM webinars WHERE id = $1`
 	var webinar model.Webinar
-	err := r.db.QueryRow(query, id).Scan(&webinar.ID, &webinar.Title, &webinar.Description, &webinar.LinkMeet, &webinar.HostID, &webinar.CreatedAt, &webinar.UpdatedAt)
+	var start, end, deadline sql.NullTime
+	err := r.db.QueryRow(query, id).Scan(&webinar.ID, &webinar.Title, &webinar.Description, &webinar.LinkMeet, &webinar.HostID, &start, &end, &webinar.Capacity, &deadline, &webinar.Status, &webinar.CreatedAt, &webinar.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, errors.New("webinar not found")
 	} else if err != nil {
 		return nil, errors.New("failed to fetch webinar: " + err.Error())
 	}
+	webinar.StartTime, webinar.EndTime, webinar.RegistrationDeadline = timePtr(start), timePtr(end), timePtr(deadline)
 	return &webinar, nil
 }
+
+// GetRegistrations mengambil daftar pendaftar webinar, opsional difilter berdasarkan status
+func (r *webinarRepository) GetRegistrations(webinarID int, status string) ([]model.WebinarRegistration, error) {
+	query := `SELECT id, name, email, COALESCE(phone_number, ''), status, created_at
+			  FROM webinar_registrations WHERE webinar_id = $1 AND ($2 = '' OR status = $2)
+			  ORDER BY created_at, id`
+	rows, err := r.db.Query(query, webinarID, status)
+	if err != nil {
+		return nil, errors.New("failed to fetch registrations: " + err.Error())
+	}
+	defer rows.Close()
+
+	registrations := []model.WebinarRegistration{}
+	for rows.Next() {
+		var reg model.WebinarRegistration
+		if err := rows.Scan(&reg.ID, &reg.Name, &reg.Email, &reg.PhoneNumber, &reg.Status, &reg.CreatedAt); err != nil {
+			return nil, errors.New("failed to scan registration: " + err.Error())
+		}
+		registrations = append(registrations, reg)
+	}
+	return registrations, rows.Err()
+}
+
+// SetCheckInCode menyimpan kode check-in baru; kode lama langsung tidak berlaku
+func (r *webinarRepository) SetCheckInCode(webinarID int, code string) error {
+	_, err := r.db.Exec(`UPDATE webinars SET check_in_code = $1, updated_at = NOW() WHERE id = $2`, code, webinarID)
+	return err
+}
+
+// MarkAttendance mencatat peserta hadir penuh atas konfirmasi host. Check-in mandiri sebelumnya ditimpa.
+// Mengembalikan sql.ErrNoRows jika pendaftaran tidak ada di webinar ini atau masih di daftar tunggu.
+func (r *webinarRepository) MarkAttendance(webinarID, registrationID, staffID int) error {
+	return webinar.CheckIn(r.db, webinarID, registrationID, webinar.CheckInHost, staffID)
+}
+
+// UnmarkAttendance menghapus catatan kehadiran beserta sertifikat yang sudah terbit untuk pendaftaran tersebut
+func (r *webinarRepository) UnmarkAttendance(webinarID, registrationID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM webinar_certificates WHERE registration_id = $1 AND webinar_id = $2`, registrationID, webinarID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM webinar_attendance WHERE registration_id = $1 AND webinar_id = $2`, registrationID, webinarID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetAttendance mengambil laporan kehadiran pendaftar webinar
+func (r *webinarRepository) GetAttendance(webinarID int) ([]webinar.Attendance, error) {
+	return webinar.LoadAttendance(r.db, webinarID)
+}
+
+// IssueCertificates menerbitkan sertifikat untuk peserta yang memenuhi batas kehadiran
+func (r *webinarRepository) IssueCertificates(webinarID int) (int, error) {
+	return webinar.IssueCertificates(r.db, webinarID)
+}
+
+// timePtr mengubah kolom waktu yang boleh NULL menjadi pointer
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -1,23 +1,221 @@
 package service
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go-project/config"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/repository"
+	lifecycle "go-project/pkg/appointment"
+	"go-project/pkg/storage"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
+// MaxDocumentSize adalah ukuran maksimal dokumen tindak lanjut yang bisa diunggah staff
+const MaxDocumentSize = 10 << 20
+
+var (
+	// ErrSessionNotStarted dikembalikan jika sesi ditandai selesai/no-show sebelum waktu mulainya
+	ErrSessionNotStarted = errors.New("session has not started yet")
+	// ErrDocumentTooLarge dikembalikan jika dokumen melebihi MaxDocumentSize
+	ErrDocumentTooLarge = fmt.Errorf("document must not exceed %d MB", MaxDocumentSize>>20)
+	// ErrDocumentType dikembalikan jika tipe dokumen tidak diizinkan
+	ErrDocumentType = errors.New("only PDF, PNG and JPEG documents are allowed")
+)
+
+// documentTypes adalah tipe dokumen yang boleh diunggah, dideteksi dari isi file bukan dari nama file
+var documentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
 type AppointmentService struct {
-	Repo *repository.AppointmentRepository
+	Repo     *repository.AppointmentRepository
+	UserRepo *repository.UserRepository
+	Files    storage.Storage
+	Location *time.Location
 }
 
-func NewAppointmentService(repo *repository.AppointmentRepository) *AppointmentService {
-	return &AppointmentService{Repo: repo}
+func NewAppointmentService(repo *repository.AppointmentRepository, userRepo *repository.UserRepository, files storage.Storage) *AppointmentService {
+	return &AppointmentService{Repo: repo, UserRepo: userRepo, Files: files, Location: config.Location()}
 }
 
 func (s *AppointmentService) CreateAppointment(appointment *model.Appointment) error {
 	appointment.Status = "pending"
+	if appointment.EndTime.IsZero() {
+		appointment.EndTime = appointment.Time.Add(lifecycle.DefaultDuration)
+	}
 	return s.Repo.CreateAppointment(appointment)
 }
 
-func (s *AppointmentService) ListAppointments() ([]model.Appointment, error) {
-	return s.Repo.GetAppointments()
+// ListAppointments mengambil appointment yang dipandu staff yang sedang login, difilter per hari/minggu dan status
+func (s *AppointmentService) ListAppointments(email string, filter model.AppointmentFilter) ([]model.Appointment, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Status != "" && !lifecycle.IsValidStatus(filter.Status) {
+		return nil, fmt.Errorf("invalid status: %s", filter.Status)
+	}
+	from, to, err := s.dateRange(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetHostAppointments(staffID, from, to, filter.Status)
+}
+
+// GetAppointment mengambil appointment milik staff yang sedang login beserta catatan dan dokumennya
+func (s *AppointmentService) GetAppointment(email string, id int) (*model.AppointmentDetail, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	appt, err := s.Repo.GetHostAppointment(staffID, id)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := s.Repo.GetNotes(id)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.Repo.GetDocuments(id)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AppointmentDetail{Appointment: appt, Notes: notes, Documents: documents}, nil
+}
+
+// AddNote menambahkan catatan sesi privat. Catatan tidak pernah ditampilkan ke klien.
+func (s *AppointmentService) AddNote(email string, id int, text string) (*model.AppointmentNote, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("note is required")
+	}
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.GetHostAppointment(staffID, id); err != nil {
+		return nil, err
+	}
+	note := &model.AppointmentNote{AppointmentID: id, Note: text}
+	if err := s.Repo.AddNote(staffID, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// CompleteAppointment menandai sesi sudah selesai
+func (s *AppointmentService) CompleteAppointment(email string, id int, note string) error {
+	return s.closeSession(email, id, lifecycle.StatusCompleted, note)
+}
+
+// MarkNoShow menandai klien tidak hadir pada sesi
+func (s *AppointmentService) MarkNoShow(email string, id int, note string) error {
+	return s.closeSession(email, id, lifecycle.StatusNoShow, note)
+}
+
+func (s *AppointmentService) closeSession(email string, id int, status, note string) error {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	appt, err := s.Repo.GetHostAppointment(staffID, id)
+	if err != nil {
+		return err
+	}
+	if time.Now().Before(appt.Time) {
+		return ErrSessionNotStarted
+	}
+	return s.Repo.UpdateStatus(id, status, "staff:"+email, note)
+}
+
+// UploadDocument menyimpan dokumen tindak lanjut untuk klien. Klien bisa mengunduhnya melalui link kelola appointment.
+func (s *AppointmentService) UploadDocument(email string, id int, filename string, r io.Reader) (*model.AppointmentDocument, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.GetHostAppointment(staffID, id); err != nil {
+		return nil, err
+	}
+
+	// Baca satu byte lebih dari batas untuk mengetahui apakah file terlalu besar
+	data, err := io.ReadAll(io.LimitReader(r, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if !documentTypes[contentType] {
+		return nil, ErrDocumentType
+	}
+
+	key, err := s.Files.Save("appointments", filename, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	doc := &model.AppointmentDocument{
+		AppointmentID: id,
+		Filename:      filepath.Base(filename),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		StorageKey:    key,
+	}
+	if err := s.Repo.AddDocument(staffID, doc); err != nil {
+		// File yang sudah tersimpan dihapus agar tidak tertinggal tanpa baris dokumen
+		if delErr := s.Files.Delete(key); delErr != nil {
+			log.Printf("Failed to delete orphaned document %s: %v", key, delErr)
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+// GetDocument mengambil dokumen dari appointment milik staff yang sedang login
+func (s *AppointmentService) GetDocument(email string, id, documentID int) (model.AppointmentDocument, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return model.AppointmentDocument{}, err
+	}
+	if _, err := s.Repo.GetHostAppointment(staffID, id); err != nil {
+		return model.AppointmentDocument{}, err
+	}
+	return s.Repo.GetDocument(id, documentID)
+}
+
+// dateRange menghitung rentang waktu filter: satu hari, atau satu minggu mulai hari Senin
+func (s *AppointmentService) dateRange(filter model.AppointmentFilter) (*time.Time, *time.Time, error) {
+	if filter.Range == "" && filter.Date == "" {
+		return nil, nil, nil
+	}
+
+	day := time.Now().In(s.Location)
+	if filter.Date != "" {
+		var err error
+		if day, err = time.ParseInLocation("2006-01-02", filter.Date, s.Location); err != nil {
+			return nil, nil, errors.New("invalid date, expected YYYY-MM-DD")
+		}
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.Location)
+
+	switch filter.Range {
+	case "", "day":
+		to := from.AddDate(0, 0, 1)
+		return &from, &to, nil
+	case "week":
+		from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+		to := from.AddDate(0, 0, 7)
+		return &from, &to, nil
+	}
+	return nil, nil, errors.New("range must be day or week")
 }
@@ -4,47 +4,55 @@ import (
 	"fmt"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/repository"
-	"go-project/pkg/utils"
+	"go-project/pkg/notify"
 )
 
 type ArticleService struct {
-	Repo             *repository.ArticleRepository
-	NotificationRepo *repository.NotificationRepository // Tambahkan untuk menyimpan notifikasi
+	Repo     *repository.ArticleRepository
+	UserRepo *repository.UserRepository // Untuk mencari penulis dari email di token
 }
 
-func (s *ArticleService) CreateArticle(article model.Article) error {
-	if s.NotificationRepo == nil {
-		return fmt.Errorf("Notification repository is not initialized")
-	}
+// CreateArticle menyimpan artikel baru atas nama staff yang sedang login; author_id dari body diabaikan
+func (s *ArticleService) CreateArticle(email string, article model.Article) error {
 	// Validasi status
 	validStatuses := map[string]bool{
 		"pending approval": true,
 	}
 
 	if !validStatuses[article.Status] {
-		return fmt.Errorf("invalid status: %s", article.Status)
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, article.Status)
 	}
 
-	// Simpan artikel
-	err := s.Repo.SaveArticle(article)
+	authorID, err := resolveStaffID(s.UserRepo, email)
 	if err != nil {
 		return err
 	}
+	article.AuthorID = authorID
 
-	// Kirim notifikasi WhatsApp dan simpan ke database
-	message := fmt.Sprintf("Artikel baru '%s' telah diunggah!", article.Title)
-	err = utils.SendWhatsAppNotification("whatsapp:+6285707202183", message)
+	vars, err := notify.Vars(notify.ContentSubmittedVars{Title: article.Title, AuthorName: s.authorName(article.AuthorID)})
 	if err != nil {
-		return fmt.Errorf("failed to send WhatsApp notification: %w", err)
+		return err
 	}
 
-	notification := model.Notification{
-		UserID:  article.AuthorID,
+	// Simpan artikel; notifikasi untuk reviewer dicatat di outbox dan dikirim oleh worker
+	return s.Repo.SaveArticle(article, notify.Event{
+		Name:    notify.EventArticleSubmitted,
 		Type:    "article",
-		Message: message,
-		Status:  "unread",
+		Vars:    vars,
+		ActorID: article.AuthorID,
+	})
+}
+
+// authorName mengambil nama penulis untuk pesan notifikasi; kosong jika tidak ditemukan
+func (s *ArticleService) authorName(id int) string {
+	if s.UserRepo == nil || id == 0 {
+		return ""
+	}
+	user, err := s.UserRepo.GetUserByID(id)
+	if err != nil {
+		return ""
 	}
-	return s.NotificationRepo.SaveNotification(notification)
+	return user.Name
 }
 
 func (s *ArticleService) GetArticleByID(id int) (*model.Article, error) {
@@ -54,3 +62,40 @@ func (s *ArticleService) GetArticleByID(id int) (*model.Article, error) {
 func (s *ArticleService) GetAllArticles() ([]model.Article, error) {
 	return s.Repo.GetAllArticles() // Mendapatkan semua artikel
 }
+
+// GetMyArticles mengambil artikel milik staff yang sedang login
+func (s *ArticleService) GetMyArticles(email, status string) ([]model.Article, error) {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetArticlesByAuthor(authorID, status)
+}
+
+// UpdateMyArticle memperbarui artikel milik staff selama masih pending atau diminta revisi
+func (s *ArticleService) UpdateMyArticle(email string, article model.Article) error {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	article.AuthorID = authorID
+	return s.Repo.UpdateOwnArticle(article)
+}
+
+// WithdrawMyArticle menarik artikel milik staff dari proses review atau publikasi
+func (s *ArticleService) WithdrawMyArticle(email string, id int) error {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	return s.Repo.WithdrawArticle(id, authorID)
+}
+
+// GetMyArticleReviews mengambil riwayat feedback reviewer untuk artikel milik staff
+func (s *ArticleService) GetMyArticleReviews(email string, id int) ([]model.ContentReview, error) {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetArticleReviews(id, authorID)
+}
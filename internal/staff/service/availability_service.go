@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-project/internal/staff/model"
+	"go-project/internal/staff/repository"
+	"time"
+)
+
+type AvailabilityService struct {
+	Repo     *repository.AvailabilityRepository
+	UserRepo *repository.UserRepository
+}
+
+func NewAvailabilityService(repo *repository.AvailabilityRepository, userRepo *repository.UserRepository) *AvailabilityService {
+	return &AvailabilityService{Repo: repo, UserRepo: userRepo}
+}
+
+// GetMyAvailability mengambil template jadwal mingguan milik staff yang sedang login
+func (s *AvailabilityService) GetMyAvailability(email string) ([]model.Availability, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetWeeklyAvailability(staffID)
+}
+
+// SetMyAvailability mengganti template jadwal mingguan milik staff yang sedang login
+func (s *AvailabilityService) SetMyAvailability(email string, availability []model.Availability) ([]model.Availability, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range availability {
+		a := &availability[i]
+		if a.Weekday < 0 || a.Weekday > 6 {
+			return nil, fmt.Errorf("invalid weekday: %d", a.Weekday)
+		}
+		if err := validateTimeRange(a.StartTime, a.EndTime); err != nil {
+			return nil, err
+		}
+		if a.SlotMinutes == 0 {
+			a.SlotMinutes = 60
+		}
+		if a.SlotMinutes < 15 || a.SlotMinutes > 240 {
+			return nil, fmt.Errorf("slot_minutes must be between 15 and 240")
+		}
+	}
+
+	if err := s.Repo.ReplaceWeeklyAvailability(staffID, availability); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetWeeklyAvailability(staffID)
+}
+
+// GetMyExceptions mengambil pengecualian jadwal staff mulai hari ini
+func (s *AvailabilityService) GetMyExceptions(email string) ([]model.AvailabilityException, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetExceptions(staffID, time.Now().Format("2006-01-02"))
+}
+
+// AddMyException menambahkan hari libur atau jam khusus untuk staff yang sedang login
+func (s *AvailabilityService) AddMyException(email string, exception model.AvailabilityException) (*model.AvailabilityException, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := time.Parse("2006-01-02", exception.Date); err != nil {
+		return nil, fmt.Errorf("invalid date, expected YYYY-MM-DD")
+	}
+	if (exception.StartTime == nil) != (exception.EndTime == nil) {
+		return nil, errors.New("start_time and end_time must be provided together")
+	}
+	if exception.StartTime != nil {
+		if err := validateTimeRange(*exception.StartTime, *exception.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
+	exception.StaffID = staffID
+	if err := s.Repo.CreateException(&exception); err != nil {
+		return nil, err
+	}
+	return &exception, nil
+}
+
+// DeleteMyException menghapus pengecualian jadwal milik staff yang sedang login
+func (s *AvailabilityService) DeleteMyException(email string, id int) error {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	return s.Repo.DeleteException(id, staffID)
+}
+
+func validateTimeRange(start, end string) error {
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return fmt.Errorf("invalid start_time %q, expected HH:MM", start)
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return fmt.Errorf("invalid end_time %q, expected HH:MM", end)
+	}
+	if !startTime.Before(endTime) {
+		return errors.New("start_time must be before end_time")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"go-project/config"
+	"go-project/internal/staff/model"
+	"go-project/internal/staff/repository"
+	"go-project/pkg/calendar"
+	"time"
+)
+
+// feedHistory adalah seberapa jauh ke belakang sesi lama tetap ditampilkan di feed iCal
+const feedHistory = 30 * 24 * time.Hour
+
+type CalendarService struct {
+	Repo     *repository.CalendarRepository
+	UserRepo *repository.UserRepository
+}
+
+func NewCalendarService(repo *repository.CalendarRepository, userRepo *repository.UserRepository) *CalendarService {
+	return &CalendarService{Repo: repo, UserRepo: userRepo}
+}
+
+// GetMyFeed mengembalikan URL feed iCal milik staff yang sedang login, token dibuat saat pertama kali diminta
+func (s *CalendarService) GetMyFeed(email string) (*model.CalendarFeed, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.Repo.GetCalendarToken(staffID)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return s.ResetMyFeed(email)
+	}
+	return &model.CalendarFeed{URL: feedURL(token)}, nil
+}
+
+// ResetMyFeed membuat token feed baru sehingga URL lama yang mungkin bocor tidak bisa dipakai
+func (s *CalendarService) ResetMyFeed(email string) (*model.CalendarFeed, error) {
+	staffID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(raw)
+	if err := s.Repo.SetCalendarToken(staffID, token); err != nil {
+		return nil, err
+	}
+	return &model.CalendarFeed{URL: feedURL(token)}, nil
+}
+
+// GetFeed membuat kalender berisi appointment dan webinar yang dipandu pemilik token
+func (s *CalendarService) GetFeed(token string) (calendar.Calendar, error) {
+	if token == "" {
+		return calendar.Calendar{}, errors.New("missing calendar token")
+	}
+	staff, err := s.Repo.GetStaffByCalendarToken(token)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	sessions, err := s.Repo.GetHostSessions(staff.ID, time.Now().Add(-feedHistory))
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+
+	cal := calendar.Calendar{Name: "Jadwal " + staff.Name}
+	for _, session := range sessions {
+		event := calendar.Event{
+			Summary:     session.Title,
+			Description: session.Description,
+			Location:    session.LinkMeet,
+			URL:         session.LinkMeet,
+			Start:       session.Start,
+			End:         session.End,
+			Status:      calendar.AppointmentStatus(session.Status),
+			Organizer:   staff.Email,
+			Updated:     session.UpdatedAt,
+		}
+		if session.Kind == "webinar" {
+			event.UID = calendar.WebinarUID(session.ID)
+		} else {
+			event.UID = calendar.AppointmentUID(session.ID)
+			if session.AttendeeEmail != "" {
+				event.Attendees = []string{session.AttendeeEmail}
+			}
+		}
+		cal.Events = append(cal.Events, event)
+	}
+	return cal, nil
+}
+
+func feedURL(token string) string {
+	return config.BaseURL() + "/staff/calendar/" + token + ".ics"
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/staff/repository"
+)
+
+var (
+	// ErrNotStaff dikembalikan jika user yang sedang login bukan staff
+	ErrNotStaff = errors.New("only staff can perform this action")
+	// ErrInvalidStatus dikembalikan jika status konten baru tidak diizinkan
+	ErrInvalidStatus = errors.New("invalid status")
+)
+
+// resolveStaffID mencari ID staff yang sedang login berdasarkan email di token JWT
+func resolveStaffID(users *repository.UserRepository, email string) (int, error) {
+	if users == nil {
+		return 0, errors.New("user repository is not initialized")
+	}
+	user, err := users.GetUserByEmail(email)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && user.Role != "staff" {
+		return 0, ErrNotStaff
+	}
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
@@ -4,28 +4,24 @@ import (
 	"fmt"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/repository"
-	"go-project/pkg/utils"
+	"go-project/pkg/notify"
 )
 
 type VideoService struct {
-	Repo             *repository.VideoRepository
-	NotificationRepo *repository.NotificationRepository
+	Repo     *repository.VideoRepository
+	UserRepo *repository.UserRepository
 }
 
 // Konstruktor untuk VideoService
-func NewVideoService(repo *repository.VideoRepository, notificationRepo *repository.NotificationRepository) *VideoService {
-	if notificationRepo == nil {
-		// Error handling jika notificationRepo tidak diinisialisasi
-		panic("Notification repository is not initialized")
-	}
-
+func NewVideoService(repo *repository.VideoRepository, userRepo *repository.UserRepository) *VideoService {
 	return &VideoService{
-		Repo:             repo,
-		NotificationRepo: notificationRepo, // Pastikan repository notifikasi diinisialisasi dengan benar
+		Repo:     repo,
+		UserRepo: userRepo,
 	}
 }
 
-func (s *VideoService) CreateVideo(video model.Video) error {
+// CreateVideo menyimpan video baru atas nama staff yang sedang login; author_id dari body diabaikan
+func (s *VideoService) CreateVideo(email string, video model.Video) error {
 	// Validasi status hanya di sini
 	validStatuses := map[string]bool{
 		"pending approval": true,
@@ -33,34 +29,39 @@ func (s *VideoService) CreateVideo(video model.Video) error {
 
 	// Jika status tidak valid, kembalikan error
 	if !validStatuses[video.Status] && video.Status != "" {
-		return fmt.Errorf("invalid status: %s", video.Status)
+		return fmt.Errorf("%w: %s", ErrInvalidStatus, video.Status)
 	}
 
-	// Simpan video
-	err := s.Repo.SaveVideo(video)
+	authorID, err := resolveStaffID(s.UserRepo, email)
 	if err != nil {
 		return err
 	}
+	video.AuthorID = authorID
 
-	// Kirim notifikasi WhatsApp dan simpan ke database
-	message := fmt.Sprintf("Video baru '%s' telah diunggah!", video.Title)
-	err = utils.SendWhatsAppNotification("whatsapp:+6285707202183", message)
+	vars, err := notify.Vars(notify.ContentSubmittedVars{Title: video.Title, AuthorName: s.authorName(video.AuthorID)})
 	if err != nil {
-		return fmt.Errorf("failed to send WhatsApp notification: %w", err)
-	}
-
-	// Pastikan NotificationRepo tidak nil sebelum memanggil SaveNotification
-	if s.NotificationRepo == nil {
-		return fmt.Errorf("Notification repository is not initialized")
+		return err
 	}
 
-	notification := model.Notification{
-		UserID:  video.AuthorID,
+	// Simpan video; notifikasi untuk reviewer dicatat di outbox dan dikirim oleh worker
+	return s.Repo.SaveVideo(video, notify.Event{
+		Name:    notify.EventVideoSubmitted,
 		Type:    "video",
-		Message: message,
-		Status:  "unread",
+		Vars:    vars,
+		ActorID: video.AuthorID,
+	})
+}
+
+// authorName mengambil nama penulis untuk pesan notifikasi; kosong jika tidak ditemukan
+func (s *VideoService) authorName(id int) string {
+	if s.UserRepo == nil || id == 0 {
+		return ""
 	}
-	return s.NotificationRepo.SaveNotification(notification)
+	user, err := s.UserRepo.GetUserByID(id)
+	if err != nil {
+		return ""
+	}
+	return user.Name
 }
 
 func (s *VideoService) GetVideoByID(id int) (*model.Video, error) {
@@ -70,3 +71,40 @@ func (s *VideoService) GetVideoByID(id int) (*model.Video, error) {
 func (s *VideoService) GetAllVideos() ([]model.Video, error) {
 	return s.Repo.GetAllVideos()
 }
+
+// GetMyVideos mengambil video milik staff yang sedang login
+func (s *VideoService) GetMyVideos(email, status string) ([]model.Video, error) {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetVideosByAuthor(authorID, status)
+}
+
+// UpdateMyVideo memperbarui video milik staff selama masih pending atau diminta revisi
+func (s *VideoService) UpdateMyVideo(email string, video model.Video) error {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	video.AuthorID = authorID
+	return s.Repo.UpdateOwnVideo(video)
+}
+
+// WithdrawMyVideo menarik video milik staff dari proses review atau publikasi
+func (s *VideoService) WithdrawMyVideo(email string, id int) error {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return err
+	}
+	return s.Repo.WithdrawVideo(id, authorID)
+}
+
+// GetMyVideoReviews mengambil riwayat feedback reviewer untuk video milik staff
+func (s *VideoService) GetMyVideoReviews(email string, id int) ([]model.ContentReview, error) {
+	authorID, err := resolveStaffID(s.UserRepo, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetVideoReviews(id, authorID)
+}
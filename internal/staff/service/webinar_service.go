@@ -1,21 +1,39 @@
 package service
 
 import (
+	"errors"
 	"go-project/internal/staff/model"
 	"go-project/internal/staff/repository"
+	"go-project/pkg/calendar"
+	"go-project/pkg/webinar"
 )
 
 type WebinarService interface {
 	GetAllWebinars() ([]model.Webinar, error)
 	GetWebinarByID(id int) (*model.Webinar, error)
+	GetWebinarCalendar(id int) (calendar.Calendar, error)
+	GetMyRegistrations(email string, webinarID int, status string) ([]model.WebinarRegistration, error)
+	OpenCheckIn(email string, webinarID int) (string, error)
+	MarkAttendance(email string, webinarID, registrationID int, attended bool) error
+	GetMyAttendance(email string, webinarID int) ([]webinar.Attendance, error)
 }
 
+var (
+	// ErrNotWebinarHost dikembalikan jika staff yang sedang login bukan host webinar
+	ErrNotWebinarHost = errors.New("only the webinar host can manage its attendees")
+	// ErrWebinarNotLive dikembalikan jika kode check-in dibuat saat webinar tidak sedang berlangsung
+	ErrWebinarNotLive = errors.New("check-in is only available while the webinar is live")
+	// ErrAttendanceClosed dikembalikan jika kehadiran ditandai sebelum webinar dimulai atau setelah dibatalkan
+	ErrAttendanceClosed = errors.New("attendance can only be recorded once the webinar is live")
+)
+
 type webinarService struct {
-	repo repository.WebinarRepository
+	repo     repository.WebinarRepository
+	userRepo *repository.UserRepository
 }
 
-func NewWebinarService(repo repository.WebinarRepository) WebinarService {
-	return &webinarService{repo: repo}
+func NewWebinarService(repo repository.WebinarRepository, userRepo *repository.UserRepository) WebinarService {
+	return &webinarService{repo: repo, userRepo: userRepo}
 }
 
 func (s *webinarService) GetAllWebinars() ([]model.Webinar, error) {
@@ -25,3 +43,90 @@ func (s *webinarService) GetAllWebinars() ([]model.Webinar, error) {
 func (s *webinarService) GetWebinarByID(id int) (*model.Webinar, error) {
 	return s.repo.GetWebinarByID(id)
 }
+
+// GetWebinarCalendar membuat file iCalendar untuk webinar yang sudah memiliki jadwal
+func (s *webinarService) GetWebinarCalendar(id int) (calendar.Calendar, error) {
+	w, err := s.repo.GetWebinarByID(id)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	return calendar.WebinarCalendar(calendar.Webinar{
+		ID:          w.ID,
+		Title:       w.Title,
+		Description: w.Description,
+		LinkMeet:    w.LinkMeet,
+		Status:      w.Status,
+		Start:       w.StartTime,
+		End:         w.EndTime,
+	})
+}
+
+// GetMyRegistrations mengambil daftar peserta webinar yang dipandu staff yang sedang login
+func (s *webinarService) GetMyRegistrations(email string, webinarID int, status string) ([]model.WebinarRegistration, error) {
+	if _, _, err := s.hostWebinar(email, webinarID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRegistrations(webinarID, status)
+}
+
+// OpenCheckIn membuat kode check-in baru untuk dibagikan host kepada peserta selama webinar berlangsung
+func (s *webinarService) OpenCheckIn(email string, webinarID int) (string, error) {
+	w, _, err := s.hostWebinar(email, webinarID)
+	if err != nil {
+		return "", err
+	}
+	if w.Status != webinar.StatusLive {
+		return "", ErrWebinarNotLive
+	}
+	code, err := webinar.NewCheckInCode()
+	if err != nil {
+		return "", err
+	}
+	return code, s.repo.SetCheckInCode(webinarID, code)
+}
+
+// MarkAttendance menandai atau membatalkan kehadiran peserta secara manual. Jika webinar sudah selesai,
+// sertifikat untuk peserta yang kini memenuhi syarat langsung diterbitkan.
+func (s *webinarService) MarkAttendance(email string, webinarID, registrationID int, attended bool) error {
+	w, hostID, err := s.hostWebinar(email, webinarID)
+	if err != nil {
+		return err
+	}
+	if w.Status != webinar.StatusLive && w.Status != webinar.StatusEnded {
+		return ErrAttendanceClosed
+	}
+	if !attended {
+		return s.repo.UnmarkAttendance(webinarID, registrationID)
+	}
+	if err := s.repo.MarkAttendance(webinarID, registrationID, hostID); err != nil {
+		return err
+	}
+	if w.Status == webinar.StatusEnded {
+		_, err = s.repo.IssueCertificates(webinarID)
+	}
+	return err
+}
+
+// GetMyAttendance mengambil laporan kehadiran webinar yang dipandu staff yang sedang login
+func (s *webinarService) GetMyAttendance(email string, webinarID int) ([]webinar.Attendance, error) {
+	if _, _, err := s.hostWebinar(email, webinarID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAttendance(webinarID)
+}
+
+// hostWebinar mengambil webinar dan memastikan staff yang sedang login adalah host-nya
+func (s *webinarService) hostWebinar(email string, webinarID int) (*model.Webinar, int, error) {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return nil, 0, err
+	}
+	w, err := s.repo.GetWebinarByID(webinarID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user.Role != "staff" || w.HostID != user.ID {
+		return nil, 0, ErrNotWebinarHost
+	}
+	return w, user.ID, nil
+}
@@ -1,10 +1,21 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
 	"go-project/internal/user/service"
+	"go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"go-project/pkg/middleware"
+	"go-project/pkg/storage"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
 )
 
 type AppointmentHandler struct {
@@ -32,6 +43,7 @@ func (h *AppointmentHandler) CreateAppointment(w http.ResponseWriter, r *http.Re
 		Email:         appointmentRequest.Email,
 		DateOfBooking: appointmentRequest.DateOfBooking,
 		Time:          appointmentRequest.Time,
+		HostID:        appointmentRequest.HostID,
 		Status:        appointmentRequest.Status,
 		PDFFile:       appointmentRequest.PDFFile,
 		Img:           appointmentRequest.Img,
@@ -39,7 +51,12 @@ func (h *AppointmentHandler) CreateAppointment(w http.ResponseWriter, r *http.Re
 
 	// Panggil service untuk membuat appointment
 	if err := h.Service.CreateAppointment(&appointment); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, repository.ErrSlotTaken), errors.Is(err, service.ErrSlotUnavailable):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
@@ -47,3 +64,168 @@ func (h *AppointmentHandler) CreateAppointment(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(appointment)
 }
+
+// ListFreeSlots mengembalikan slot appointment yang masih kosong.
+// Query parameter: from, to (YYYY-MM-DD) dan host_id (opsional).
+func (h *AppointmentHandler) ListFreeSlots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	hostID := 0
+	if value := query.Get("host_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid host_id", http.StatusBadRequest)
+			return
+		}
+		hostID = id
+	}
+
+	slots, err := h.Service.ListFreeSlots(query.Get("from"), query.Get("to"), hostID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(slots)
+}
+
+// ListMyAppointments menampilkan semua appointment milik user yang sedang login
+func (h *AppointmentHandler) ListMyAppointments(w http.ResponseWriter, r *http.Request) {
+	appointments, err := h.Service.ListAppointments(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(appointments)
+}
+
+// GetMyAppointment menampilkan satu appointment milik user yang sedang login berdasarkan kode referensi
+func (h *AppointmentHandler) GetMyAppointment(w http.ResponseWriter, r *http.Request) {
+	appointment, err := h.Service.GetMyAppointment(middleware.GetUserEmail(r.Context()), mux.Vars(r)["reference"])
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(appointment)
+}
+
+// LookupAppointment menampilkan appointment untuk user tanpa login berdasarkan email dan kode referensi
+func (h *AppointmentHandler) LookupAppointment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email         string `json:"email"`
+		ReferenceCode string `json:"reference_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	if req.Email == "" || req.ReferenceCode == "" {
+		http.Error(w, "email and reference_code are required", http.StatusBadRequest)
+		return
+	}
+
+	appointment, err := h.Service.LookupAppointment(req.Email, req.ReferenceCode)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(appointment)
+}
+
+// GetManagedAppointment menampilkan appointment dan timeline status-nya dari link kelola (?token=)
+func (h *AppointmentHandler) GetManagedAppointment(w http.ResponseWriter, r *http.Request) {
+	managed, err := h.Service.GetManagedAppointment(r.URL.Query().Get("token"))
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(managed)
+}
+
+// DownloadManagedCalendar mengunduh appointment sebagai file .ics dari link kelola (?token=)
+func (h *AppointmentHandler) DownloadManagedCalendar(w http.ResponseWriter, r *http.Request) {
+	cal, err := h.Service.GetManagedCalendar(r.URL.Query().Get("token"))
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	calendar.Serve(w, "appointment", cal)
+}
+
+// DownloadManagedDocument mengunduh dokumen tindak lanjut dari staff host melalui link kelola (?token=)
+func (h *AppointmentHandler) DownloadManagedDocument(w http.ResponseWriter, r *http.Request) {
+	documentID, _ := strconv.Atoi(mux.Vars(r)["id"])
+	doc, err := h.Service.GetManagedDocument(r.URL.Query().Get("token"), documentID)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	if err := storage.Serve(w, h.Service.Files, doc.StorageKey, doc.Filename, doc.ContentType); err != nil {
+		writeLifecycleError(w, err)
+	}
+}
+
+// CancelAppointment membatalkan appointment melalui link kelola
+func (h *AppointmentHandler) CancelAppointment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token  string `json:"token"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.CancelByToken(req.Token, req.Reason); err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Appointment cancelled successfully"))
+}
+
+// RescheduleAppointment memindahkan appointment ke slot lain melalui link kelola
+func (h *AppointmentHandler) RescheduleAppointment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string    `json:"token"`
+		Time  time.Time `json:"time"`
+		Note  string    `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Time.IsZero() {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	appt, err := h.Service.RescheduleByToken(req.Token, req.Time, req.Note)
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(appt)
+}
+
+// writeLifecycleError memetakan error lifecycle appointment ke status HTTP yang sesuai
+func writeLifecycleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidManageToken):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, storage.ErrNotFound):
+		http.Error(w, "Appointment not found", http.StatusNotFound)
+	case errors.Is(err, appointment.ErrInvalidTransition), errors.Is(err, appointment.ErrCutoffPassed),
+		errors.Is(err, repository.ErrSlotTaken), errors.Is(err, service.ErrSlotUnavailable):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
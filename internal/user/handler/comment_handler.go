@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"go-project/internal/user/repository"
+	"go-project/internal/user/service"
+	"go-project/pkg/middleware"
+	"go-project/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type CommentHandler struct {
+	Service *service.CommentService
+}
+
+func NewCommentHandler(service *service.CommentService) *CommentHandler {
+	return &CommentHandler{Service: service}
+}
+
+// GetCommentThreads mengembalikan pohon komentar yang sudah disetujui untuk sebuah artikel.
+// Query parameter: max_depth, sort (oldest|newest|top), page, limit.
+func (h *CommentHandler) GetCommentThreads(w http.ResponseWriter, r *http.Request) {
+	articleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	q := service.CommentThreadQuery{
+		ArticleID: articleID,
+		Sort:      query.Get("sort"),
+	}
+	for param, target := range map[string]*int{"max_depth": &q.MaxDepth, "page": &q.Page, "limit": &q.Limit} {
+		if value := query.Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "Invalid "+param, http.StatusBadRequest)
+				return
+			}
+			*target = n
+		}
+	}
+
+	threads, err := h.Service.GetCommentThreads(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(threads)
+}
+
+// CreateComment menyimpan komentar publik untuk sebuah artikel
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	articleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	var req service.NewCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.ArticleID = articleID
+	req.IPAddress = utils.ClientIP(r)
+
+	comment, err := h.Service.CreateComment(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// ReactToComment memberi reaksi (like/helpful) pada komentar; reaksi lama dari pembaca yang sama diganti.
+// Pembaca dikenali dari JWT jika login, selain itu dari IP client.
+func (h *CommentHandler) ReactToComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Type string `json:"type"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	counts, err := h.Service.React(commentID, reader(r), req.Type)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(counts)
+}
+
+// RemoveReaction menghapus reaksi milik pembaca itu sendiri dari komentar
+func (h *CommentHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	counts, err := h.Service.Unreact(commentID, reader(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(counts)
+}
+
+// ReportComment melaporkan komentar kasar atau spam, satu laporan per pembaca per komentar
+func (h *CommentHandler) ReportComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.Service.ReportComment(commentID, reader(r), req.Reason)
+	if errors.Is(err, repository.ErrAlreadyReported) || errors.Is(err, repository.ErrNotReportable) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(report)
+}
+
+// reader mengambil identitas pembaca dari JWT (lewat middleware.OptionalAuth) dan IP client,
+// bukan dari body request yang bisa diisi bebas
+func reader(r *http.Request) service.Reader {
+	return service.Reader{Email: middleware.GetUserEmail(r.Context()), IPAddress: utils.ClientIP(r)}
+}
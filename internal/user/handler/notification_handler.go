@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-project/internal/user/model"
+	"go-project/internal/user/service"
+	"go-project/pkg/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type NotificationHandler struct {
+	Service *service.NotificationService
+}
+
+func NewNotificationHandler(service *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{Service: service}
+}
+
+// ListNotifications menampilkan inbox notifikasi pengguna yang sedang login.
+// Query parameter: page, limit, unread (true untuk hanya yang belum dibaca).
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var q service.NotificationQuery
+	for param, target := range map[string]*int{"page": &q.Page, "limit": &q.Limit} {
+		if value := query.Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "Invalid "+param, http.StatusBadRequest)
+				return
+			}
+			*target = n
+		}
+	}
+	if value := query.Get("unread"); value != "" {
+		unread, err := strconv.ParseBool(value)
+		if err != nil {
+			http.Error(w, "Invalid unread", http.StatusBadRequest)
+			return
+		}
+		q.UnreadOnly = unread
+	}
+
+	page, err := h.Service.ListNotifications(middleware.GetUserEmail(r.Context()), q)
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// CountUnread menampilkan jumlah notifikasi yang belum dibaca
+func (h *NotificationHandler) CountUnread(w http.ResponseWriter, r *http.Request) {
+	count, err := h.Service.CountUnread(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"unread": count})
+}
+
+// MarkRead menandai satu notifikasi sudah dibaca
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.MarkRead(middleware.GetUserEmail(r.Context()), id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notification marked as read"})
+}
+
+// MarkAllRead menandai semua notifikasi pengguna sudah dibaca
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	count, err := h.Service.MarkAllRead(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"message": "All notifications marked as read", "count": count})
+}
+
+// DeleteNotification menghapus satu notifikasi dari inbox
+func (h *NotificationHandler) DeleteNotification(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.DeleteNotification(middleware.GetUserEmail(r.Context()), id); err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Notification deleted"})
+}
+
+// GetPreferences menampilkan tipe notifikasi yang dibisukan per kanal
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	preferences, err := h.Service.GetPreferences(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferences)
+}
+
+// UpdatePreferences membisukan atau menyalakan kembali tipe notifikasi per kanal.
+// Body: [{"type": "article", "channel": "whatsapp", "muted": true}]
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var preferences []model.NotificationPreference
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.Service.UpdatePreferences(middleware.GetUserEmail(r.Context()), preferences)
+	if err != nil {
+		writeNotificationError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// writeNotificationError memetakan error inbox notifikasi ke status HTTP
+func writeNotificationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Notification not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrInvalidPreference):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
+	"go-project/internal/user/service"
+	"go-project/pkg/calendar"
+	"go-project/pkg/certificate"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type WebinarHandler struct {
+	Service *service.WebinarService
+}
+
+func NewWebinarHandler(service *service.WebinarService) *WebinarHandler {
+	return &WebinarHandler{Service: service}
+}
+
+// GetWebinar menampilkan detail webinar beserta kapasitas dan jumlah pendaftar
+func (h *WebinarHandler) GetWebinar(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	webinar, err := h.Service.GetWebinar(id)
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webinar)
+}
+
+// Register mendaftarkan seseorang ke webinar, atau ke daftar tunggu jika kapasitas penuh
+func (h *WebinarHandler) Register(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	var reg model.WebinarRegistration
+	if err := json.NewDecoder(r.Body).Decode(&reg); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.Register(id, &reg); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reg)
+}
+
+// GetRegistration menampilkan status pendaftaran dari token pembatalan (?token=)
+func (h *WebinarHandler) GetRegistration(w http.ResponseWriter, r *http.Request) {
+	reg, err := h.Service.GetRegistration(r.URL.Query().Get("token"))
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reg)
+}
+
+// CancelRegistration membatalkan pendaftaran webinar
+func (h *WebinarHandler) CancelRegistration(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.CancelRegistration(req.Token); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Registration cancelled successfully"))
+}
+
+// DownloadWebinarCalendar mengunduh jadwal webinar sebagai file .ics
+func (h *WebinarHandler) DownloadWebinarCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid webinar ID", http.StatusBadRequest)
+		return
+	}
+
+	cal, err := h.Service.GetWebinarCalendar(id)
+	if err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	calendar.Serve(w, "webinar-"+strconv.Itoa(id), cal)
+}
+
+// CheckIn mencatat kehadiran peserta dengan token pendaftaran dan kode check-in dari host
+func (h *WebinarHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+		Code  string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.CheckIn(req.Token, req.Code); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Checked in successfully"))
+}
+
+// CheckOut mencatat waktu peserta meninggalkan webinar dengan token pendaftaran
+func (h *WebinarHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.CheckOut(req.Token); err != nil {
+		writeWebinarError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Checked out successfully"))
+}
+
+// DownloadMyCertificate mengunduh sertifikat kehadiran milik pendaftar (?token=)
+func (h *WebinarHandler) DownloadMyCertificate(w http.ResponseWriter, r *http.Request) {
+	cert, err := h.Service.GetMyCertificate(r.URL.Query().Get("token"))
+	if err != nil {
+		writeCertificateError(w, err)
+		return
+	}
+	certificate.Serve(w, service.CertificatePDF(cert))
+}
+
+// VerifyCertificate menampilkan data sertifikat sehingga pihak lain bisa memastikan keasliannya
+func (h *WebinarHandler) VerifyCertificate(w http.ResponseWriter, r *http.Request) {
+	cert, err := h.Service.GetCertificate(mux.Vars(r)["code"])
+	if err != nil {
+		writeCertificateError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cert)
+}
+
+// DownloadCertificate mengunduh sertifikat berdasarkan kodenya
+func (h *WebinarHandler) DownloadCertificate(w http.ResponseWriter, r *http.Request) {
+	cert, err := h.Service.GetCertificate(mux.Vars(r)["code"])
+	if err != nil {
+		writeCertificateError(w, err)
+		return
+	}
+	certificate.Serve(w, service.CertificatePDF(cert))
+}
+
+func writeCertificateError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Certificate not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// writeWebinarError memetakan error webinar ke status HTTP yang sesuai
+func writeWebinarError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Webinar not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrWebinarNotScheduled), errors.Is(err, service.ErrRegistrationClosed),
+		errors.Is(err, repository.ErrAlreadyRegistered), errors.Is(err, repository.ErrRegistrationCancelled),
+		errors.Is(err, service.ErrNotAttending), errors.Is(err, service.ErrCheckInClosed), errors.Is(err, service.ErrNotCheckedIn):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrInvalidCheckInCode):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/xml"
+	"go-project/config"
+	"go-project/internal/user/model"
+	"go-project/internal/user/service"
+	"go-project/pkg/utils"
+	"log"
+	"net/http"
+)
+
+// twimlResponse adalah balasan TwiML yang dikirim Twilio ke pengirim sebagai pesan WhatsApp
+type twimlResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Message string   `xml:"Message,omitempty"`
+}
+
+type WhatsAppHandler struct {
+	Service   *service.WhatsAppService
+	AuthToken string // Auth token Twilio untuk memverifikasi X-Twilio-Signature
+	BaseURL   string // Alamat publik yang didaftarkan sebagai webhook di Twilio
+}
+
+func NewWhatsAppHandler(service *service.WhatsAppService) *WhatsAppHandler {
+	return &WhatsAppHandler{Service: service, AuthToken: config.Current().Notifications.Twilio.AuthToken, BaseURL: config.BaseURL()}
+}
+
+// Inbound menerima pesan WhatsApp masuk dari webhook Twilio. Permintaan tanpa tanda tangan Twilio yang
+// valid ditolak. Klien bisa membalas pengingat dengan YA untuk konfirmasi atau BATAL untuk membatalkan.
+func (h *WhatsAppHandler) Inbound(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	// Twilio menandatangani URL persis seperti yang didaftarkan, jadi dipakai alamat publik aplikasi
+	// karena Host di belakang proxy bisa berbeda
+	url := h.BaseURL + r.URL.RequestURI()
+	if !utils.ValidateTwilioSignature(h.AuthToken, url, r.PostForm, r.Header.Get(utils.TwilioSignatureHeader)) {
+		http.Error(w, "Invalid Twilio signature", http.StatusForbidden)
+		return
+	}
+
+	reply, err := h.Service.HandleInbound(model.WhatsAppMessage{
+		MessageSID: r.PostForm.Get("MessageSid"),
+		From:       r.PostForm.Get("From"),
+		To:         r.PostForm.Get("To"),
+		Body:       r.PostForm.Get("Body"),
+	})
+	if err != nil {
+		log.Printf("whatsapp inbound: %v", err)
+		http.Error(w, "Failed to process message", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(twimlResponse{Message: reply})
+}
@@ -3,13 +3,46 @@ package model
 import "time"
 
 type Appointment struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	PhoneNumber   string    `json:"phone_number"`
-	Email         string    `json:"email"`
-	DateOfBooking time.Time `json:"date_of_booking"`
-	Time          time.Time `json:"time"`
-	Status        string    `json:"status"` // Status akan default ke "pending"
-	PDFFile       string    `json:"pdf_file"`
-	Img           string    `json:"img"`
+	ID               int       `json:"id"`
+	ReferenceCode    string    `json:"reference_code"` // Kode booking untuk melacak appointment
+	Name             string    `json:"name"`
+	PhoneNumber      string    `json:"phone_number"`
+	Email            string    `json:"email"`
+	DateOfBooking    time.Time `json:"date_of_booking"`
+	Time             time.Time `json:"time"`                // Waktu mulai slot yang dipilih
+	EndTime          time.Time `json:"end_time"`            // Diisi otomatis dari durasi slot
+	LinkMeet         string    `json:"link_meet,omitempty"` // Hanya diisi admin atau MeetingProvider
+	HostID           int       `json:"host_id"`             // 0 berarti host dipilih otomatis jika strategi aktif
+	HostName         string    `json:"host_name,omitempty"`
+	CategoryID       int       `json:"category_id,omitempty"`
+	AssignmentMethod string    `json:"assignment_method,omitempty"` // "manual" atau nama strategi yang memilih host
+	Status           string    `json:"status"`                      // Status akan default ke "pending"
+	PDFFile          string    `json:"pdf_file"`
+	Img              string    `json:"img"`
+	ManageToken      string    `json:"manage_token,omitempty"` // Token link untuk membatalkan atau menjadwal ulang
+}
+
+// AppointmentStatusChange adalah satu entri pada timeline status appointment
+type AppointmentStatusChange struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AppointmentDocument adalah dokumen tindak lanjut dari staff host yang bisa diunduh klien
+type AppointmentDocument struct {
+	ID          int       `json:"id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ManagedAppointment adalah appointment beserta timeline dan dokumennya, ditampilkan melalui link kelola
+type ManagedAppointment struct {
+	Appointment Appointment               `json:"appointment"`
+	Timeline    []AppointmentStatusChange `json:"timeline"`
+	Documents   []AppointmentDocument     `json:"documents"`
 }
@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+type Comment struct {
+	ID        int       `json:"id"`
+	ArticleID int       `json:"article_id"`
+	Username  string    `json:"username"`
+	Comment   string    `json:"comment"`
+	ParentID  *int      `json:"parent_id,omitempty"`
+	Status    string    `json:"status,omitempty"` // Hanya diisi saat komentar baru dibuat
+	Likes     int       `json:"likes"`
+	Helpful   int       `json:"helpful"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReactionCounts adalah jumlah reaksi untuk satu komentar
+type ReactionCounts struct {
+	CommentID int `json:"comment_id"`
+	Likes     int `json:"likes"`
+	Helpful   int `json:"helpful"`
+}
+
+// CommentReport adalah laporan pembaca terhadap komentar yang kasar atau spam
+type CommentReport struct {
+	ID            int       `json:"id"`
+	CommentID     int       `json:"comment_id"`
+	ReporterEmail string    `json:"reporter_email,omitempty"` // Hanya diisi jika pelapor login
+	Reason        string    `json:"reason"`
+	Hidden        bool      `json:"hidden"` // True jika laporan ini membuat komentar disembunyikan
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CommentNode adalah satu komentar beserta balasannya di dalam thread
+type CommentNode struct {
+	Comment
+	ReplyCount   int            `json:"reply_count"`   // Jumlah balasan langsung
+	TotalReplies int            `json:"total_replies"` // Jumlah seluruh balasan di bawah komentar ini
+	Replies      []*CommentNode `json:"replies"`
+}
+
+// CommentThreadPage adalah satu halaman thread komentar (komentar tingkat atas) untuk sebuah artikel
+type CommentThreadPage struct {
+	ArticleID    int            `json:"article_id"`
+	Page         int            `json:"page"`
+	Limit        int            `json:"limit"`
+	TotalThreads int            `json:"total_threads"`
+	Threads      []*CommentNode `json:"threads"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Notification adalah satu notifikasi in-app milik pengguna
+type Notification struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	Status    string    `json:"status"` // unread atau read
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NotificationPage adalah satu halaman inbox notifikasi
+type NotificationPage struct {
+	Page          int            `json:"page"`
+	Limit         int            `json:"limit"`
+	Total         int            `json:"total"`  // Jumlah notifikasi sesuai filter
+	Unread        int            `json:"unread"` // Jumlah seluruh notifikasi yang belum dibaca
+	Notifications []Notification `json:"notifications"`
+}
+
+// NotificationPreference menentukan apakah suatu tipe notifikasi dibisukan di sebuah kanal
+type NotificationPreference struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+	Muted   bool   `json:"muted"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Slot adalah satu slot appointment yang masih tersedia untuk dibooking
+type Slot struct {
+	HostID   int       `json:"host_id"`
+	HostName string    `json:"host_name"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+// AvailabilityTemplate adalah jadwal mingguan seorang host
+type AvailabilityTemplate struct {
+	HostID      int
+	HostName    string
+	Weekday     int
+	StartTime   string // "HH:MM"
+	EndTime     string // "HH:MM"
+	SlotMinutes int
+}
+
+// AvailabilityException adalah libur atau jam khusus host pada tanggal tertentu
+type AvailabilityException struct {
+	HostID    int
+	Date      string  // "YYYY-MM-DD"
+	StartTime *string // nil berarti libur sepanjang hari
+	EndTime   *string
+}
+
+// BookedSlot adalah rentang waktu host yang sudah terisi appointment aktif
+type BookedSlot struct {
+	HostID int
+	Start  time.Time
+	End    time.Time
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type Webinar struct {
+	ID                   int        `json:"id"`
+	Title                string     `json:"title"`
+	Description          string     `json:"description"`
+	LinkMeet             string     `json:"link_meet"`
+	HostID               int        `json:"host_id"`
+	StartTime            *time.Time `json:"start_time"`
+	EndTime              *time.Time `json:"end_time"`
+	Capacity             int        `json:"capacity,omitempty"` // 0 berarti tidak dibatasi
+	RegistrationDeadline *time.Time `json:"registration_deadline,omitempty"`
+	RegisteredCount      int        `json:"registered_count"`
+	Status               string     `json:"status"`                  // draft, scheduled, live, ended atau cancelled
+	RecordingURL         string     `json:"recording_url,omitempty"` // Link video rekaman setelah webinar selesai
+}
+
+// WebinarRegistration adalah pendaftaran seseorang ke webinar
+type WebinarRegistration struct {
+	ID               int       `json:"id"`
+	WebinarID        int       `json:"webinar_id"`
+	Name             string    `json:"name"`
+	Email            string    `json:"email"`
+	PhoneNumber      string    `json:"phone_number,omitempty"`
+	Status           string    `json:"status"`                      // "registered", "waitlisted" atau "cancelled"
+	WaitlistPosition int       `json:"waitlist_position,omitempty"` // Urutan di daftar tunggu, diisi jika status waitlisted
+	CancelToken      string    `json:"cancel_token,omitempty"`      // Token untuk melihat atau membatalkan pendaftaran
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Certificate adalah sertifikat kehadiran webinar yang bisa diverifikasi lewat kodenya
+type Certificate struct {
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	WebinarID       int       `json:"webinar_id"`
+	WebinarTitle    string    `json:"webinar_title"`
+	HostName        string    `json:"host_name,omitempty"`
+	WebinarDate     time.Time `json:"webinar_date"`
+	AttendedPercent int       `json:"attended_percent"`
+	IssuedAt        time.Time `json:"issued_at"`
+	VerifyURL       string    `json:"verify_url"`
+}
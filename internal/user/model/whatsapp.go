@@ -0,0 +1,14 @@
+package model
+
+// WhatsAppMessage adalah pesan WhatsApp masuk dari webhook Twilio beserta tindakan yang diambil
+type WhatsAppMessage struct {
+	ID            int
+	MessageSID    string // MessageSid dari Twilio, unik per pesan sehingga webhook yang dikirim ulang tidak diproses dua kali
+	From          string // Nomor pengirim, misalnya "whatsapp:+6281234567890"
+	To            string
+	Body          string
+	AppointmentID int    // 0 jika pengirim tidak punya appointment yang bisa dibalas
+	Action        string // "confirm", "cancel" atau kosong jika kata kunci tidak dikenal
+	Result        string
+	Reply         string // Balasan yang dikirim kembali ke pengirim
+}
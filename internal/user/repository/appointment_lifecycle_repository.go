@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"go-project/internal/user/model"
+	"go-project/pkg/appointment"
+	"time"
+)
+
+// changedByUser menandai perubahan status yang dilakukan user melalui link kelola appointment
+const changedByUser = "user"
+
+func (r *AppointmentRepository) GetAppointmentByID(id int) (model.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + `
+              FROM appointments a LEFT JOIN users h ON h.id = a.host_id
+              WHERE a.id = $1`
+	return scanAppointment(r.DB.QueryRow(query, id))
+}
+
+// GetStatusHistory mengambil timeline status appointment, diurutkan dari yang paling lama
+func (r *AppointmentRepository) GetStatusHistory(id int) ([]model.AppointmentStatusChange, error) {
+	query := `SELECT COALESCE(from_status, ''), to_status, COALESCE(note, ''), created_at
+              FROM appointment_status_history WHERE appointment_id = $1 ORDER BY created_at, id`
+	rows, err := r.DB.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []model.AppointmentStatusChange{}
+	for rows.Next() {
+		var change model.AppointmentStatusChange
+		if err := rows.Scan(&change.FromStatus, &change.ToStatus, &change.Note, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+// GetDocuments mengambil dokumen tindak lanjut yang diunggah staff host
+func (r *AppointmentRepository) GetDocuments(id int) ([]model.AppointmentDocument, error) {
+	rows, err := r.DB.Query(`SELECT id, filename, content_type, size_bytes, storage_key, created_at
+              FROM appointment_documents WHERE appointment_id = $1 ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []model.AppointmentDocument{}
+	for rows.Next() {
+		var d model.AppointmentDocument
+		if err := rows.Scan(&d.ID, &d.Filename, &d.ContentType, &d.Size, &d.StorageKey, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	return documents, rows.Err()
+}
+
+// GetDocument mengambil satu dokumen milik appointment tertentu
+func (r *AppointmentRepository) GetDocument(id, documentID int) (model.AppointmentDocument, error) {
+	var d model.AppointmentDocument
+	err := r.DB.QueryRow(`SELECT id, filename, content_type, size_bytes, storage_key, created_at
+              FROM appointment_documents WHERE id = $1 AND appointment_id = $2`, documentID, id).
+		Scan(&d.ID, &d.Filename, &d.ContentType, &d.Size, &d.StorageKey, &d.CreatedAt)
+	return d, err
+}
+
+// CancelAppointment membatalkan appointment atas permintaan user
+func (r *AppointmentRepository) CancelAppointment(id int, reason string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := transitionStatus(tx, id, appointment.StatusCancelled, changedByUser, reason); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RescheduleAppointment memindahkan appointment ke slot baru milik host yang sama
+func (r *AppointmentRepository) RescheduleAppointment(id int, start, end, dateOfBooking time.Time, note string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := transitionStatus(tx, id, appointment.StatusRescheduled, changedByUser, note); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`UPDATE appointments SET time = $1, end_time = $2, date_of_booking = $3, updated_at = NOW() WHERE id = $4`,
+		start, end, dateOfBooking, id)
+	if isSlotConflict(err) {
+		return ErrSlotTaken
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// transitionStatus mengunci baris appointment, memvalidasi perpindahan status sesuai lifecycle,
+// lalu menyimpan status baru beserta entri timeline-nya
+func transitionStatus(tx *sql.Tx, id int, to, changedBy, note string) error {
+	var current string
+	if err := tx.QueryRow("SELECT status FROM appointments WHERE id = $1 FOR UPDATE", id).Scan(&current); err != nil {
+		return err
+	}
+	if err := appointment.ValidateTransition(current, to); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE appointments SET status = $1, updated_at = NOW() WHERE id = $2", to, id); err != nil {
+		return err
+	}
+	return insertStatusHistory(tx, id, current, to, changedBy, note)
+}
+
+func insertStatusHistory(tx *sql.Tx, id int, from, to, changedBy, note string) error {
+	query := `INSERT INTO appointment_status_history (appointment_id, from_status, to_status, changed_by, note)
+              VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, ''))`
+	_, err := tx.Exec(query, id, from, to, changedBy, note)
+	return err
+}
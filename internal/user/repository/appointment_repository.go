@@ -2,9 +2,35 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"go-project/internal/user/model"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrSlotTaken dikembalikan jika slot host sudah dibooking oleh appointment lain
+	ErrSlotTaken = errors.New("slot is already booked")
+	// ErrReferenceTaken dikembalikan jika kode referensi yang dibuat sudah dipakai appointment lain
+	ErrReferenceTaken = errors.New("reference code is already used")
 )
 
+// appointmentColumns adalah kolom yang dibaca scanAppointment, dengan alias a untuk appointments dan h untuk host
+const appointmentColumns = `a.id, COALESCE(a.reference_code, ''), COALESCE(a.name, ''), COALESCE(a.phone_number, ''),
+              COALESCE(a.email, ''), a.date_of_booking, a.time, COALESCE(a.end_time, a.time), COALESCE(a.link_meet, ''),
+              COALESCE(a.host_id, 0), COALESCE(h.name, ''), a.status, COALESCE(a.pdf_file, ''), COALESCE(a.img, '')`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAppointment(row rowScanner) (model.Appointment, error) {
+	var a model.Appointment
+	err := row.Scan(&a.ID, &a.ReferenceCode, &a.Name, &a.PhoneNumber, &a.Email, &a.DateOfBooking, &a.Time, &a.EndTime,
+		&a.LinkMeet, &a.HostID, &a.HostName, &a.Status, &a.PDFFile, &a.Img)
+	return a, err
+}
+
 type AppointmentRepository struct {
 	DB *sql.DB
 }
@@ -13,48 +39,86 @@ func NewAppointmentRepository(db *sql.DB) *AppointmentRepository {
 	return &AppointmentRepository{DB: db}
 }
 
+// isSlotConflict melaporkan apakah err berasal dari exclusion constraint excl_appointments_host_overlap
+func isSlotConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01"
+}
+
 func (r *AppointmentRepository) CreateAppointment(appointment *model.Appointment) error {
 	query := `INSERT INTO appointments 
-        (name, phone_number, email, date_of_booking, time, status, pdf_file, img, created_at, updated_at) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW()) RETURNING id`
+        (reference_code, name, phone_number, email, date_of_booking, time, end_time, host_id, category_id, assignment_method,
+        host_assigned_at, status, pdf_file, img, created_at, updated_at) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0), $10, NOW(), $11, $12, $13, NOW(), NOW()) RETURNING id`
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	// Tidak memasukkan link_meet karena itu hanya diatur oleh admin
-	return r.DB.QueryRow(query,
+	// Tidak memasukkan link_meet karena itu hanya diatur oleh admin.
+	// Exclusion constraint excl_appointments_host_overlap menjamin jadwal host tidak beririsan.
+	err = tx.QueryRow(query,
+		appointment.ReferenceCode,
 		appointment.Name,
 		appointment.PhoneNumber,
 		appointment.Email,
 		appointment.DateOfBooking,
 		appointment.Time,
+		appointment.EndTime,
+		appointment.HostID,
+		appointment.CategoryID,
+		appointment.AssignmentMethod,
 		appointment.Status,
 		appointment.PDFFile,
 		appointment.Img).Scan(&appointment.ID)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "uniq_appointments_reference_code" {
+		return ErrReferenceTaken
+	}
+	if isSlotConflict(err) {
+		return ErrSlotTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	// Entri pertama timeline: appointment dibuat dengan status awal
+	if err := insertStatusHistory(tx, appointment.ID, "", appointment.Status, changedByUser, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *AppointmentRepository) GetAppointments() ([]model.Appointment, error) {
-	query := `SELECT id, name, email, date_of_booking, time, pdf_file, img, status,
-              FROM appointments WHERE status = 'pending'`
-	rows, err := r.DB.Query(query)
+// GetAppointmentsByEmail mengambil semua appointment milik satu email, yang terbaru lebih dulu
+func (r *AppointmentRepository) GetAppointmentsByEmail(email string) ([]model.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + `
+              FROM appointments a LEFT JOIN users h ON h.id = a.host_id
+              WHERE lower(a.email) = lower($1)
+              ORDER BY a.time DESC`
+	rows, err := r.DB.Query(query, email)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var appointments []model.Appointment
+	appointments := []model.Appointment{}
 	for rows.Next() {
-		var appointment model.Appointment
-		if err := rows.Scan(
-			&appointment.ID,
-			&appointment.Name,
-			&appointment.Email,
-			&appointment.DateOfBooking,
-			&appointment.Time,
-			&appointment.PDFFile,
-			&appointment.Img,
-			&appointment.Status,
-		); err != nil {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
 			return nil, err
 		}
 		appointments = append(appointments, appointment)
 	}
-	return appointments, nil
+	return appointments, rows.Err()
+}
+
+// GetAppointmentByReference mengambil appointment berdasarkan kode referensi dan email pemiliknya
+func (r *AppointmentRepository) GetAppointmentByReference(email, referenceCode string) (model.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + `
+              FROM appointments a LEFT JOIN users h ON h.id = a.host_id
+              WHERE lower(a.email) = lower($1) AND a.reference_code = upper($2)`
+	return scanAppointment(r.DB.QueryRow(query, email, referenceCode))
 }
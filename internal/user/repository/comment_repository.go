@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/user/model"
+)
+
+type CommentRepository struct {
+	DB *sql.DB
+}
+
+// ErrAlreadyReported dikembalikan jika pembaca yang sama sudah melaporkan komentar tersebut
+var ErrAlreadyReported = errors.New("comment already reported")
+
+// ErrNotReportable dikembalikan jika komentar yang dilaporkan tidak sedang tampil (belum disetujui, ditolak, atau disembunyikan)
+var ErrNotReportable = errors.New("only approved comments can be reported")
+
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{DB: db}
+}
+
+// GetApprovedComments mengambil semua komentar yang sudah disetujui untuk satu artikel
+func (r *CommentRepository) GetApprovedComments(articleID int) ([]model.Comment, error) {
+	query := `SELECT c.id, c.article_id, c.username, c.comment, c.parent_id, c.created_at,
+              COUNT(cr.id) FILTER (WHERE cr.type = 'like'), COUNT(cr.id) FILTER (WHERE cr.type = 'helpful')
+              FROM comments c
+              LEFT JOIN comment_reactions cr ON cr.comment_id = c.id
+              WHERE c.article_id = $1 AND c.status = 'approved'
+              GROUP BY c.id ORDER BY c.created_at, c.id`
+	rows, err := r.DB.Query(query, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []model.Comment
+	for rows.Next() {
+		var comment model.Comment
+		var parentID sql.NullInt32
+		if err := rows.Scan(
+			&comment.ID,
+			&comment.ArticleID,
+			&comment.Username,
+			&comment.Comment,
+			&parentID,
+			&comment.CreatedAt,
+			&comment.Likes,
+			&comment.Helpful,
+		); err != nil {
+			return nil, err
+		}
+		if parentID.Valid {
+			id := int(parentID.Int32)
+			comment.ParentID = &id
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// GetCommentArticleID mengambil ID artikel dari sebuah komentar, dipakai untuk memeriksa komentar induk balasan
+func (r *CommentRepository) GetCommentArticleID(commentID int) (int, error) {
+	var articleID int
+	err := r.DB.QueryRow(`SELECT article_id FROM comments WHERE id = $1`, commentID).Scan(&articleID)
+	return articleID, err
+}
+
+// CreateComment menyimpan komentar publik beserta hasil moderasi otomatis
+func (r *CommentRepository) CreateComment(comment *model.Comment, email, ipAddress string, score float64, reason string) error {
+	query := `INSERT INTO comments (article_id, username, email, comment, parent_id, status, ip_address, moderation_score, moderation_reason, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW()) RETURNING id, created_at`
+	return r.DB.QueryRow(query,
+		comment.ArticleID,
+		comment.Username,
+		email,
+		comment.Comment,
+		comment.ParentID,
+		comment.Status,
+		ipAddress,
+		score,
+		reason).Scan(&comment.ID, &comment.CreatedAt)
+}
+
+// SetReaction menyimpan reaksi pembaca; reaksi sebelumnya dari pembaca yang sama akan diganti
+func (r *CommentRepository) SetReaction(commentID int, readerKey, reactionType string) error {
+	query := `INSERT INTO comment_reactions (comment_id, reader_key, type, created_at, updated_at)
+              SELECT id, $2, $3, NOW(), NOW() FROM comments WHERE id = $1 AND status = 'approved'
+              ON CONFLICT (comment_id, reader_key) DO UPDATE SET type = EXCLUDED.type, updated_at = NOW()`
+	result, err := r.DB.Exec(query, commentID, readerKey, reactionType)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return errors.New("comment not found")
+	}
+	return nil
+}
+
+// RemoveReaction menghapus reaksi pembaca dari sebuah komentar
+func (r *CommentRepository) RemoveReaction(commentID int, readerKey string) error {
+	_, err := r.DB.Exec(`DELETE FROM comment_reactions WHERE comment_id = $1 AND reader_key = $2`, commentID, readerKey)
+	return err
+}
+
+// GetReactionCounts menghitung reaksi untuk sebuah komentar
+func (r *CommentRepository) GetReactionCounts(commentID int) (*model.ReactionCounts, error) {
+	counts := model.ReactionCounts{CommentID: commentID}
+	query := `SELECT COUNT(*) FILTER (WHERE type = 'like'), COUNT(*) FILTER (WHERE type = 'helpful')
+              FROM comment_reactions WHERE comment_id = $1`
+	if err := r.DB.QueryRow(query, commentID).Scan(&counts.Likes, &counts.Helpful); err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// CreateReport menyimpan laporan pembaca untuk komentar yang sudah disetujui, satu laporan per readerKey
+// per komentar. Jika jumlah laporan terbuka mencapai threshold, komentar disembunyikan sampai direview moderator.
+func (r *CommentRepository) CreateReport(report *model.CommentReport, readerKey, ipAddress string, threshold int) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM comments WHERE id = $1 FOR UPDATE`, report.CommentID).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("comment not found")
+		}
+		return err
+	}
+	if status != "approved" {
+		return ErrNotReportable
+	}
+
+	query := `INSERT INTO comment_reports (comment_id, reader_key, reporter_email, ip_address, reason, status, created_at)
+              VALUES ($1, $2, NULLIF($3, ''), $4, $5, 'open', NOW())
+              ON CONFLICT (comment_id, reader_key) DO NOTHING RETURNING id, created_at`
+	err = tx.QueryRow(query, report.CommentID, readerKey, report.ReporterEmail, ipAddress, report.Reason).Scan(&report.ID, &report.CreatedAt)
+	if err == sql.ErrNoRows {
+		return ErrAlreadyReported
+	}
+	if err != nil {
+		return err
+	}
+
+	var openReports int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM comment_reports WHERE comment_id = $1 AND status = 'open'`, report.CommentID).Scan(&openReports); err != nil {
+		return err
+	}
+	if threshold > 0 && openReports >= threshold {
+		if _, err := tx.Exec(`UPDATE comments SET status = 'hidden', updated_at = NOW() WHERE id = $1`, report.CommentID); err != nil {
+			return err
+		}
+		report.Hidden = true
+	}
+	return tx.Commit()
+}
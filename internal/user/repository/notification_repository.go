@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+	"go-project/internal/user/model"
+)
+
+// NotificationRepository membaca dan mengelola inbox notifikasi in-app. Semua query dibatasi pada
+// pengguna pemilik email sehingga notifikasi orang lain tidak bisa dibaca atau diubah.
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{DB: db}
+}
+
+const ownerByEmail = `(SELECT id FROM users WHERE email = $1)`
+
+// ListNotifications mengambil notifikasi milik pengguna, terbaru lebih dulu, beserta jumlah totalnya
+func (r *NotificationRepository) ListNotifications(email string, unreadOnly bool, limit, offset int) ([]model.Notification, int, error) {
+	rows, err := r.DB.Query(`SELECT id, COALESCE(type, ''), COALESCE(message, ''), status, created_at, updated_at,
+			COUNT(*) OVER ()
+		FROM notifications
+		WHERE user_id = `+ownerByEmail+` AND (NOT $2 OR status = 'unread')
+		ORDER BY created_at DESC, id DESC LIMIT $3 OFFSET $4`, email, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	notifications := []model.Notification{}
+	total := 0
+	for rows.Next() {
+		var n model.Notification
+		if err := rows.Scan(&n.ID, &n.Type, &n.Message, &n.Status, &n.CreatedAt, &n.UpdatedAt, &total); err != nil {
+			return nil, 0, err
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// Halaman di luar jangkauan tidak mengembalikan baris, jadi total dihitung terpisah
+	if len(notifications) == 0 && offset > 0 {
+		err = r.DB.QueryRow(`SELECT COUNT(*) FROM notifications
+			WHERE user_id = `+ownerByEmail+` AND (NOT $2 OR status = 'unread')`, email, unreadOnly).Scan(&total)
+	}
+	return notifications, total, err
+}
+
+// CountUnread menghitung notifikasi yang belum dibaca
+func (r *NotificationRepository) CountUnread(email string) (int, error) {
+	var count int
+	err := r.DB.QueryRow(`SELECT COUNT(*) FROM notifications
+		WHERE user_id = `+ownerByEmail+` AND status = 'unread'`, email).Scan(&count)
+	return count, err
+}
+
+// MarkRead menandai satu notifikasi sudah dibaca. Jika notifikasi tidak ditemukan, fungsi ini
+// mengembalikan sql.ErrNoRows.
+func (r *NotificationRepository) MarkRead(email string, id int) error {
+	result, err := r.DB.Exec(`UPDATE notifications
+		SET status = 'read', updated_at = CASE WHEN status = 'read' THEN updated_at ELSE NOW() END
+		WHERE id = $2 AND user_id = `+ownerByEmail, email, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// MarkAllRead menandai semua notifikasi pengguna sudah dibaca dan mengembalikan jumlah yang berubah
+func (r *NotificationRepository) MarkAllRead(email string) (int64, error) {
+	result, err := r.DB.Exec(`UPDATE notifications SET status = 'read', updated_at = NOW()
+		WHERE user_id = `+ownerByEmail+` AND status = 'unread'`, email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteNotification menghapus satu notifikasi. Jika notifikasi tidak ditemukan, fungsi ini
+// mengembalikan sql.ErrNoRows.
+func (r *NotificationRepository) DeleteNotification(email string, id int) error {
+	result, err := r.DB.Exec(`DELETE FROM notifications WHERE id = $2 AND user_id = `+ownerByEmail, email, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// GetPreferences mengambil pengaturan bisu notifikasi milik pengguna
+func (r *NotificationRepository) GetPreferences(email string) ([]model.NotificationPreference, error) {
+	rows, err := r.DB.Query(`SELECT type, channel, muted FROM notification_preferences
+		WHERE user_id = `+ownerByEmail+` ORDER BY type, channel`, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := []model.NotificationPreference{}
+	for rows.Next() {
+		var p model.NotificationPreference
+		if err := rows.Scan(&p.Type, &p.Channel, &p.Muted); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, p)
+	}
+	return preferences, rows.Err()
+}
+
+// SetPreferences menyimpan pengaturan bisu dalam satu transaksi. Pengaturan yang tidak dibisukan
+// dihapus karena tanpa baris berarti notifikasi tetap dikirim. Jika pengguna tidak ditemukan,
+// fungsi ini mengembalikan sql.ErrNoRows.
+func (r *NotificationRepository) SetPreferences(email string, preferences []model.NotificationPreference) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	if err := tx.QueryRow(`SELECT id FROM users WHERE email = $1`, email).Scan(&userID); err != nil {
+		return err
+	}
+	for _, p := range preferences {
+		if p.Muted {
+			_, err = tx.Exec(`INSERT INTO notification_preferences (user_id, type, channel, muted, updated_at)
+				VALUES ($1, $2, $3, true, NOW())
+				ON CONFLICT (user_id, type, channel) DO UPDATE SET muted = true, updated_at = NOW()`,
+				userID, p.Type, p.Channel)
+		} else {
+			_, err = tx.Exec(`DELETE FROM notification_preferences WHERE user_id = $1 AND type = $2 AND channel = $3`,
+				userID, p.Type, p.Channel)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// requireRow mengembalikan sql.ErrNoRows jika query tidak mengubah baris apa pun
+func requireRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
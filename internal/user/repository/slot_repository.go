@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"go-project/internal/user/model"
+	"go-project/pkg/appointment"
+	"time"
+)
+
+// GetAvailabilityTemplates mengambil jadwal mingguan semua staff aktif, atau satu host jika hostID > 0
+func (r *AppointmentRepository) GetAvailabilityTemplates(hostID int) ([]model.AvailabilityTemplate, error) {
+	query := `SELECT sa.staff_id, COALESCE(u.name, u.email), sa.weekday, to_char(sa.start_time, 'HH24:MI'),
+              to_char(sa.end_time, 'HH24:MI'), sa.slot_minutes
+              FROM staff_availability sa
+              JOIN users u ON u.id = sa.staff_id
+              WHERE u.role = 'staff' AND COALESCE(u.status, 'active') = 'active' AND ($1 = 0 OR sa.staff_id = $1)
+              ORDER BY sa.staff_id, sa.weekday, sa.start_time`
+	rows, err := r.DB.Query(query, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []model.AvailabilityTemplate
+	for rows.Next() {
+		var t model.AvailabilityTemplate
+		if err := rows.Scan(&t.HostID, &t.HostName, &t.Weekday, &t.StartTime, &t.EndTime, &t.SlotMinutes); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// GetAvailabilityExceptions mengambil libur/jam khusus host di antara dua tanggal
+func (r *AppointmentRepository) GetAvailabilityExceptions(from, to string, hostID int) ([]model.AvailabilityException, error) {
+	query := `SELECT staff_id, to_char(date, 'YYYY-MM-DD'), to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI')
+              FROM availability_exceptions
+              WHERE date BETWEEN $1::date AND $2::date AND ($3 = 0 OR staff_id = $3)`
+	rows, err := r.DB.Query(query, from, to, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exceptions []model.AvailabilityException
+	for rows.Next() {
+		var e model.AvailabilityException
+		var start, end sql.NullString
+		if err := rows.Scan(&e.HostID, &e.Date, &start, &end); err != nil {
+			return nil, err
+		}
+		if start.Valid && end.Valid {
+			e.StartTime, e.EndTime = &start.String, &end.String
+		}
+		exceptions = append(exceptions, e)
+	}
+	return exceptions, rows.Err()
+}
+
+// GetBookedSlots mengambil rentang appointment aktif yang beririsan dengan [from, to)
+func (r *AppointmentRepository) GetBookedSlots(from, to time.Time, hostID int) ([]model.BookedSlot, error) {
+	query := `SELECT host_id, time, COALESCE(end_time, time + $4 * interval '1 minute') FROM appointments
+              WHERE host_id IS NOT NULL AND status <> 'cancelled' AND ($3 = 0 OR host_id = $3)
+              AND time < $2 AND COALESCE(end_time, time + $4 * interval '1 minute') > $1`
+	rows, err := r.DB.Query(query, from, to, hostID, int(appointment.DefaultDuration/time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var booked []model.BookedSlot
+	for rows.Next() {
+		var b model.BookedSlot
+		if err := rows.Scan(&b.HostID, &b.Start, &b.End); err != nil {
+			return nil, err
+		}
+		booked = append(booked, b)
+	}
+	return booked, rows.Err()
+}
+
+// GetHostCandidates mengambil semua staff aktif beserta beban kerja dan status bentrok jadwalnya
+func (r *AppointmentRepository) GetHostCandidates(start, end time.Time, day string) ([]appointment.Candidate, error) {
+	return appointment.LoadCandidates(r.DB, start, end, day, 0)
+}
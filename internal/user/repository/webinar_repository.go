@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/user/model"
+	"go-project/pkg/webinar"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrAlreadyRegistered dikembalikan jika email sudah terdaftar di webinar yang sama
+	ErrAlreadyRegistered = errors.New("email is already registered for this webinar")
+	// ErrRegistrationCancelled dikembalikan jika pendaftaran sudah dibatalkan sebelumnya
+	ErrRegistrationCancelled = errors.New("registration is already cancelled")
+	// ErrRegistrationClosed dikembalikan jika webinar tidak lagi menerima pendaftaran
+	ErrRegistrationClosed = errors.New("registration for this webinar is closed")
+)
+
+// Status pendaftaran webinar
+const (
+	RegistrationRegistered = "registered"
+	RegistrationWaitlisted = "waitlisted"
+	RegistrationCancelled  = "cancelled"
+)
+
+type WebinarRepository struct {
+	DB *sql.DB
+}
+
+func NewWebinarRepository(db *sql.DB) *WebinarRepository {
+	return &WebinarRepository{DB: db}
+}
+
+func (r *WebinarRepository) GetWebinarByID(id int) (model.Webinar, error) {
+	query := `SELECT w.id, COALESCE(w.title, ''), COALESCE(w.description, ''), COALESCE(w.link_meet, ''), COALESCE(w.host_id, 0),
+              w.start_time, w.end_time, COALESCE(w.capacity, 0), w.registration_deadline,
+              (SELECT COUNT(*) FROM webinar_registrations r WHERE r.webinar_id = w.id AND r.status = 'registered'),
+              w.status, COALESCE(v.link_video, '')
+              FROM webinars w LEFT JOIN videos v ON v.id = w.recording_video_id
+              WHERE w.id = $1`
+	var w model.Webinar
+	var start, end, deadline sql.NullTime
+	if err := r.DB.QueryRow(query, id).Scan(&w.ID, &w.Title, &w.Description, &w.LinkMeet, &w.HostID, &start, &end,
+		&w.Capacity, &deadline, &w.RegisteredCount, &w.Status, &w.RecordingURL); err != nil {
+		return w, err
+	}
+	if start.Valid {
+		w.StartTime = &start.Time
+	}
+	if end.Valid {
+		w.EndTime = &end.Time
+	}
+	if deadline.Valid {
+		w.RegistrationDeadline = &deadline.Time
+	}
+	return w, nil
+}
+
+// Register menyimpan pendaftaran baru. Baris webinar dikunci selama transaksi sehingga kapasitas
+// tidak terlampaui oleh pendaftaran yang bersamaan dan webinar tidak dibatalkan di tengah jalan;
+// jika penuh pendaftar masuk daftar tunggu.
+func (r *WebinarRepository) Register(reg *model.WebinarRegistration) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var capacity sql.NullInt64
+	var status string
+	if err := tx.QueryRow(`SELECT capacity, status FROM webinars WHERE id = $1 FOR UPDATE`, reg.WebinarID).Scan(&capacity, &status); err != nil {
+		return err
+	}
+	if !webinar.AcceptsRegistrations(status) {
+		return ErrRegistrationClosed
+	}
+	var registered int64
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM webinar_registrations WHERE webinar_id = $1 AND status = 'registered'`,
+		reg.WebinarID).Scan(&registered); err != nil {
+		return err
+	}
+	reg.Status = RegistrationRegistered
+	if capacity.Valid && registered >= capacity.Int64 {
+		reg.Status = RegistrationWaitlisted
+	}
+
+	query := `INSERT INTO webinar_registrations (webinar_id, name, email, phone_number, status, cancel_token)
+              VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6) RETURNING id, created_at`
+	err = tx.QueryRow(query, reg.WebinarID, reg.Name, reg.Email, reg.PhoneNumber, reg.Status, reg.CancelToken).
+		Scan(&reg.ID, &reg.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrAlreadyRegistered
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return r.fillWaitlistPosition(reg)
+}
+
+// GetRegistrationByToken mengambil pendaftaran berdasarkan token pembatalan
+func (r *WebinarRepository) GetRegistrationByToken(token string) (model.WebinarRegistration, error) {
+	query := `SELECT id, webinar_id, name, email, COALESCE(phone_number, ''), status, created_at
+              FROM webinar_registrations WHERE cancel_token = $1`
+	var reg model.WebinarRegistration
+	if err := r.DB.QueryRow(query, token).Scan(&reg.ID, &reg.WebinarID, &reg.Name, &reg.Email, &reg.PhoneNumber,
+		&reg.Status, &reg.CreatedAt); err != nil {
+		return reg, err
+	}
+	return reg, r.fillWaitlistPosition(&reg)
+}
+
+// CancelRegistration membatalkan pendaftaran. Jika pendaftar sudah mendapat kursi, kursinya diberikan
+// ke pendaftar paling awal di daftar tunggu.
+func (r *WebinarRepository) CancelRegistration(token string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Webinar dikunci lebih dulu, urutan yang sama dengan Register, agar tidak terjadi deadlock
+	var id, webinarID int
+	var status string
+	if err := tx.QueryRow(`SELECT id, webinar_id FROM webinar_registrations WHERE cancel_token = $1`, token).Scan(&id, &webinarID); err != nil {
+		return err
+	}
+	var capacity sql.NullInt64
+	if err := tx.QueryRow(`SELECT capacity FROM webinars WHERE id = $1 FOR UPDATE`, webinarID).Scan(&capacity); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(`SELECT status FROM webinar_registrations WHERE id = $1 FOR UPDATE`, id).Scan(&status); err != nil {
+		return err
+	}
+	if status == RegistrationCancelled {
+		return ErrRegistrationCancelled
+	}
+
+	_, err = tx.Exec(`UPDATE webinar_registrations SET status = 'cancelled', cancelled_at = NOW(), updated_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if status == RegistrationRegistered {
+		if err := promoteWaitlist(tx, webinarID, capacity); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetCheckInCode mengambil status webinar dan kode check-in yang sedang berlaku
+func (r *WebinarRepository) GetCheckInCode(webinarID int) (status, code string, err error) {
+	err = r.DB.QueryRow(`SELECT status, COALESCE(check_in_code, '') FROM webinars WHERE id = $1`, webinarID).Scan(&status, &code)
+	return status, code, err
+}
+
+// AddCheckIn mencatat check-in mandiri peserta. Check-in pertama yang dipakai sehingga
+// memasukkan kode berulang kali tidak mengurangi persentase kehadiran.
+func (r *WebinarRepository) AddCheckIn(registrationID, webinarID int) error {
+	return webinar.CheckIn(r.DB, webinarID, registrationID, webinar.CheckInSelf, 0)
+}
+
+// AddCheckOut mencatat waktu peserta meninggalkan webinar
+func (r *WebinarRepository) AddCheckOut(registrationID, webinarID int) error {
+	return webinar.CheckOut(r.DB, webinarID, registrationID)
+}
+
+const certificateQuery = `SELECT c.code, c.name, w.id, COALESCE(w.title, ''), COALESCE(h.name, ''),
+              COALESCE(w.start_time, c.issued_at), c.attended_percent, c.issued_at
+              FROM webinar_certificates c
+              JOIN webinars w ON w.id = c.webinar_id
+              LEFT JOIN users h ON h.id = w.host_id`
+
+// GetCertificateByCode mengambil sertifikat berdasarkan kodenya
+func (r *WebinarRepository) GetCertificateByCode(code string) (model.Certificate, error) {
+	return scanCertificate(r.DB.QueryRow(certificateQuery+` WHERE c.code = $1`, code))
+}
+
+// GetCertificateByToken mengambil sertifikat milik pendaftaran dengan token tersebut
+func (r *WebinarRepository) GetCertificateByToken(token string) (model.Certificate, error) {
+	return scanCertificate(r.DB.QueryRow(certificateQuery+`
+              JOIN webinar_registrations r ON r.id = c.registration_id WHERE r.cancel_token = $1`, token))
+}
+
+func scanCertificate(row *sql.Row) (model.Certificate, error) {
+	var c model.Certificate
+	err := row.Scan(&c.Code, &c.Name, &c.WebinarID, &c.WebinarTitle, &c.HostName, &c.WebinarDate, &c.AttendedPercent, &c.IssuedAt)
+	return c, err
+}
+
+// promoteWaitlist memindahkan pendaftar paling awal di daftar tunggu selama masih ada kursi kosong
+func promoteWaitlist(tx *sql.Tx, webinarID int, capacity sql.NullInt64) error {
+	query := `UPDATE webinar_registrations SET status = 'registered', promoted_at = NOW(), updated_at = NOW()
+              WHERE id = (SELECT id FROM webinar_registrations WHERE webinar_id = $1 AND status = 'waitlisted'
+                          ORDER BY created_at, id LIMIT 1)
+                AND ($2::integer IS NULL OR
+                     (SELECT COUNT(*) FROM webinar_registrations WHERE webinar_id = $1 AND status = 'registered') < $2)`
+	_, err := tx.Exec(query, webinarID, capacity)
+	return err
+}
+
+func (r *WebinarRepository) fillWaitlistPosition(reg *model.WebinarRegistration) error {
+	if reg.Status != RegistrationWaitlisted {
+		return nil
+	}
+	query := `SELECT COUNT(*) FROM webinar_registrations
+              WHERE webinar_id = $1 AND status = 'waitlisted' AND (created_at, id) <= ($2, $3)`
+	return r.DB.QueryRow(query, reg.WebinarID, reg.CreatedAt, reg.ID).Scan(&reg.WaitlistPosition)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"go-project/internal/user/model"
+	"go-project/pkg/appointment"
+)
+
+// changedByWhatsApp menandai perubahan status dari balasan WhatsApp klien
+const changedByWhatsApp = "whatsapp"
+
+type WhatsAppRepository struct {
+	DB *sql.DB
+}
+
+func NewWhatsAppRepository(db *sql.DB) *WhatsAppRepository {
+	return &WhatsAppRepository{DB: db}
+}
+
+// SaveMessage menyimpan pesan masuk. Jika MessageSid sudah pernah disimpan (Twilio mengirim ulang webhook),
+// pesan lama dimuat ke msg dan created bernilai false.
+func (r *WhatsAppRepository) SaveMessage(msg *model.WhatsAppMessage) (created bool, err error) {
+	err = r.DB.QueryRow(`INSERT INTO whatsapp_messages (message_sid, from_number, to_number, body)
+              VALUES ($1, $2, $3, $4) ON CONFLICT (message_sid) DO NOTHING RETURNING id`,
+		msg.MessageSID, msg.From, msg.To, msg.Body).Scan(&msg.ID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	err = r.DB.QueryRow(`SELECT id, COALESCE(appointment_id, 0), COALESCE(action, ''), result, COALESCE(reply, '')
+              FROM whatsapp_messages WHERE message_sid = $1`, msg.MessageSID).
+		Scan(&msg.ID, &msg.AppointmentID, &msg.Action, &msg.Result, &msg.Reply)
+	return false, err
+}
+
+// UpdateMessageResult menyimpan appointment yang dituju, tindakan dan balasan untuk pesan masuk
+func (r *WhatsAppRepository) UpdateMessageResult(msg model.WhatsAppMessage) error {
+	_, err := r.DB.Exec(`UPDATE whatsapp_messages
+              SET appointment_id = NULLIF($1, 0), action = NULLIF($2, ''), result = $3, reply = NULLIF($4, '')
+              WHERE id = $5`, msg.AppointmentID, msg.Action, msg.Result, msg.Reply, msg.ID)
+	return err
+}
+
+// FindReplyAppointment mencari appointment aktif terdekat yang akan datang milik nomor pengirim
+func (r *WhatsAppRepository) FindReplyAppointment(phone string) (model.Appointment, error) {
+	query := `SELECT ` + appointmentColumns + `
+              FROM appointments a LEFT JOIN users h ON h.id = a.host_id
+              WHERE normalize_phone(a.phone_number) = normalize_phone($1) AND normalize_phone($1) <> ''
+                AND a.status IN ($2, $3, $4) AND a.time > NOW()
+              ORDER BY a.time LIMIT 1`
+	return scanAppointment(r.DB.QueryRow(query, phone,
+		appointment.StatusPending, appointment.StatusConfirmed, appointment.StatusRescheduled))
+}
+
+// TransitionByWhatsApp mengubah status appointment sesuai balasan WhatsApp klien
+func (r *WhatsAppRepository) TransitionByWhatsApp(id int, to, note string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := transitionStatus(tx, id, to, changedByWhatsApp, note); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
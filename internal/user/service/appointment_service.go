@@ -1,24 +1,358 @@
 package service
 
 import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"go-project/config"
 	"go-project/internal/user/model"
 	"go-project/internal/user/repository"
+	lifecycle "go-project/pkg/appointment"
+	"go-project/pkg/calendar"
+	"go-project/pkg/storage"
+	"go-project/pkg/utils"
+	"strings"
+	"time"
 )
 
+var (
+	// ErrSlotUnavailable dikembalikan jika waktu yang diminta bukan slot kosong milik host
+	ErrSlotUnavailable = errors.New("requested time is not an available slot for this host")
+	// ErrInvalidManageToken dikembalikan jika token link kelola tidak valid atau kedaluwarsa
+	ErrInvalidManageToken = errors.New("invalid or expired appointment link")
+)
+
+// referenceCodeAttempts adalah jumlah percobaan membuat kode referensi yang unik
+const referenceCodeAttempts = 3
+
+// manageTokenGrace adalah lama token link kelola tetap berlaku setelah appointment selesai
+const manageTokenGrace = 7 * 24 * time.Hour
+
 type AppointmentService struct {
-	Repo *repository.AppointmentRepository
+	Repo     *repository.AppointmentRepository
+	Files    storage.Storage // Tempat dokumen tindak lanjut dari staff disimpan
+	Location *time.Location  // Zona waktu jadwal appointment
+	Strategy string          // Strategi penentuan host otomatis, kosong jika user wajib memilih host
+}
+
+func NewAppointmentService(repo *repository.AppointmentRepository, files storage.Storage, strategy string) *AppointmentService {
+	return &AppointmentService{Repo: repo, Files: files, Location: config.Location(), Strategy: strategy}
 }
 
-func NewAppointmentService(repo *repository.AppointmentRepository) *AppointmentService {
-	return &AppointmentService{Repo: repo}
+// ListFreeSlots mengembalikan slot kosong di antara dua tanggal (YYYY-MM-DD), untuk semua host atau satu host
+func (s *AppointmentService) ListFreeSlots(fromDate, toDate string, hostID int) ([]model.Slot, error) {
+	from, err := time.ParseInLocation("2006-01-02", fromDate, s.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date, expected YYYY-MM-DD")
+	}
+	to, err := time.ParseInLocation("2006-01-02", toDate, s.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date, expected YYYY-MM-DD")
+	}
+	if to.Before(from) {
+		return nil, errors.New("to date must not be before from date")
+	}
+	if to.Sub(from) > MaxSlotRangeDays*24*time.Hour {
+		return nil, fmt.Errorf("date range must not exceed %d days", MaxSlotRangeDays)
+	}
+
+	templates, err := s.Repo.GetAvailabilityTemplates(hostID)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := s.Repo.GetAvailabilityExceptions(fromDate, toDate, hostID)
+	if err != nil {
+		return nil, err
+	}
+	booked, err := s.Repo.GetBookedSlots(from, to.AddDate(0, 0, 1), hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSlots(from, to, s.Location, time.Now(), templates, exceptions, booked), nil
 }
 
+// CreateAppointment membooking satu slot kosong milik host. Slot divalidasi terhadap jadwal host,
+// dan constraint database mencegah slot yang sama dibooking dua kali secara bersamaan.
 func (s *AppointmentService) CreateAppointment(appointment *model.Appointment) error {
+	if appointment.Name == "" || appointment.Email == "" || appointment.Time.IsZero() {
+		return errors.New("name, email and time are required")
+	}
+
+	var slot *model.Slot
+	var err error
+	if appointment.HostID > 0 {
+		appointment.AssignmentMethod = lifecycle.AssignmentManual
+		slot, err = s.findFreeSlot(appointment.HostID, appointment.Time)
+	} else {
+		slot, err = s.assignHost(appointment)
+	}
+	if err != nil {
+		return err
+	}
+	appointment.HostID = slot.HostID
+
+	appointment.Time = slot.Start
+	appointment.EndTime = slot.End
+	appointment.DateOfBooking = s.dateOf(slot.Start)
+
 	// Set status appointment ke "pending"
 	appointment.Status = "pending"
-	return s.Repo.CreateAppointment(appointment)
+
+	// Kode referensi dibuat ulang jika kebetulan sudah dipakai
+	for attempt := 0; ; attempt++ {
+		if appointment.ReferenceCode, err = newReferenceCode(); err != nil {
+			return err
+		}
+		err = s.Repo.CreateAppointment(appointment)
+		if !errors.Is(err, repository.ErrReferenceTaken) || attempt == referenceCodeAttempts-1 {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.manageToken(*appointment)
+	if err != nil {
+		return err
+	}
+	appointment.ManageToken = token
+	return nil
+}
+
+// GetManagedAppointment mengambil appointment dan timeline-nya berdasarkan token link kelola
+func (s *AppointmentService) GetManagedAppointment(token string) (*model.ManagedAppointment, error) {
+	id, err := utils.ValidateAppointmentToken(token)
+	if err != nil {
+		return nil, ErrInvalidManageToken
+	}
+	appt, err := s.Repo.GetAppointmentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.managed(appt)
+}
+
+// CancelByToken membatalkan appointment melalui link kelola, selama belum melewati batas cut-off
+func (s *AppointmentService) CancelByToken(token, reason string) error {
+	appt, err := s.appointmentFromToken(token)
+	if err != nil {
+		return err
+	}
+	if err := lifecycle.CheckCutoff(appt.Time, time.Now()); err != nil {
+		return err
+	}
+	return s.Repo.CancelAppointment(appt.ID, reason)
+}
+
+// RescheduleByToken memindahkan appointment ke slot kosong lain milik host yang sama melalui link kelola.
+// Token baru dikembalikan karena masa berlaku token mengikuti jadwal appointment.
+func (s *AppointmentService) RescheduleByToken(token string, newTime time.Time, note string) (*model.Appointment, error) {
+	appt, err := s.appointmentFromToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := lifecycle.CheckCutoff(appt.Time, time.Now()); err != nil {
+		return nil, err
+	}
+
+	slot, err := s.findFreeSlot(appt.HostID, newTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.RescheduleAppointment(appt.ID, slot.Start, slot.End, s.dateOf(slot.Start), note); err != nil {
+		return nil, err
+	}
+
+	appt.Time = slot.Start
+	appt.EndTime = slot.End
+	appt.DateOfBooking = s.dateOf(slot.Start)
+	appt.Status = lifecycle.StatusRescheduled
+	if appt.ManageToken, err = s.manageToken(appt); err != nil {
+		return nil, err
+	}
+	return &appt, nil
+}
+
+// GetManagedCalendar membuat file iCalendar untuk appointment dari link kelola
+func (s *AppointmentService) GetManagedCalendar(token string) (calendar.Calendar, error) {
+	appt, err := s.appointmentFromToken(token)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	return calendar.Calendar{
+		Name:   "Appointment",
+		Events: []calendar.Event{AppointmentEvent(appt)},
+	}, nil
+}
+
+// AppointmentEvent mengubah appointment menjadi event iCalendar
+func AppointmentEvent(a model.Appointment) calendar.Event {
+	event := calendar.Event{
+		UID:       calendar.AppointmentUID(a.ID),
+		Summary:   "Appointment " + a.Name,
+		Location:  a.LinkMeet,
+		URL:       a.LinkMeet,
+		Start:     a.Time,
+		End:       a.EndTime,
+		Status:    calendar.AppointmentStatus(a.Status),
+		Attendees: []string{a.Email},
+	}
+	if a.LinkMeet != "" {
+		event.Description = "Link meeting: " + a.LinkMeet
+	}
+	return event
+}
+
+func (s *AppointmentService) appointmentFromToken(token string) (model.Appointment, error) {
+	id, err := utils.ValidateAppointmentToken(token)
+	if err != nil {
+		return model.Appointment{}, ErrInvalidManageToken
+	}
+	return s.Repo.GetAppointmentByID(id)
+}
+
+// assignHost memilih host otomatis sesuai appointments.assignment_strategy di antara host yang
+// memiliki slot kosong pada waktu yang diminta
+func (s *AppointmentService) assignHost(a *model.Appointment) (*model.Slot, error) {
+	strategy := s.Strategy
+	if strategy == "" {
+		return nil, errors.New("host_id is required")
+	}
+
+	date := a.Time.In(s.Location).Format("2006-01-02")
+	slots, err := s.ListFreeSlots(date, date, 0)
+	if err != nil {
+		return nil, err
+	}
+	free := map[int]model.Slot{}
+	end := a.Time
+	for _, slot := range slots {
+		if slot.Start.Equal(a.Time) {
+			free[slot.HostID] = slot
+			if slot.End.After(end) {
+				end = slot.End
+			}
+		}
+	}
+	if len(free) == 0 {
+		return nil, ErrSlotUnavailable
+	}
+
+	candidates, err := s.Repo.GetHostCandidates(a.Time, end, date)
+	if err != nil {
+		return nil, err
+	}
+	// Panjang slot bisa berbeda per host, dan slot kosong sudah diperiksa terhadap rentang appointment
+	// yang dibooking. Host bisa dipilih hanya jika memiliki slot kosong yang dimulai pada waktu tersebut.
+	for i := range candidates {
+		_, ok := free[candidates[i].StaffID]
+		candidates[i].Busy = !ok
+	}
+	host, err := lifecycle.PickHost(strategy, candidates, a.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := free[host.StaffID]
+	a.AssignmentMethod = strategy
+	return &slot, nil
+}
+
+// findFreeSlot mencari slot kosong milik host yang dimulai tepat pada waktu yang diminta
+func (s *AppointmentService) findFreeSlot(hostID int, start time.Time) (*model.Slot, error) {
+	date := start.In(s.Location).Format("2006-01-02")
+	slots, err := s.ListFreeSlots(date, date, hostID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range slots {
+		if slots[i].Start.Equal(start) {
+			return &slots[i], nil
+		}
+	}
+	return nil, ErrSlotUnavailable
+}
+
+func (s *AppointmentService) dateOf(t time.Time) time.Time {
+	date, _ := time.ParseInLocation("2006-01-02", t.In(s.Location).Format("2006-01-02"), s.Location)
+	return date
+}
+
+// manageToken membuat token link kelola yang berlaku sampai satu minggu setelah appointment selesai
+func (s *AppointmentService) manageToken(a model.Appointment) (string, error) {
+	return utils.GenerateAppointmentToken(a.ID, a.EndTime.Add(manageTokenGrace))
+}
+
+// ListAppointments mengambil semua appointment milik email user yang sedang login. Token link kelola
+// tidak disertakan; token hanya diberikan untuk satu appointment saat booking atau lookup dengan kode referensi.
+func (s *AppointmentService) ListAppointments(email string) ([]model.Appointment, error) {
+	if email == "" {
+		return nil, errors.New("email is required")
+	}
+	return s.Repo.GetAppointmentsByEmail(email)
+}
+
+// GetMyAppointment mengambil appointment milik user yang sedang login beserta timeline-nya berdasarkan
+// kode referensi, tanpa token link kelola
+func (s *AppointmentService) GetMyAppointment(email, referenceCode string) (*model.ManagedAppointment, error) {
+	if email == "" || referenceCode == "" {
+		return nil, errors.New("email and reference_code are required")
+	}
+	appt, err := s.Repo.GetAppointmentByReference(email, strings.TrimSpace(referenceCode))
+	if err != nil {
+		return nil, err
+	}
+	return s.managed(appt)
+}
+
+// LookupAppointment mengambil appointment beserta timeline-nya berdasarkan email dan kode referensi.
+// Keduanya harus cocok sehingga user hanya bisa melihat appointment miliknya sendiri. Token link kelola
+// yang dikembalikan hanya berlaku untuk appointment ini.
+func (s *AppointmentService) LookupAppointment(email, referenceCode string) (*model.ManagedAppointment, error) {
+	managed, err := s.GetMyAppointment(email, referenceCode)
+	if err != nil {
+		return nil, err
+	}
+	if managed.Appointment.ManageToken, err = s.manageToken(managed.Appointment); err != nil {
+		return nil, err
+	}
+	return managed, nil
+}
+
+// GetManagedDocument mengambil dokumen tindak lanjut appointment berdasarkan token link kelola
+func (s *AppointmentService) GetManagedDocument(token string, documentID int) (model.AppointmentDocument, error) {
+	id, err := utils.ValidateAppointmentToken(token)
+	if err != nil {
+		return model.AppointmentDocument{}, ErrInvalidManageToken
+	}
+	return s.Repo.GetDocument(id, documentID)
+}
+
+// managed melengkapi appointment dengan timeline status dan dokumen tindak lanjutnya
+func (s *AppointmentService) managed(appt model.Appointment) (*model.ManagedAppointment, error) {
+	timeline, err := s.Repo.GetStatusHistory(appt.ID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.Repo.GetDocuments(appt.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ManagedAppointment{Appointment: appt, Timeline: timeline, Documents: documents}, nil
 }
 
-func (s *AppointmentService) ListAppointments() ([]model.Appointment, error) {
-	return s.Repo.GetAppointments()
+// newReferenceCode membuat kode booking acak seperti "APT-7KQ2MX9D" tanpa karakter yang mudah tertukar
+func newReferenceCode() (string, error) {
+	const alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	code := make([]byte, len(raw))
+	for i, b := range raw {
+		code[i] = alphabet[int(b)%len(alphabet)]
+	}
+	return "APT-" + string(code), nil
 }
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
+	"go-project/pkg/moderation"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultCommentDepth = 3
+	MaxCommentDepth     = 10
+	DefaultThreadLimit  = 10
+	MaxThreadLimit      = 50
+)
+
+// CommentThreadQuery adalah opsi untuk mengambil thread komentar sebuah artikel
+type CommentThreadQuery struct {
+	ArticleID int
+	MaxDepth  int    // Kedalaman maksimum balasan yang ditampilkan (1 = hanya komentar tingkat atas)
+	Sort      string // "oldest", "newest", atau "top"
+	Page      int
+	Limit     int // Jumlah thread tingkat atas per halaman
+}
+
+// NewCommentRequest adalah komentar publik yang dikirim pembaca
+type NewCommentRequest struct {
+	ArticleID int    `json:"-"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Comment   string `json:"comment"`
+	ParentID  *int   `json:"parent_id,omitempty"`
+	IPAddress string `json:"-"`
+}
+
+// ErrInvalidParent dikembalikan jika komentar induk balasan tidak ada atau berada di artikel lain
+var ErrInvalidParent = errors.New("parent comment not found in this article")
+
+type CommentService struct {
+	Repo            *repository.CommentRepository
+	Moderator       *moderation.Pipeline
+	ReportThreshold int // Jumlah laporan sebelum komentar disembunyikan otomatis
+}
+
+func NewCommentService(repo *repository.CommentRepository, moderator *moderation.Pipeline, reportThreshold int) *CommentService {
+	return &CommentService{Repo: repo, Moderator: moderator, ReportThreshold: reportThreshold}
+}
+
+// CreateComment menyimpan komentar publik. Pipeline moderasi menentukan apakah komentar
+// langsung disetujui, ditolak, atau masuk antrian review.
+func (s *CommentService) CreateComment(req NewCommentRequest) (*model.Comment, error) {
+	if strings.TrimSpace(req.Username) == "" || strings.TrimSpace(req.Email) == "" || strings.TrimSpace(req.Comment) == "" {
+		return nil, errors.New("username, email and comment are required")
+	}
+	if req.ParentID != nil {
+		articleID, err := s.Repo.GetCommentArticleID(*req.ParentID)
+		if errors.Is(err, sql.ErrNoRows) || err == nil && articleID != req.ArticleID {
+			return nil, ErrInvalidParent
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	comment := &model.Comment{
+		ArticleID: req.ArticleID,
+		Username:  req.Username,
+		Comment:   req.Comment,
+		ParentID:  req.ParentID,
+		Status:    moderation.DecisionPending,
+	}
+
+	var result moderation.Result
+	if s.Moderator != nil {
+		var err error
+		result, err = s.Moderator.Evaluate(moderation.Input{
+			ArticleID: req.ArticleID,
+			Username:  req.Username,
+			Email:     req.Email,
+			IPAddress: req.IPAddress,
+			Content:   req.Comment,
+		})
+		if err != nil {
+			return nil, err
+		}
+		comment.Status = result.Decision
+	}
+
+	if err := s.Repo.CreateComment(comment, req.Email, req.IPAddress, result.Score, result.Reason()); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetCommentThreads menyusun komentar yang sudah disetujui menjadi pohon dan mengembalikan satu halaman thread
+func (s *CommentService) GetCommentThreads(q CommentThreadQuery) (*model.CommentThreadPage, error) {
+	if err := normalizeThreadQuery(&q); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.Repo.GetApprovedComments(q.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+	return buildThreadPage(comments, q), nil
+}
+
+// buildThreadPage menyusun daftar komentar datar menjadi pohon, mengurutkannya, lalu mengambil satu halaman
+// thread tingkat atas dengan balasan yang dipotong sampai q.MaxDepth. q harus sudah dinormalisasi.
+func buildThreadPage(comments []model.Comment, q CommentThreadQuery) *model.CommentThreadPage {
+	nodes := make(map[int]*model.CommentNode, len(comments))
+	for _, c := range comments {
+		nodes[c.ID] = &model.CommentNode{Comment: c, Replies: []*model.CommentNode{}}
+	}
+
+	// Balasan yang induknya belum disetujui ikut disembunyikan
+	var roots []*model.CommentNode
+	for _, c := range comments {
+		node := nodes[c.ID]
+		if c.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*c.ParentID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		}
+	}
+
+	for _, root := range roots {
+		countReplies(root)
+	}
+	sortThread(roots, q.Sort)
+
+	page := &model.CommentThreadPage{
+		ArticleID:    q.ArticleID,
+		Page:         q.Page,
+		Limit:        q.Limit,
+		TotalThreads: len(roots),
+		Threads:      []*model.CommentNode{},
+	}
+
+	start := (q.Page - 1) * q.Limit
+	if start >= len(roots) {
+		return page
+	}
+	end := start + q.Limit
+	if end > len(roots) {
+		end = len(roots)
+	}
+	for _, root := range roots[start:end] {
+		trimDepth(root, 1, q.MaxDepth)
+		page.Threads = append(page.Threads, root)
+	}
+	return page
+}
+
+func normalizeThreadQuery(q *CommentThreadQuery) error {
+	if q.ArticleID <= 0 {
+		return fmt.Errorf("invalid article id")
+	}
+	switch q.Sort {
+	case "":
+		q.Sort = "oldest"
+	case "oldest", "newest", "top":
+	default:
+		return fmt.Errorf("invalid sort: %s", q.Sort)
+	}
+	if q.MaxDepth <= 0 {
+		q.MaxDepth = DefaultCommentDepth
+	}
+	if q.MaxDepth > MaxCommentDepth {
+		q.MaxDepth = MaxCommentDepth
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultThreadLimit
+	}
+	if q.Limit > MaxThreadLimit {
+		q.Limit = MaxThreadLimit
+	}
+	return nil
+}
+
+// countReplies mengisi ReplyCount dan TotalReplies untuk node dan seluruh turunannya
+func countReplies(node *model.CommentNode) int {
+	node.ReplyCount = len(node.Replies)
+	node.TotalReplies = 0
+	for _, reply := range node.Replies {
+		node.TotalReplies += 1 + countReplies(reply)
+	}
+	return node.TotalReplies
+}
+
+func sortThread(nodes []*model.CommentNode, order string) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		switch order {
+		case "newest":
+			return a.CreatedAt.After(b.CreatedAt)
+		case "top":
+			if a.Likes+a.Helpful != b.Likes+b.Helpful {
+				return a.Likes+a.Helpful > b.Likes+b.Helpful
+			}
+			if a.TotalReplies != b.TotalReplies {
+				return a.TotalReplies > b.TotalReplies
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	})
+	for _, node := range nodes {
+		sortThread(node.Replies, order)
+	}
+}
+
+// trimDepth memotong balasan yang melebihi kedalaman maksimum; jumlah balasan tetap dipertahankan
+func trimDepth(node *model.CommentNode, depth, maxDepth int) {
+	if depth >= maxDepth {
+		node.Replies = []*model.CommentNode{}
+		return
+	}
+	for _, reply := range node.Replies {
+		trimDepth(reply, depth+1, maxDepth)
+	}
+}
+
+// validReactions adalah jenis reaksi yang bisa diberikan pembaca
+var validReactions = map[string]bool{"like": true, "helpful": true}
+
+// Reader adalah identitas pembaca yang memberi reaksi atau laporan. Email hanya diisi dari JWT yang
+// sudah diverifikasi; pembaca tanpa login dikenali dari IP client.
+type Reader struct {
+	Email     string
+	IPAddress string
+}
+
+// key mengembalikan kunci unik pembaca untuk satu reaksi dan satu laporan per komentar
+func (r Reader) key() (string, error) {
+	switch {
+	case r.Email != "":
+		return "user:" + r.Email, nil
+	case r.IPAddress != "":
+		return "ip:" + r.IPAddress, nil
+	}
+	return "", errors.New("reader identity is required")
+}
+
+// React menyimpan reaksi pembaca (satu reaksi per pembaca) dan mengembalikan jumlah reaksi terbaru
+func (s *CommentService) React(commentID int, reader Reader, reactionType string) (*model.ReactionCounts, error) {
+	key, err := reader.key()
+	if err != nil {
+		return nil, err
+	}
+	if !validReactions[reactionType] {
+		return nil, fmt.Errorf("invalid reaction type: %s", reactionType)
+	}
+	if err := s.Repo.SetReaction(commentID, key, reactionType); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetReactionCounts(commentID)
+}
+
+// Unreact menghapus reaksi milik pembaca itu sendiri dan mengembalikan jumlah reaksi terbaru
+func (s *CommentService) Unreact(commentID int, reader Reader) (*model.ReactionCounts, error) {
+	key, err := reader.key()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.RemoveReaction(commentID, key); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetReactionCounts(commentID)
+}
+
+// ReportComment mencatat laporan pembaca terhadap komentar kasar atau spam. Satu pembaca (email dari JWT
+// atau IP client) hanya bisa melaporkan satu komentar sekali.
+func (s *CommentService) ReportComment(commentID int, reader Reader, reason string) (*model.CommentReport, error) {
+	key, err := reader.key()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	report := &model.CommentReport{
+		CommentID:     commentID,
+		ReporterEmail: reader.Email,
+		Reason:        reason,
+	}
+	if err := s.Repo.CreateReport(report, key, reader.IPAddress, s.ReportThreshold); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"go-project/internal/user/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+// threadComments adalah daftar komentar datar seperti hasil GetApprovedComments:
+//
+//	1 ─ 2 ─ 3 ─ 4        (rantai balasan sedalam 4 tingkat)
+//	5 ─ 6, 7             (3 reaksi, balasan 7 lebih disukai dari 6)
+//	8                    (3 reaksi tanpa balasan)
+//	11
+//	9 ─ 10               (9 membalas komentar 99 yang belum disetujui)
+func threadComments() []model.Comment {
+	base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	comment := func(id, parent, minute, likes, helpful int) model.Comment {
+		c := model.Comment{ID: id, ArticleID: 1, Likes: likes, Helpful: helpful, CreatedAt: base.Add(time.Duration(minute) * time.Minute)}
+		if parent != 0 {
+			c.ParentID = &parent
+		}
+		return c
+	}
+	return []model.Comment{
+		comment(1, 0, 0, 0, 0),
+		comment(2, 1, 1, 0, 0),
+		comment(3, 2, 2, 0, 0),
+		comment(4, 3, 3, 0, 0),
+		comment(5, 0, 4, 3, 0),
+		comment(6, 5, 5, 1, 0),
+		comment(7, 5, 6, 2, 0),
+		comment(8, 0, 7, 1, 2),
+		comment(9, 99, 8, 5, 0),
+		comment(10, 9, 9, 0, 0),
+		comment(11, 0, 10, 0, 0),
+	}
+}
+
+// renderThreads menuliskan thread sebagai ID dengan balasan di dalam kurung, misalnya "1(2(3)) 5(6,7)"
+func renderThreads(nodes []*model.CommentNode) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part := fmt.Sprint(node.ID)
+		if len(node.Replies) > 0 {
+			part += "(" + strings.ReplaceAll(renderThreads(node.Replies), " ", ",") + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBuildThreadPage(t *testing.T) {
+	tests := []struct {
+		name string
+		q    CommentThreadQuery
+		want string
+	}{
+		{"default depth and order", CommentThreadQuery{}, "1(2(3)) 5(6,7) 8 11"},
+		{"top level only", CommentThreadQuery{MaxDepth: 1}, "1 5 8 11"},
+		{"two levels", CommentThreadQuery{MaxDepth: 2}, "1(2) 5(6,7) 8 11"},
+		{"depth above maximum is capped", CommentThreadQuery{MaxDepth: MaxCommentDepth + 5}, "1(2(3(4))) 5(6,7) 8 11"},
+		{"newest first", CommentThreadQuery{Sort: "newest"}, "11 8 5(7,6) 1(2(3))"},
+		{"top by reactions then replies then age", CommentThreadQuery{Sort: "top"}, "5(7,6) 8 1(2(3)) 11"},
+		{"first page", CommentThreadQuery{Limit: 2}, "1(2(3)) 5(6,7)"},
+		{"last full page", CommentThreadQuery{Limit: 2, Page: 2}, "8 11"},
+		{"partial last page", CommentThreadQuery{Limit: 3, Page: 2}, "11"},
+		{"page past the end", CommentThreadQuery{Limit: 2, Page: 3}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.q
+			q.ArticleID = 1
+			if err := normalizeThreadQuery(&q); err != nil {
+				t.Fatal(err)
+			}
+			page := buildThreadPage(threadComments(), q)
+			if got := renderThreads(page.Threads); got != tt.want {
+				t.Errorf("threads = %q, want %q", got, tt.want)
+			}
+			// Balasan yatim (9 dan 10) tidak dihitung sebagai thread
+			if page.TotalThreads != 4 {
+				t.Errorf("TotalThreads = %d, want 4", page.TotalThreads)
+			}
+			if page.Threads == nil {
+				t.Error("Threads is nil, want an empty slice")
+			}
+		})
+	}
+}
+
+func TestBuildThreadPageKeepsReplyCountsWhenTrimmed(t *testing.T) {
+	q := CommentThreadQuery{ArticleID: 1, MaxDepth: 1}
+	if err := normalizeThreadQuery(&q); err != nil {
+		t.Fatal(err)
+	}
+	page := buildThreadPage(threadComments(), q)
+
+	root := page.Threads[0]
+	if root.ID != 1 || root.ReplyCount != 1 || root.TotalReplies != 3 || len(root.Replies) != 0 {
+		t.Errorf("thread %d: reply_count = %d, total_replies = %d, %d replies shown; want thread 1 with 1, 3, 0",
+			root.ID, root.ReplyCount, root.TotalReplies, len(root.Replies))
+	}
+}
+
+func TestNormalizeThreadQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       CommentThreadQuery
+		want    CommentThreadQuery
+		wantErr bool
+	}{
+		{"defaults", CommentThreadQuery{ArticleID: 1},
+			CommentThreadQuery{ArticleID: 1, MaxDepth: DefaultCommentDepth, Sort: "oldest", Page: 1, Limit: DefaultThreadLimit}, false},
+		{"limits capped", CommentThreadQuery{ArticleID: 1, MaxDepth: 99, Sort: "top", Page: 2, Limit: 999},
+			CommentThreadQuery{ArticleID: 1, MaxDepth: MaxCommentDepth, Sort: "top", Page: 2, Limit: MaxThreadLimit}, false},
+		{"unknown sort", CommentThreadQuery{ArticleID: 1, Sort: "random"}, CommentThreadQuery{}, true},
+		{"missing article", CommentThreadQuery{}, CommentThreadQuery{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.q
+			err := normalizeThreadQuery(&q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeThreadQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && q != tt.want {
+				t.Errorf("normalizeThreadQuery() = %+v, want %+v", q, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
+	"go-project/pkg/notify"
+	"strings"
+)
+
+const (
+	DefaultNotificationLimit = 20
+	MaxNotificationLimit     = 100
+)
+
+// ErrInvalidPreference dikembalikan jika tipe atau kanal pada pengaturan notifikasi tidak valid
+var ErrInvalidPreference = errors.New("invalid notification preference")
+
+// notificationChannels adalah kanal yang bisa dibisukan pengguna
+var notificationChannels = map[string]bool{
+	notify.ChannelInApp:    true,
+	notify.ChannelWhatsApp: true,
+	notify.ChannelEmail:    true,
+}
+
+// NotificationQuery adalah parameter halaman inbox notifikasi
+type NotificationQuery struct {
+	Page       int
+	Limit      int
+	UnreadOnly bool
+}
+
+type NotificationService struct {
+	Repo *repository.NotificationRepository
+}
+
+func NewNotificationService(repo *repository.NotificationRepository) *NotificationService {
+	return &NotificationService{Repo: repo}
+}
+
+// ListNotifications mengambil satu halaman inbox milik pengguna yang sedang login
+func (s *NotificationService) ListNotifications(email string, q NotificationQuery) (*model.NotificationPage, error) {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultNotificationLimit
+	}
+	if q.Limit > MaxNotificationLimit {
+		q.Limit = MaxNotificationLimit
+	}
+
+	notifications, total, err := s.Repo.ListNotifications(email, q.UnreadOnly, q.Limit, (q.Page-1)*q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.Repo.CountUnread(email)
+	if err != nil {
+		return nil, err
+	}
+	return &model.NotificationPage{
+		Page:          q.Page,
+		Limit:         q.Limit,
+		Total:         total,
+		Unread:        unread,
+		Notifications: notifications,
+	}, nil
+}
+
+// CountUnread menghitung notifikasi yang belum dibaca, dipakai untuk badge di aplikasi
+func (s *NotificationService) CountUnread(email string) (int, error) {
+	return s.Repo.CountUnread(email)
+}
+
+// MarkRead menandai satu notifikasi sudah dibaca
+func (s *NotificationService) MarkRead(email string, id int) error {
+	return s.Repo.MarkRead(email, id)
+}
+
+// MarkAllRead menandai semua notifikasi sudah dibaca
+func (s *NotificationService) MarkAllRead(email string) (int64, error) {
+	return s.Repo.MarkAllRead(email)
+}
+
+// DeleteNotification menghapus satu notifikasi dari inbox
+func (s *NotificationService) DeleteNotification(email string, id int) error {
+	return s.Repo.DeleteNotification(email, id)
+}
+
+// GetPreferences mengambil tipe notifikasi yang dibisukan pengguna per kanal
+func (s *NotificationService) GetPreferences(email string) ([]model.NotificationPreference, error) {
+	return s.Repo.GetPreferences(email)
+}
+
+// UpdatePreferences membisukan atau menyalakan kembali tipe notifikasi di kanal tertentu.
+// Pengaturan yang tidak disebut tetap seperti sebelumnya.
+func (s *NotificationService) UpdatePreferences(email string, preferences []model.NotificationPreference) ([]model.NotificationPreference, error) {
+	for i := range preferences {
+		p := &preferences[i]
+		p.Type = strings.ToLower(strings.TrimSpace(p.Type))
+		p.Channel = strings.ToLower(strings.TrimSpace(p.Channel))
+		if p.Type == "" || len(p.Type) > 50 || !notificationChannels[p.Channel] {
+			return nil, ErrInvalidPreference
+		}
+	}
+	if err := s.Repo.SetPreferences(email, preferences); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetPreferences(email)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"go-project/internal/user/model"
+	"sort"
+	"time"
+)
+
+// MaxSlotRangeDays membatasi rentang tanggal yang bisa diminta sekaligus
+const MaxSlotRangeDays = 31
+
+type slotWindow struct {
+	start, end  string // "HH:MM"
+	slotMinutes int
+}
+
+// buildSlots menghitung slot kosong dari template mingguan, pengecualian dan slot yang sudah dibooking.
+// from dan to adalah tanggal (jam 00:00) di zona waktu loc; slot sebelum now tidak ditampilkan.
+func buildSlots(from, to time.Time, loc *time.Location, now time.Time,
+	templates []model.AvailabilityTemplate, exceptions []model.AvailabilityException, booked []model.BookedSlot) []model.Slot {
+
+	hostNames := make(map[int]string)
+	weekly := make(map[int]map[int][]slotWindow) // host -> weekday -> window
+	for _, t := range templates {
+		hostNames[t.HostID] = t.HostName
+		if weekly[t.HostID] == nil {
+			weekly[t.HostID] = make(map[int][]slotWindow)
+		}
+		weekly[t.HostID][t.Weekday] = append(weekly[t.HostID][t.Weekday], slotWindow{t.StartTime, t.EndTime, t.SlotMinutes})
+	}
+
+	overrides := make(map[int]map[string][]slotWindow) // host -> tanggal -> window (kosong = libur)
+	for _, e := range exceptions {
+		if overrides[e.HostID] == nil {
+			overrides[e.HostID] = make(map[string][]slotWindow)
+		}
+		windows := overrides[e.HostID][e.Date]
+		if e.StartTime != nil {
+			windows = append(windows, slotWindow{*e.StartTime, *e.EndTime, 0})
+		}
+		overrides[e.HostID][e.Date] = windows
+	}
+
+	taken := make(map[int][]model.BookedSlot) // host -> appointment aktif
+	for _, b := range booked {
+		taken[b.HostID] = append(taken[b.HostID], b)
+	}
+
+	slots := []model.Slot{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		for hostID, byWeekday := range weekly {
+			windows := byWeekday[int(day.Weekday())]
+			if override, ok := overrides[hostID][date]; ok {
+				windows = withDefaultSlotMinutes(override, byWeekday[int(day.Weekday())])
+			}
+			for _, w := range windows {
+				for _, start := range windowStarts(day, loc, w) {
+					end := start.Add(time.Duration(w.slotMinutes) * time.Minute)
+					if !start.After(now) || overlapsBooked(taken[hostID], start, end) {
+						continue
+					}
+					slots = append(slots, model.Slot{
+						HostID:   hostID,
+						HostName: hostNames[hostID],
+						Start:    start,
+						End:      end,
+					})
+				}
+			}
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		if !slots[i].Start.Equal(slots[j].Start) {
+			return slots[i].Start.Before(slots[j].Start)
+		}
+		return slots[i].HostID < slots[j].HostID
+	})
+	return slots
+}
+
+// overlapsBooked melaporkan apakah rentang [start, end) beririsan dengan appointment yang sudah dibooking,
+// sehingga appointment yang tidak sejajar dengan grid slot tetap menutup semua slot yang tertimpa
+func overlapsBooked(booked []model.BookedSlot, start, end time.Time) bool {
+	for _, b := range booked {
+		if b.Start.Before(end) && b.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaultSlotMinutes memakai durasi slot dari template hari itu untuk jam khusus (bawaan 60 menit)
+func withDefaultSlotMinutes(override, regular []slotWindow) []slotWindow {
+	minutes := 60
+	if len(regular) > 0 {
+		minutes = regular[0].slotMinutes
+	}
+	windows := make([]slotWindow, len(override))
+	for i, w := range override {
+		w.slotMinutes = minutes
+		windows[i] = w
+	}
+	return windows
+}
+
+// windowStarts mengembalikan waktu mulai setiap slot utuh di dalam window pada tanggal tertentu
+func windowStarts(day time.Time, loc *time.Location, w slotWindow) []time.Time {
+	start, err1 := time.ParseInLocation("2006-01-02 15:04", day.Format("2006-01-02")+" "+w.start, loc)
+	end, err2 := time.ParseInLocation("2006-01-02 15:04", day.Format("2006-01-02")+" "+w.end, loc)
+	if err1 != nil || err2 != nil || w.slotMinutes <= 0 {
+		return nil
+	}
+
+	step := time.Duration(w.slotMinutes) * time.Minute
+	var starts []time.Time
+	for t := start; !t.Add(step).After(end); t = t.Add(step) {
+		starts = append(starts, t)
+	}
+	return starts
+}
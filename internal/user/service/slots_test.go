@@ -0,0 +1,53 @@
+package service
+
+import (
+	"go-project/internal/user/model"
+	"testing"
+	"time"
+)
+
+func TestBuildSlotsSkipsOverlappingBookings(t *testing.T) {
+	loc := time.UTC
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, loc) // Senin
+	now := day.Add(-24 * time.Hour)
+	templates := []model.AvailabilityTemplate{
+		{HostID: 1, HostName: "Host", Weekday: int(time.Monday), StartTime: "09:00", EndTime: "12:00", SlotMinutes: 60},
+	}
+
+	tests := []struct {
+		name   string
+		booked []model.BookedSlot
+		want   []string
+	}{
+		{"no bookings", nil, []string{"09:00", "10:00", "11:00"}},
+		{"booking on slot boundary", []model.BookedSlot{
+			{HostID: 1, Start: day.Add(10 * time.Hour), End: day.Add(11 * time.Hour)},
+		}, []string{"09:00", "11:00"}},
+		{"booking off the slot grid", []model.BookedSlot{
+			{HostID: 1, Start: day.Add(9*time.Hour + 30*time.Minute), End: day.Add(10*time.Hour + 30*time.Minute)},
+		}, []string{"11:00"}},
+		{"booking ending at slot start", []model.BookedSlot{
+			{HostID: 1, Start: day.Add(8 * time.Hour), End: day.Add(9 * time.Hour)},
+		}, []string{"09:00", "10:00", "11:00"}},
+		{"booking of another host", []model.BookedSlot{
+			{HostID: 2, Start: day.Add(9 * time.Hour), End: day.Add(12 * time.Hour)},
+		}, []string{"09:00", "10:00", "11:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := buildSlots(day, day, loc, now, templates, nil, tt.booked)
+			var got []string
+			for _, slot := range slots {
+				got = append(got, slot.Start.Format("15:04"))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("slots = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("slots = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"go-project/config"
+	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
+	"go-project/pkg/calendar"
+	"go-project/pkg/certificate"
+	"go-project/pkg/webinar"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrWebinarNotScheduled dikembalikan jika webinar belum memiliki jadwal
+	ErrWebinarNotScheduled = calendar.ErrWebinarNotScheduled
+	// ErrRegistrationClosed dikembalikan jika batas pendaftaran webinar sudah lewat
+	// atau webinar tidak berstatus scheduled
+	ErrRegistrationClosed = repository.ErrRegistrationClosed
+	// ErrNotAttending dikembalikan jika pendaftar di daftar tunggu atau yang sudah batal mencoba check-in
+	ErrNotAttending = errors.New("only registered attendees can check in")
+	// ErrCheckInClosed dikembalikan jika webinar tidak sedang berlangsung atau host belum membuka check-in
+	ErrCheckInClosed = errors.New("check-in is not open for this webinar")
+	// ErrInvalidCheckInCode dikembalikan jika kode check-in salah
+	ErrInvalidCheckInCode = errors.New("invalid check-in code")
+	// ErrNotCheckedIn dikembalikan jika peserta check-out sebelum check-in
+	ErrNotCheckedIn = errors.New("attendee has not checked in")
+)
+
+type WebinarService struct {
+	Repo *repository.WebinarRepository
+}
+
+func NewWebinarService(repo *repository.WebinarRepository) *WebinarService {
+	return &WebinarService{Repo: repo}
+}
+
+// GetWebinar mengambil detail webinar beserta jumlah pendaftar
+func (s *WebinarService) GetWebinar(id int) (model.Webinar, error) {
+	return s.Repo.GetWebinarByID(id)
+}
+
+// Register mendaftarkan seseorang ke webinar. Pendaftar masuk daftar tunggu jika kapasitas sudah penuh.
+func (s *WebinarService) Register(webinarID int, reg *model.WebinarRegistration) error {
+	reg.Name = strings.TrimSpace(reg.Name)
+	reg.Email = strings.TrimSpace(reg.Email)
+	if reg.Name == "" || reg.Email == "" {
+		return errors.New("name and email are required")
+	}
+	if _, err := mail.ParseAddress(reg.Email); err != nil {
+		return errors.New("invalid email address")
+	}
+
+	w, err := s.Repo.GetWebinarByID(webinarID)
+	if err != nil {
+		return err
+	}
+	if w.StartTime == nil {
+		return ErrWebinarNotScheduled
+	}
+	deadline := *w.StartTime
+	if w.RegistrationDeadline != nil {
+		deadline = *w.RegistrationDeadline
+	}
+	if !time.Now().Before(deadline) {
+		return ErrRegistrationClosed
+	}
+
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return err
+	}
+	reg.WebinarID = webinarID
+	reg.CancelToken = hex.EncodeToString(raw)
+	return s.Repo.Register(reg)
+}
+
+// GetRegistration mengambil status pendaftaran berdasarkan token pembatalan
+func (s *WebinarService) GetRegistration(token string) (model.WebinarRegistration, error) {
+	if token == "" {
+		return model.WebinarRegistration{}, sql.ErrNoRows
+	}
+	return s.Repo.GetRegistrationByToken(token)
+}
+
+// CancelRegistration membatalkan pendaftaran; kursi yang kosong diberikan ke daftar tunggu
+func (s *WebinarService) CancelRegistration(token string) error {
+	if token == "" {
+		return sql.ErrNoRows
+	}
+	return s.Repo.CancelRegistration(token)
+}
+
+// CheckIn mencatat kehadiran peserta dengan kode check-in yang dibagikan host selama sesi
+func (s *WebinarService) CheckIn(token, code string) error {
+	reg, err := s.GetRegistration(token)
+	if err != nil {
+		return err
+	}
+	if reg.Status != repository.RegistrationRegistered {
+		return ErrNotAttending
+	}
+	status, current, err := s.Repo.GetCheckInCode(reg.WebinarID)
+	if err != nil {
+		return err
+	}
+	if status != webinar.StatusLive || current == "" {
+		return ErrCheckInClosed
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(current)) != 1 {
+		return ErrInvalidCheckInCode
+	}
+	return s.Repo.AddCheckIn(reg.ID, reg.WebinarID)
+}
+
+// CheckOut mencatat waktu peserta meninggalkan webinar yang sedang berlangsung sehingga persentase
+// kehadiran dihitung sampai waktu tersebut
+func (s *WebinarService) CheckOut(token string) error {
+	reg, err := s.GetRegistration(token)
+	if err != nil {
+		return err
+	}
+	if reg.Status != repository.RegistrationRegistered {
+		return ErrNotAttending
+	}
+	status, _, err := s.Repo.GetCheckInCode(reg.WebinarID)
+	if err != nil {
+		return err
+	}
+	if status != webinar.StatusLive {
+		return ErrCheckInClosed
+	}
+	err = s.Repo.AddCheckOut(reg.ID, reg.WebinarID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotCheckedIn
+	}
+	return err
+}
+
+// GetCertificate mengambil sertifikat berdasarkan kodenya untuk verifikasi
+func (s *WebinarService) GetCertificate(code string) (model.Certificate, error) {
+	c, err := s.Repo.GetCertificateByCode(webinar.NormalizeCertificateCode(code))
+	c.VerifyURL = CertificateURL(c.Code)
+	return c, err
+}
+
+// GetMyCertificate mengambil sertifikat milik pendaftar berdasarkan token pendaftarannya
+func (s *WebinarService) GetMyCertificate(token string) (model.Certificate, error) {
+	if token == "" {
+		return model.Certificate{}, sql.ErrNoRows
+	}
+	c, err := s.Repo.GetCertificateByToken(token)
+	c.VerifyURL = CertificateURL(c.Code)
+	return c, err
+}
+
+// CertificateURL adalah alamat publik untuk memverifikasi sertifikat
+func CertificateURL(code string) string {
+	return config.BaseURL() + "/user/certificates/" + code
+}
+
+// CertificatePDF menyiapkan data sertifikat untuk dicetak
+func CertificatePDF(c model.Certificate) certificate.Certificate {
+	return certificate.Certificate{
+		Code:         c.Code,
+		Name:         c.Name,
+		WebinarTitle: c.WebinarTitle,
+		HostName:     c.HostName,
+		Date:         c.WebinarDate.In(config.Location()),
+		VerifyURL:    c.VerifyURL,
+	}
+}
+
+// GetWebinarCalendar membuat file iCalendar untuk webinar
+func (s *WebinarService) GetWebinarCalendar(id int) (calendar.Calendar, error) {
+	w, err := s.Repo.GetWebinarByID(id)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	return calendar.WebinarCalendar(calendar.Webinar{
+		ID:          w.ID,
+		Title:       w.Title,
+		Description: w.Description,
+		LinkMeet:    w.LinkMeet,
+		Status:      w.Status,
+		Start:       w.StartTime,
+		End:         w.EndTime,
+	})
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-project/config"
+	"go-project/internal/user/model"
+	"go-project/internal/user/repository"
+	lifecycle "go-project/pkg/appointment"
+	"go-project/pkg/notify"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Tindakan dari kata kunci balasan WhatsApp
+const (
+	ReplyActionConfirm = "confirm"
+	ReplyActionCancel  = "cancel"
+)
+
+// Hasil pemrosesan pesan WhatsApp masuk, disimpan di kolom result
+const (
+	ReplyResultConfirmed        = "confirmed"
+	ReplyResultCancelled        = "cancelled"
+	ReplyResultAlreadyConfirmed = "already_confirmed"
+	ReplyResultRejected         = "rejected"
+	ReplyResultNoAppointment    = "no_appointment"
+	ReplyResultUnknownKeyword   = "unknown_keyword"
+	ReplyResultFailed           = "failed"
+)
+
+// replyKeywords memetakan kata pertama balasan (huruf besar) ke tindakannya
+var replyKeywords = map[string]string{
+	"YA":         ReplyActionConfirm,
+	"Y":          ReplyActionConfirm,
+	"YES":        ReplyActionConfirm,
+	"KONFIRMASI": ReplyActionConfirm,
+	"CONFIRM":    ReplyActionConfirm,
+	"BATAL":      ReplyActionCancel,
+	"CANCEL":     ReplyActionCancel,
+}
+
+// replyTimeFormat sama dengan format waktu pada pesan pengingat
+const replyTimeFormat = "02 Jan 2006 15:04 MST"
+
+type WhatsAppService struct {
+	Repo      *repository.WhatsAppRepository
+	Templates *notify.Templates // Template balasan untuk pengirim, bisa diubah admin
+	Location  *time.Location    // Zona waktu jadwal appointment pada balasan
+}
+
+func NewWhatsAppService(repo *repository.WhatsAppRepository, templates *notify.Templates) *WhatsAppService {
+	return &WhatsAppService{Repo: repo, Templates: templates, Location: config.Location()}
+}
+
+// ParseReplyKeyword mengembalikan tindakan dari kata pertama pesan, atau string kosong jika tidak dikenal
+func ParseReplyKeyword(body string) string {
+	words := strings.FieldsFunc(strings.ToUpper(body), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	return replyKeywords[words[0]]
+}
+
+// HandleInbound menyimpan pesan masuk lalu mengonfirmasi atau membatalkan appointment terdekat milik
+// pengirim sesuai kata kunci. Balasan untuk pengirim dikembalikan. Pesan yang sudah pernah diproses
+// tidak diproses ulang dan balasan sebelumnya dikembalikan.
+func (s *WhatsAppService) HandleInbound(msg model.WhatsAppMessage) (string, error) {
+	if msg.MessageSID == "" || msg.From == "" {
+		return "", errors.New("MessageSid and From are required")
+	}
+	created, err := s.Repo.SaveMessage(&msg)
+	if err != nil {
+		return "", err
+	}
+	if !created {
+		return msg.Reply, nil
+	}
+
+	s.process(&msg)
+	if err := s.Repo.UpdateMessageResult(msg); err != nil {
+		return "", err
+	}
+	return msg.Reply, nil
+}
+
+// process menentukan tindakan, menjalankannya dan mengisi hasil serta balasan pada msg
+func (s *WhatsAppService) process(msg *model.WhatsAppMessage) {
+	msg.Action = ParseReplyKeyword(msg.Body)
+
+	appt, err := s.Repo.FindReplyAppointment(msg.From)
+	if errors.Is(err, sql.ErrNoRows) {
+		msg.Result = ReplyResultNoAppointment
+		s.reply(msg, notify.EventWhatsAppReplyNoAppointment, notify.WhatsAppReplyVars{})
+		return
+	}
+	if err != nil {
+		s.reject(msg, err)
+		return
+	}
+	msg.AppointmentID = appt.ID
+	vars := notify.WhatsAppReplyVars{Time: appt.Time.In(s.Location).Format(replyTimeFormat)}
+
+	switch msg.Action {
+	case ReplyActionConfirm:
+		if appt.Status == lifecycle.StatusConfirmed {
+			msg.Result = ReplyResultAlreadyConfirmed
+			s.reply(msg, notify.EventWhatsAppReplyAlreadyConfirmed, vars)
+			return
+		}
+		if err := s.Repo.TransitionByWhatsApp(appt.ID, lifecycle.StatusConfirmed, "Dikonfirmasi lewat balasan WhatsApp"); err != nil {
+			s.reject(msg, err)
+			return
+		}
+		msg.Result = ReplyResultConfirmed
+		s.reply(msg, notify.EventWhatsAppReplyConfirmed, vars)
+	case ReplyActionCancel:
+		err := lifecycle.CheckCutoff(appt.Time, time.Now())
+		if err == nil {
+			err = s.Repo.TransitionByWhatsApp(appt.ID, lifecycle.StatusCancelled, "Dibatalkan lewat balasan WhatsApp")
+		}
+		if err != nil {
+			s.reject(msg, err)
+			return
+		}
+		msg.Result = ReplyResultCancelled
+		s.reply(msg, notify.EventWhatsAppReplyCancelled, vars)
+	default:
+		msg.Result = ReplyResultUnknownKeyword
+		s.reply(msg, notify.EventWhatsAppReplyUnknownKeyword, vars)
+	}
+}
+
+// reject mencatat tindakan yang tidak bisa dijalankan beserta alasannya untuk pengirim
+func (s *WhatsAppService) reject(msg *model.WhatsAppMessage, err error) {
+	msg.Result = ReplyResultRejected
+	switch {
+	case errors.Is(err, lifecycle.ErrCutoffPassed):
+		s.reply(msg, notify.EventWhatsAppReplyCutoffPassed, notify.WhatsAppReplyVars{Cutoff: lifecycle.CancelCutoff().String()})
+	case errors.Is(err, lifecycle.ErrInvalidTransition):
+		s.reply(msg, notify.EventWhatsAppReplyInvalidTransition, notify.WhatsAppReplyVars{})
+	default:
+		log.Printf("whatsapp inbound %s: %v", msg.MessageSID, err)
+		msg.Result = ReplyResultFailed
+		s.reply(msg, notify.EventWhatsAppReplyFailed, notify.WhatsAppReplyVars{})
+	}
+}
+
+// reply mengisi balasan untuk pengirim dari template registry. Jika template tidak bisa dirender,
+// template bawaan dipakai agar pengirim tetap mendapat balasan.
+func (s *WhatsAppService) reply(msg *model.WhatsAppMessage, event string, vars notify.WhatsAppReplyVars) {
+	data, err := notify.Vars(vars)
+	if err == nil {
+		_, msg.Reply, err = s.Templates.Render(context.Background(), event, notify.DefaultLocale, data)
+	}
+	if err != nil {
+		log.Printf("whatsapp reply %s: %v", event, err)
+		_, msg.Reply, _ = notify.NewTemplates(nil).Render(context.Background(), event, notify.DefaultLocale, data)
+	}
+}
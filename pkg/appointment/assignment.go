@@ -0,0 +1,99 @@
+package appointment
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Strategi penentuan host otomatis untuk appointment baru
+const (
+	StrategyRoundRobin  = "round_robin"  // host yang paling lama tidak mendapat appointment
+	StrategyLeastLoaded = "least_loaded" // host dengan appointment paling sedikit pada hari yang sama
+	StrategySpecialty   = "specialty"    // host yang menangani kategori appointment, lalu least_loaded
+)
+
+// Metode penentuan host yang disimpan di kolom appointments.assignment_method
+const AssignmentManual = "manual"
+
+// DefaultDuration dipakai untuk appointment yang belum memiliki end_time
+const DefaultDuration = 60 * time.Minute
+
+// ErrNoHostAvailable dikembalikan jika tidak ada staff yang kosong untuk waktu appointment
+var ErrNoHostAvailable = errors.New("no staff is available at the requested time")
+
+// Candidate adalah staff yang bisa ditugaskan sebagai host beserta beban kerjanya
+type Candidate struct {
+	StaffID        int
+	Name           string
+	Load           int       // jumlah appointment aktif pada hari yang sama
+	LastAssignedAt time.Time // kosong jika belum pernah ditugaskan
+	Specialties    []int     // ID kategori yang ditangani staff
+	Busy           bool      // sudah memiliki appointment lain yang bentrok
+}
+
+// IsValidStrategy memeriksa apakah nama strategi dikenal
+func IsValidStrategy(strategy string) bool {
+	switch strategy {
+	case StrategyRoundRobin, StrategyLeastLoaded, StrategySpecialty:
+		return true
+	}
+	return false
+}
+
+// PickHost memilih host dari kandidat yang tidak bentrok sesuai strategi
+func PickHost(strategy string, candidates []Candidate, categoryID int) (Candidate, error) {
+	var free []Candidate
+	for _, c := range candidates {
+		if !c.Busy {
+			free = append(free, c)
+		}
+	}
+	if len(free) == 0 {
+		return Candidate{}, ErrNoHostAvailable
+	}
+
+	switch strategy {
+	case StrategyRoundRobin:
+		sort.SliceStable(free, func(i, j int) bool { return assignedBefore(free[i], free[j]) })
+		return free[0], nil
+	case StrategySpecialty:
+		if categoryID > 0 {
+			var specialists []Candidate
+			for _, c := range free {
+				if hasSpecialty(c, categoryID) {
+					specialists = append(specialists, c)
+				}
+			}
+			// Jika tidak ada staff dengan spesialisasi yang sesuai, pilih dari semua staff
+			if len(specialists) > 0 {
+				free = specialists
+			}
+		}
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		if free[i].Load != free[j].Load {
+			return free[i].Load < free[j].Load
+		}
+		return assignedBefore(free[i], free[j])
+	})
+	return free[0], nil
+}
+
+// assignedBefore mengurutkan staff yang paling lama tidak ditugaskan lebih dulu, lalu berdasarkan ID
+func assignedBefore(a, b Candidate) bool {
+	if !a.LastAssignedAt.Equal(b.LastAssignedAt) {
+		return a.LastAssignedAt.Before(b.LastAssignedAt)
+	}
+	return a.StaffID < b.StaffID
+}
+
+func hasSpecialty(c Candidate, categoryID int) bool {
+	for _, id := range c.Specialties {
+		if id == categoryID {
+			return true
+		}
+	}
+	return false
+}
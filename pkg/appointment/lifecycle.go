@@ -0,0 +1,82 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status appointment sesuai kolom status di tabel appointments
+const (
+	StatusPending     = "pending"
+	StatusConfirmed   = "confirmed"
+	StatusRescheduled = "rescheduled"
+	StatusCompleted   = "completed"
+	StatusCancelled   = "cancelled"
+	StatusNoShow      = "no-show"
+)
+
+var (
+	// ErrInvalidTransition dikembalikan jika perpindahan status tidak diperbolehkan
+	ErrInvalidTransition = errors.New("invalid appointment status transition")
+	// ErrCutoffPassed dikembalikan jika appointment sudah terlalu dekat dengan waktu mulai untuk diubah user
+	ErrCutoffPassed = errors.New("appointment can no longer be changed")
+)
+
+// transitions adalah perpindahan status yang diperbolehkan. Completed, cancelled dan no-show adalah status akhir.
+var transitions = map[string][]string{
+	StatusPending:     {StatusConfirmed, StatusRescheduled, StatusCancelled},
+	StatusConfirmed:   {StatusRescheduled, StatusCompleted, StatusCancelled, StatusNoShow},
+	StatusRescheduled: {StatusConfirmed, StatusRescheduled, StatusCompleted, StatusCancelled, StatusNoShow},
+}
+
+// IsValidStatus memeriksa apakah status dikenal
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusConfirmed, StatusRescheduled, StatusCompleted, StatusCancelled, StatusNoShow:
+		return true
+	}
+	return false
+}
+
+// ValidateTransition mengembalikan error jika status tidak boleh berpindah dari `from` ke `to`
+func ValidateTransition(from, to string) error {
+	if !IsValidStatus(to) {
+		return fmt.Errorf("%w: unknown status %s", ErrInvalidTransition, to)
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+// DefaultCancelCutoff adalah batas cut-off bawaan sebelum appointment dimulai
+const DefaultCancelCutoff = 24 * time.Hour
+
+// cancelCutoff adalah batas waktu sebelum appointment dimulai di mana user tidak bisa lagi
+// membatalkan atau menjadwal ulang sendiri
+var cancelCutoff = DefaultCancelCutoff
+
+// ConfigureCancelCutoff mengatur batas cut-off pembatalan dan penjadwalan ulang oleh user
+// (appointments.cancel_cutoff); nilai negatif tetap memakai nilai sebelumnya
+func ConfigureCancelCutoff(cutoff time.Duration) {
+	if cutoff >= 0 {
+		cancelCutoff = cutoff
+	}
+}
+
+// CancelCutoff mengembalikan batas cut-off pembatalan dan penjadwalan ulang oleh user
+func CancelCutoff() time.Duration {
+	return cancelCutoff
+}
+
+// CheckCutoff mengembalikan error jika appointment yang dimulai pada `start` sudah melewati batas cut-off
+func CheckCutoff(start, now time.Time) error {
+	cutoff := CancelCutoff()
+	if now.Add(cutoff).After(start) {
+		return fmt.Errorf("%w: changes must be made at least %s before the appointment starts", ErrCutoffPassed, cutoff)
+	}
+	return nil
+}
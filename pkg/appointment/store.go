@@ -0,0 +1,47 @@
+package appointment
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// LoadCandidates mengambil semua staff aktif beserta beban kerja pada hari appointment dan apakah
+// jadwalnya bentrok dengan rentang [start, end). excludeID mengabaikan appointment itu sendiri
+// saat host-nya diganti.
+func LoadCandidates(db *sql.DB, start, end time.Time, day string, excludeID int) ([]Candidate, error) {
+	query := `SELECT u.id, COALESCE(u.name, u.email),
+			  (SELECT COUNT(*) FROM appointments a
+			   WHERE a.host_id = u.id AND a.status <> 'cancelled' AND a.date_of_booking = $1::date AND a.id <> $4),
+			  (SELECT MAX(a.host_assigned_at) FROM appointments a WHERE a.host_id = u.id),
+			  COALESCE((SELECT JSON_AGG(s.category_id) FROM staff_specialties s WHERE s.staff_id = u.id), '[]'),
+			  EXISTS (SELECT 1 FROM appointments a
+			          WHERE a.host_id = u.id AND a.status <> 'cancelled' AND a.id <> $4
+			          AND a.time < $3 AND COALESCE(a.end_time, a.time + $5 * interval '1 minute') > $2)
+			  FROM users u
+			  WHERE u.role = 'staff' AND COALESCE(u.status, 'active') = 'active'
+			  ORDER BY u.id`
+	rows, err := db.Query(query, day, start, end, excludeID, int(DefaultDuration/time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []Candidate
+	for rows.Next() {
+		var c Candidate
+		var lastAssigned sql.NullTime
+		var specialties []byte
+		if err := rows.Scan(&c.StaffID, &c.Name, &c.Load, &lastAssigned, &specialties, &c.Busy); err != nil {
+			return nil, err
+		}
+		if lastAssigned.Valid {
+			c.LastAssignedAt = lastAssigned.Time
+		}
+		if err := json.Unmarshal(specialties, &c.Specialties); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
@@ -0,0 +1,157 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Status event iCalendar
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// ContentType adalah MIME type untuk file .ics
+const ContentType = "text/calendar; charset=utf-8"
+
+// Event adalah satu sesi (appointment atau webinar) dalam file iCalendar
+type Event struct {
+	UID         string // ID unik yang stabil, misalnya "appointment-12@domain"
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Status      string
+	Organizer   string // email organizer
+	Attendees   []string
+	Updated     time.Time // LAST-MODIFIED agar klien kalender memperbarui event
+}
+
+// Calendar adalah kumpulan event yang ditulis sebagai satu VCALENDAR
+type Calendar struct {
+	Name   string
+	Method string // "PUBLISH" untuk feed/unduhan, "REQUEST" untuk undangan email
+	Events []Event
+}
+
+// Bytes menghasilkan isi file .ics sesuai RFC 5545
+func (c Calendar) Bytes() []byte {
+	var b strings.Builder
+	now := time.Now().UTC().Format(icsTimeFormat)
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//go-project//edukasi//ID")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	method := c.Method
+	if method == "" {
+		method = "PUBLISH"
+	}
+	writeLine(&b, "METHOD:"+method)
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+now)
+		writeLine(&b, "DTSTART:"+e.Start.UTC().Format(icsTimeFormat))
+		writeLine(&b, "DTEND:"+e.End.UTC().Format(icsTimeFormat))
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(e.Location))
+		}
+		if e.URL != "" {
+			writeLine(&b, "URL:"+e.URL)
+		}
+		if e.Status != "" {
+			writeLine(&b, "STATUS:"+e.Status)
+		}
+		if !e.Updated.IsZero() {
+			writeLine(&b, "LAST-MODIFIED:"+e.Updated.UTC().Format(icsTimeFormat))
+		}
+		if e.Organizer != "" {
+			writeLine(&b, "ORGANIZER:mailto:"+e.Organizer)
+		}
+		for _, attendee := range e.Attendees {
+			writeLine(&b, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:"+attendee)
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeText meng-escape karakter khusus pada nilai TEXT iCalendar
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// writeLine menulis satu content line dan melipatnya setiap 75 oktet seperti yang diwajibkan RFC 5545
+func writeLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Jangan memotong di tengah karakter UTF-8
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // baris lanjutan diawali satu spasi
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// AppointmentUID mengembalikan UID event yang stabil untuk sebuah appointment
+func AppointmentUID(id int) string {
+	return fmt.Sprintf("appointment-%d@%s", id, UIDDomain())
+}
+
+// WebinarUID mengembalikan UID event yang stabil untuk sebuah webinar
+func WebinarUID(id int) string {
+	return fmt.Sprintf("webinar-%d@%s", id, UIDDomain())
+}
+
+// AppointmentStatus memetakan status appointment ke STATUS iCalendar
+func AppointmentStatus(status string) string {
+	switch status {
+	case "cancelled", "no-show":
+		return StatusCancelled
+	case "pending":
+		return StatusTentative
+	}
+	return StatusConfirmed
+}
+
+// Filename membersihkan nama file .ics untuk header Content-Disposition
+func Filename(name string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+	return clean + ".ics"
+}
+
+// Serve menulis kalender sebagai unduhan .ics
+func Serve(w http.ResponseWriter, name string, cal Calendar) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+Filename(name)+`"`)
+	w.Write(cal.Bytes())
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MeetingRequest berisi data sesi yang membutuhkan link meeting
+type MeetingRequest struct {
+	Title     string
+	Start     time.Time
+	End       time.Time
+	HostEmail string
+}
+
+// Meeting adalah link meeting yang dibuat provider
+type Meeting struct {
+	ID  string
+	URL string
+}
+
+// MeetingProvider membuat link meeting untuk appointment dan webinar
+type MeetingProvider interface {
+	CreateMeeting(ctx context.Context, req MeetingRequest) (Meeting, error)
+}
+
+// JitsiProvider membuat ruang Jitsi Meet dengan nama acak, tanpa perlu API key
+type JitsiProvider struct {
+	BaseURL string // bawaan https://meet.jit.si
+	Prefix  string
+}
+
+func (p *JitsiProvider) CreateMeeting(ctx context.Context, req MeetingRequest) (Meeting, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return Meeting{}, err
+	}
+	room := p.Prefix + hex.EncodeToString(suffix)
+	return Meeting{ID: room, URL: strings.TrimRight(p.BaseURL, "/") + "/" + room}, nil
+}
+
+// FakeProvider adalah MeetingProvider lokal yang membuat link berurutan dan mencatat setiap permintaan.
+// Dipakai untuk pengujian dan pengembangan tanpa layanan meeting sungguhan.
+type FakeProvider struct {
+	BaseURL string
+
+	mu       sync.Mutex
+	Requests []MeetingRequest
+	Err      error // jika diisi, CreateMeeting mengembalikan error ini
+}
+
+func (p *FakeProvider) CreateMeeting(ctx context.Context, req MeetingRequest) (Meeting, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Err != nil {
+		return Meeting{}, p.Err
+	}
+	p.Requests = append(p.Requests, req)
+	id := fmt.Sprintf("fake-%d", len(p.Requests))
+	base := p.BaseURL
+	if base == "" {
+		base = "https://meet.local"
+	}
+	return Meeting{ID: id, URL: strings.TrimRight(base, "/") + "/" + id}, nil
+}
+
+// Nama provider link meeting yang didukung NewMeetingProvider
+const (
+	ProviderJitsi = "jitsi"
+	ProviderFake  = "fake"
+)
+
+// DefaultJitsiBaseURL adalah server Jitsi Meet publik yang dipakai jika base URL kosong
+const DefaultJitsiBaseURL = "https://meet.jit.si"
+
+// NewMeetingProvider membuat provider sesuai nama ("jitsi" atau "fake") dengan base URL ruang meeting.
+// Mengembalikan nil jika nama kosong sehingga pembuatan link otomatis tidak aktif.
+func NewMeetingProvider(name, baseURL string) MeetingProvider {
+	switch name {
+	case ProviderJitsi:
+		if baseURL == "" {
+			baseURL = DefaultJitsiBaseURL
+		}
+		return &JitsiProvider{BaseURL: baseURL, Prefix: "edukasi-"}
+	case ProviderFake:
+		return &FakeProvider{BaseURL: baseURL}
+	}
+	return nil
+}
+
+// uidDomain adalah domain untuk UID event iCalendar
+var uidDomain = "go-project.local"
+
+// ConfigureUIDDomain mengatur domain UID event iCalendar; nilai kosong tetap memakai go-project.local
+func ConfigureUIDDomain(domain string) {
+	if domain != "" {
+		uidDomain = domain
+	}
+}
+
+// UIDDomain mengembalikan domain untuk UID event iCalendar
+func UIDDomain() string {
+	return uidDomain
+}
@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeProviderCreateMeeting(t *testing.T) {
+	p := &FakeProvider{BaseURL: "https://meet.test/"}
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	req := MeetingRequest{Title: "Appointment Budi", Start: start, End: start.Add(time.Hour), HostEmail: "host@example.com"}
+
+	for i, want := range []string{"https://meet.test/fake-1", "https://meet.test/fake-2"} {
+		meeting, err := p.CreateMeeting(context.Background(), req)
+		if err != nil {
+			t.Fatalf("CreateMeeting #%d: %v", i+1, err)
+		}
+		if meeting.URL != want {
+			t.Errorf("CreateMeeting #%d URL = %q, want %q", i+1, meeting.URL, want)
+		}
+	}
+	if len(p.Requests) != 2 || p.Requests[0] != req {
+		t.Errorf("Requests = %+v, want two copies of %+v", p.Requests, req)
+	}
+
+	p.Err = errors.New("provider down")
+	if _, err := p.CreateMeeting(context.Background(), req); !errors.Is(err, p.Err) {
+		t.Errorf("CreateMeeting error = %v, want %v", err, p.Err)
+	}
+	if len(p.Requests) != 2 {
+		t.Errorf("failed request recorded, got %d requests", len(p.Requests))
+	}
+}
+
+func TestNewMeetingProvider(t *testing.T) {
+	if p := NewMeetingProvider("", ""); p != nil {
+		t.Errorf("NewMeetingProvider(\"\") = %T, want nil", p)
+	}
+	jitsi, ok := NewMeetingProvider(ProviderJitsi, "").(*JitsiProvider)
+	if !ok || jitsi.BaseURL != DefaultJitsiBaseURL {
+		t.Errorf("NewMeetingProvider(jitsi) = %+v, want base URL %s", jitsi, DefaultJitsiBaseURL)
+	}
+	meeting, err := jitsi.CreateMeeting(context.Background(), MeetingRequest{})
+	if err != nil || !strings.HasPrefix(meeting.URL, DefaultJitsiBaseURL+"/edukasi-") {
+		t.Errorf("jitsi CreateMeeting = %+v, %v", meeting, err)
+	}
+	if _, ok := NewMeetingProvider(ProviderFake, "").(*FakeProvider); !ok {
+		t.Error("NewMeetingProvider(fake) is not a *FakeProvider")
+	}
+}
+
+func TestWebinarCalendar(t *testing.T) {
+	if _, err := WebinarCalendar(Webinar{ID: 1, Title: "Belum terjadwal"}); !errors.Is(err, ErrWebinarNotScheduled) {
+		t.Errorf("unscheduled webinar error = %v, want ErrWebinarNotScheduled", err)
+	}
+
+	start := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	cal, err := WebinarCalendar(Webinar{ID: 7, Title: "Webinar Gizi", Status: "cancelled", Start: &start, End: &end})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ics := string(cal.Bytes())
+	for _, want := range []string{"UID:webinar-7@" + UIDDomain(), "DTSTART:20260101T030000Z", "STATUS:CANCELLED"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar missing %q:\n%s", want, ics)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package calendar
+
+import (
+	"errors"
+	"go-project/pkg/webinar"
+	"time"
+)
+
+// ErrWebinarNotScheduled dikembalikan jika webinar belum memiliki jadwal mulai dan selesai
+var ErrWebinarNotScheduled = errors.New("webinar has no schedule")
+
+// Webinar berisi data webinar yang dibutuhkan untuk event iCalendar
+type Webinar struct {
+	ID          int
+	Title       string
+	Description string
+	LinkMeet    string
+	Status      string
+	Start       *time.Time
+	End         *time.Time
+}
+
+// WebinarCalendar membuat file iCalendar berisi satu webinar yang sudah memiliki jadwal
+func WebinarCalendar(w Webinar) (Calendar, error) {
+	if w.Start == nil || w.End == nil {
+		return Calendar{}, ErrWebinarNotScheduled
+	}
+	return Calendar{Name: w.Title, Events: []Event{WebinarEvent(w)}}, nil
+}
+
+// WebinarEvent mengubah webinar terjadwal menjadi event iCalendar
+func WebinarEvent(w Webinar) Event {
+	return Event{
+		UID:         WebinarUID(w.ID),
+		Summary:     w.Title,
+		Description: w.Description,
+		Location:    w.LinkMeet,
+		URL:         w.LinkMeet,
+		Start:       *w.Start,
+		End:         *w.End,
+		Status:      WebinarStatus(w.Status),
+	}
+}
+
+// WebinarStatus memetakan status webinar ke STATUS iCalendar
+func WebinarStatus(status string) string {
+	switch status {
+	case webinar.StatusCancelled:
+		return StatusCancelled
+	case webinar.StatusDraft:
+		return StatusTentative
+	}
+	return StatusConfirmed
+}
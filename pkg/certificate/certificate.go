@@ -0,0 +1,149 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ContentType adalah MIME type file sertifikat
+const ContentType = "application/pdf"
+
+// Certificate adalah data yang dicetak pada sertifikat kehadiran webinar
+type Certificate struct {
+	Code         string // ID sertifikat yang bisa diverifikasi
+	Name         string // Nama peserta
+	WebinarTitle string
+	HostName     string
+	Date         time.Time // Tanggal webinar
+	VerifyURL    string    // Alamat untuk memverifikasi keaslian sertifikat
+}
+
+// Ukuran halaman A4 landscape dalam point
+const (
+	pageWidth  = 842
+	pageHeight = 595
+	maxWidth   = 700
+)
+
+// PDF menghasilkan sertifikat satu halaman dalam format PDF. Font Go Regular dan Go Bold disematkan
+// sebagai font Unicode sehingga nama dan judul dengan huruf non-Latin tercetak apa adanya.
+func (c Certificate) PDF() []byte {
+	p := &page{glyphs: newGlyphs()}
+
+	// Bingkai ganda
+	p.content.WriteString("0.12 0.29 0.49 RG 4 w 30 30 782 535 re S 1 w 42 42 758 511 re S\n")
+
+	y := 470.0
+	p.centered(boldFont, 34, y, "SERTIFIKAT KEHADIRAN")
+	y -= 55
+	p.centered(regularFont, 15, y, "Diberikan kepada")
+	y -= 48
+	p.centered(boldFont, 28, y, c.Name)
+	y -= 42
+	p.centered(regularFont, 15, y, "atas kehadirannya dalam webinar")
+	for _, line := range p.wrap(boldFont, c.WebinarTitle, 20, maxWidth) {
+		y -= 32
+		p.centered(boldFont, 20, y, line)
+	}
+	y -= 34
+	p.centered(regularFont, 14, y, "yang diselenggarakan pada "+FormatDate(c.Date))
+	if c.HostName != "" {
+		y -= 22
+		p.centered(regularFont, 14, y, "bersama "+c.HostName)
+	}
+
+	p.text(regularFont, 10, 60, 80, "ID Sertifikat: "+c.Code)
+	if c.VerifyURL != "" {
+		p.text(regularFont, 10, 60, 64, "Verifikasi: "+c.VerifyURL)
+	}
+
+	return p.document()
+}
+
+// page adalah isi halaman sertifikat beserta glyph yang dipakai
+type page struct {
+	content bytes.Buffer
+	glyphs  *glyphs
+}
+
+// document menyusun objek PDF, tabel xref dan trailer untuk satu halaman
+func (p *page) document() []byte {
+	stream := p.content.Bytes()
+	var fontRefs strings.Builder
+	for i, f := range fonts {
+		fmt.Fprintf(&fontRefs, "/%s %d 0 R ", f.resource, 5+i*5)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents 4 0 R "+
+			"/Resources << /Font << %s>> >> >>", pageWidth, pageHeight, fontRefs.String()),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+	}
+	for i, f := range fonts {
+		objects = append(objects, p.glyphs.objects(f, 5+i*5)...)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func (p *page) text(f *embeddedFont, size, x, y float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.0f Tf 0.1 0.1 0.1 rg %.2f %.2f Td %s Tj ET\n", f.resource, size, x, y, p.glyphs.encode(f, s))
+}
+
+func (p *page) centered(f *embeddedFont, size, y float64, s string) {
+	p.text(f, size, (pageWidth-p.glyphs.width(f, s, size))/2, y, s)
+}
+
+// wrap memecah teks menjadi beberapa baris agar tidak melebihi lebar maksimum
+func (p *page) wrap(f *embeddedFont, s string, size, max float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		next := word
+		if line != "" {
+			next = line + " " + word
+		}
+		if line != "" && p.glyphs.width(f, next, size) > max {
+			lines = append(lines, line)
+			next = word
+		}
+		line = next
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+var months = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// FormatDate menulis tanggal dalam format Indonesia, misalnya "19 Oktober 2026"
+func FormatDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), months[t.Month()-1], t.Year())
+}
+
+// Serve menulis sertifikat sebagai unduhan PDF
+func Serve(w http.ResponseWriter, c Certificate) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="sertifikat-`+c.Code+`.pdf"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(c.PDF())
+}
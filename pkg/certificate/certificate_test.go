@@ -0,0 +1,57 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPDFEmbedsUnicodeFont(t *testing.T) {
+	c := Certificate{
+		Code:         "CERT-TEST",
+		Name:         "Анна Łukasiewicz",
+		WebinarTitle: "Gizi Seimbang untuk Keluarga",
+		Date:         time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}
+	pdf := c.PDF()
+
+	for _, want := range []string{"/Encoding /Identity-H", "/FontFile2", "/ToUnicode"} {
+		if !bytes.Contains(pdf, []byte(want)) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+	// Huruf Kiril dan Polandia harus dipetakan kembali ke Unicode, bukan diganti '?'
+	for _, r := range []rune{'А', 'н', 'Ł'} {
+		if !bytes.Contains(pdf, []byte(fmt.Sprintf("> <%04X>", r))) {
+			t.Errorf("ToUnicode map missing U+%04X", r)
+		}
+	}
+}
+
+func TestPDFCrossReferenceOffsets(t *testing.T) {
+	pdf := Certificate{Code: "CERT-TEST", Name: "Budi", WebinarTitle: "Webinar"}.PDF()
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("PDF has no startxref trailer")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	if len(entries) == 0 {
+		t.Fatal("xref table has no entries")
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj\n", i+1)
+		if !bytes.HasPrefix(pdf[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, pdf[off:off+len(want)], want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package certificate
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// embeddedFont adalah font TrueType yang disematkan ke PDF sebagai font Type0 dengan encoding Identity-H.
+// Teks ditulis sebagai ID glyph sehingga nama dan judul di luar Latin-1 tetap tercetak dengan benar.
+type embeddedFont struct {
+	name       string // nama PostScript di PDF
+	resource   string // nama font di resource halaman, misalnya F1
+	sfnt       *sfnt.Font
+	compressed []byte // file TTF terkompresi untuk FontFile2
+	length     int    // ukuran file TTF sebelum dikompresi
+	stemV      int
+}
+
+// Font bawaan sertifikat: Go Regular (F1) dan Go Bold (F2)
+var (
+	regularFont = loadFont("GoRegular", "F1", goregular.TTF, 80)
+	boldFont    = loadFont("GoBold", "F2", gobold.TTF, 140)
+	fonts       = []*embeddedFont{regularFont, boldFont}
+)
+
+// unitsPerEm membuat ukuran glyph dari sfnt langsung dalam satuan 1/1000 em seperti yang dipakai PDF
+var unitsPerEm = fixed.I(1000)
+
+func loadFont(name, resource string, ttf []byte, stemV int) *embeddedFont {
+	f, err := sfnt.Parse(ttf)
+	if err != nil {
+		panic(fmt.Sprintf("certificate: parse font %s: %v", name, err))
+	}
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(ttf)
+	zw.Close()
+	return &embeddedFont{name: name, resource: resource, sfnt: f, compressed: compressed.Bytes(), length: len(ttf), stemV: stemV}
+}
+
+// glyphs mencatat glyph yang dipakai di satu dokumen untuk tabel lebar (/W) dan ToUnicode
+type glyphs struct {
+	buf  sfnt.Buffer
+	used map[*embeddedFont]map[sfnt.GlyphIndex]rune
+}
+
+func newGlyphs() *glyphs {
+	return &glyphs{used: map[*embeddedFont]map[sfnt.GlyphIndex]rune{}}
+}
+
+// encode mengubah teks menjadi string hex ID glyph 2 byte untuk operator Tj.
+// Karakter yang tidak ada di font memakai glyph .notdef.
+func (g *glyphs) encode(f *embeddedFont, s string) string {
+	if g.used[f] == nil {
+		g.used[f] = map[sfnt.GlyphIndex]rune{}
+	}
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, r := range s {
+		gid, err := f.sfnt.GlyphIndex(&g.buf, r)
+		if err != nil {
+			gid = 0
+		}
+		if _, ok := g.used[f][gid]; !ok {
+			g.used[f][gid] = r
+		}
+		fmt.Fprintf(&b, "%04X", uint16(gid))
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// advance mengembalikan lebar glyph dalam satuan 1/1000 em
+func (g *glyphs) advance(f *embeddedFont, gid sfnt.GlyphIndex) int {
+	adv, err := f.sfnt.GlyphAdvance(&g.buf, gid, unitsPerEm, font.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return adv.Round()
+}
+
+// width menghitung lebar teks dalam point untuk ukuran font tertentu
+func (g *glyphs) width(f *embeddedFont, s string, size float64) float64 {
+	total := 0
+	for _, r := range s {
+		gid, err := f.sfnt.GlyphIndex(&g.buf, r)
+		if err != nil {
+			gid = 0
+		}
+		total += g.advance(f, gid)
+	}
+	return float64(total) * size / 1000
+}
+
+// objects menghasilkan objek PDF untuk font mulai dari nomor objek first: font Type0, CIDFont,
+// font descriptor, file font dan CMap ToUnicode
+func (g *glyphs) objects(f *embeddedFont, first int) []string {
+	metrics, _ := f.sfnt.Metrics(&g.buf, unitsPerEm, font.HintingNone)
+	bounds, _ := f.sfnt.Bounds(&g.buf, unitsPerEm, font.HintingNone)
+
+	used := g.used[f]
+	ids := make([]int, 0, len(used))
+	for gid := range used {
+		ids = append(ids, int(gid))
+	}
+	sort.Ints(ids)
+	var widths strings.Builder
+	for _, gid := range ids {
+		fmt.Fprintf(&widths, "%d [%d] ", gid, g.advance(f, sfnt.GlyphIndex(gid)))
+	}
+	toUnicode := unicodeCMap(ids, used)
+
+	return []string{
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			f.name, first+1, first+4),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /W [%s] >>", f.name, first+2, widths.String()),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 "+
+			"/Ascent %d /Descent %d /CapHeight %d /StemV %d /FontFile2 %d 0 R >>", f.name,
+			bounds.Min.X.Round(), -bounds.Max.Y.Round(), bounds.Max.X.Round(), -bounds.Min.Y.Round(),
+			metrics.Ascent.Round(), -metrics.Descent.Round(), metrics.CapHeight.Round(), f.stemV, first+3),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(f.compressed), f.length, f.compressed),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(toUnicode), toUnicode),
+	}
+}
+
+// unicodeCMap memetakan ID glyph kembali ke karakter Unicode agar teks sertifikat bisa disalin dan dicari
+func unicodeCMap(ids []int, used map[sfnt.GlyphIndex]rune) string {
+	var entries []string
+	for _, gid := range ids {
+		if gid == 0 {
+			continue // .notdef tidak mewakili satu karakter tertentu
+		}
+		var hex strings.Builder
+		for _, unit := range utf16.Encode([]rune{used[sfnt.GlyphIndex(gid)]}) {
+			fmt.Fprintf(&hex, "%04X", unit)
+		}
+		entries = append(entries, fmt.Sprintf("<%04X> <%s>", gid, hex.String()))
+	}
+
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// Satu blok bfchar berisi paling banyak 100 entri
+	for start := 0; start < len(entries); start += 100 {
+		end := min(start+100, len(entries))
+		fmt.Fprintf(&b, "%d beginbfchar\n%s\nendbfchar\n", end-start, strings.Join(entries[start:end], "\n"))
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend")
+	return b.String()
+}
@@ -10,24 +10,90 @@ import (
 
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
+		if r.Header.Get("Authorization") == "" {
 			http.Error(w, "Authorization header required", http.StatusUnauthorized)
 			return
 		}
+		authenticate(w, r, next)
+	})
+}
 
-		// Validasi token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		claims, err := utils.ValidateJWT(tokenString)
-		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			log.Println("Invalid token:", err)
+// OptionalAuth seperti AuthMiddleware, tetapi request tanpa header Authorization tetap diteruskan tanpa user.
+// Token yang dikirim tetap harus valid agar identitas pembaca tidak bisa dipalsukan.
+func OptionalAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
 			return
 		}
+		authenticate(w, r, next)
+	})
+}
+
+// AdminOnly seperti AuthMiddleware, tetapi hanya meneruskan user dengan peran admin
+func AdminOnly(next http.Handler) http.Handler {
+	return RequireRole("admin")(next)
+}
+
+// RequireRole membuat middleware yang mewajibkan token login dengan salah satu peran yang diberikan.
+// Token tanpa peran yang sesuai ditolak dengan 403.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		allowed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role := GetUserRole(r.Context())
+			for _, want := range roles {
+				if role == want {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		})
+		return AuthMiddleware(allowed)
+	}
+}
+
+// authenticate memvalidasi token dari header Authorization lalu meneruskan request dengan email dan peran user di context
+func authenticate(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	// Validasi token
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	claims, err := utils.ValidateJWT(tokenString)
+	if err != nil {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		log.Println("Invalid token:", err)
+		return
+	}
 
-		// Menambahkan klaim ke context untuk digunakan di handler berikutnya
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, "user", claims.Subject)
-		next.ServeHTTP(w, r.WithContext(ctx))
+	// Menambahkan klaim ke context untuk digunakan di handler berikutnya
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, "user", claims.Subject)
+	ctx = context.WithValue(ctx, "role", claims.Role)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUserEmail mengambil email user yang disimpan AuthMiddleware di context
+func GetUserEmail(ctx context.Context) string {
+	email, _ := ctx.Value("user").(string)
+	return email
+}
+
+// GetUserRole mengambil peran user dari token login yang disimpan AuthMiddleware di context
+func GetUserRole(ctx context.Context) string {
+	role, _ := ctx.Value("role").(string)
+	return role
+}
+
+// StreamTicketAuth mewajibkan tiket stream dari query parameter ticket lalu meneruskan request dengan email
+// user di context. Hanya untuk endpoint stream, karena EventSource dan WebSocket di browser tidak bisa
+// mengirim header. Tiket dibuat dari token login dan berlaku sebentar, sehingga token login tidak
+// pernah muncul di URL dan log akses.
+func StreamTicketAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		email, err := utils.ValidateStreamTicket(r.URL.Query().Get("ticket"))
+		if err != nil {
+			http.Error(w, "Invalid stream ticket", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "user", email)))
 	})
 }
@@ -0,0 +1,333 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []string // String() migrasi yang dimuat, berurutan
+		wantErr string
+		errIs   error
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"m/0010_add_index.up.sql":    file("CREATE INDEX"),
+				"m/0010_add_index.down.sql":  file("DROP INDEX"),
+				"m/0002_add_users.up.sql":    file("CREATE TABLE"),
+				"m/0002_add_users.down.sql":  file("DROP TABLE"),
+				"m/README.md":                file("not a migration"),
+				"m/0003_folder.up.sql/x.sql": file("ignored"),
+			},
+			want: []string{"0002_add_users", "0010_add_index"},
+		},
+		{
+			name:    "invalid file name",
+			files:   fstest.MapFS{"m/0001_AddUsers.up.sql": file("CREATE TABLE")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "missing version",
+			files:   fstest.MapFS{"m/add_users.up.sql": file("CREATE TABLE")},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"m/0001_add_users.up.sql":   file("CREATE TABLE"),
+				"m/0001_add_users.down.sql": file("DROP TABLE"),
+				"m/0001_add_posts.up.sql":   file("CREATE TABLE"),
+			},
+			wantErr: "migration version 1 is used by both",
+		},
+		{
+			name:    "missing down file",
+			files:   fstest.MapFS{"m/0001_add_users.up.sql": file("CREATE TABLE")},
+			wantErr: "must have non-empty up and down files",
+		},
+		{
+			name: "empty down file",
+			files: fstest.MapFS{
+				"m/0001_add_users.up.sql":   file("CREATE TABLE"),
+				"m/0001_add_users.down.sql": file("  \n"),
+			},
+			wantErr: "must have non-empty up and down files",
+		},
+		{
+			name:  "no migrations",
+			files: fstest.MapFS{"m/README.md": file("nothing here")},
+			errIs: ErrNoMigrations,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			migrations, err := Load(c.files, "m")
+			switch {
+			case c.errIs != nil:
+				if !errors.Is(err, c.errIs) {
+					t.Fatalf("Load error = %v, want %v", err, c.errIs)
+				}
+				return
+			case c.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Fatalf("Load error = %v, want it to contain %q", err, c.wantErr)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Load: %v", err)
+			}
+			var got []string
+			for _, m := range migrations {
+				got = append(got, m.String())
+			}
+			if strings.Join(got, ",") != strings.Join(c.want, ",") {
+				t.Errorf("Load = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// threeMigrations berisi tiga migrasi; SQL up migrasi yang mengandung FAIL gagal dijalankan fakeDB
+func threeMigrations(t *testing.T, secondUp string) []Migration {
+	t.Helper()
+	migrations, err := Load(fstest.MapFS{
+		"m/0001_one.up.sql":     file("UP 1"),
+		"m/0001_one.down.sql":   file("DOWN 1"),
+		"m/0002_two.up.sql":     file(secondUp),
+		"m/0002_two.down.sql":   file("DOWN 2"),
+		"m/0003_three.up.sql":   file("UP 3"),
+		"m/0003_three.down.sql": file("DOWN 3"),
+	}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return migrations
+}
+
+func versions(migrations []Migration) []int64 {
+	out := make([]int64, 0, len(migrations))
+	for _, m := range migrations {
+		out = append(out, m.Version)
+	}
+	return out
+}
+
+func TestUpAndDownSteps(t *testing.T) {
+	db := newFakeDB()
+	m := &Migrator{DB: sql.OpenDB(db), Migrations: threeMigrations(t, "UP 2"), Settings: map[string]string{"app.timezone": "UTC"}}
+	ctx := context.Background()
+
+	steps := []struct {
+		name    string
+		run     func() ([]Migration, error)
+		done    []int64
+		applied []int64
+	}{
+		{"up one step", func() ([]Migration, error) { return m.Up(ctx, 1) }, []int64{1}, []int64{1}},
+		{"up the rest", func() ([]Migration, error) { return m.Up(ctx, 0) }, []int64{2, 3}, []int64{1, 2, 3}},
+		{"up with nothing pending", func() ([]Migration, error) { return m.Up(ctx, 0) }, []int64{}, []int64{1, 2, 3}},
+		{"down defaults to one step", func() ([]Migration, error) { return m.Down(ctx, 0) }, []int64{3}, []int64{1, 2}},
+		{"down more steps than applied", func() ([]Migration, error) { return m.Down(ctx, 5) }, []int64{2, 1}, []int64{}},
+	}
+	for _, s := range steps {
+		done, err := s.run()
+		if err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if got := versions(done); !slices.Equal(got, s.done) {
+			t.Errorf("%s: done = %v, want %v", s.name, got, s.done)
+		}
+		if got := db.appliedVersions(); !slices.Equal(got, s.applied) {
+			t.Errorf("%s: applied = %v, want %v", s.name, got, s.applied)
+		}
+	}
+
+	wantScripts := "UP 1|UP 2|UP 3|DOWN 3|DOWN 2|DOWN 1"
+	if got := strings.Join(db.scripts, "|"); got != wantScripts {
+		t.Errorf("scripts = %s, want %s", got, wantScripts)
+	}
+	if db.settings["app.timezone"] != "UTC" {
+		t.Errorf("app.timezone setting = %q, want UTC", db.settings["app.timezone"])
+	}
+}
+
+func TestUpStopsAtFailingMigration(t *testing.T) {
+	db := newFakeDB()
+	m := &Migrator{DB: sql.OpenDB(db), Migrations: threeMigrations(t, "FAIL 2")}
+
+	done, err := m.Up(context.Background(), 0)
+	if err == nil || !strings.Contains(err.Error(), "0002_two up") {
+		t.Fatalf("Up error = %v, want failure in 0002_two", err)
+	}
+	if got := versions(done); !slices.Equal(got, []int64{1}) {
+		t.Errorf("done = %v, want [1]", got)
+	}
+	if got := db.appliedVersions(); !slices.Equal(got, []int64{1}) {
+		t.Errorf("applied = %v, want [1]; the failed migration must not be recorded", got)
+	}
+}
+
+func TestDownMissingFile(t *testing.T) {
+	db := newFakeDB()
+	db.applied[99] = appliedRow{name: "gone", checksum: "x", appliedAt: time.Now()}
+	m := &Migrator{DB: sql.OpenDB(db), Migrations: threeMigrations(t, "UP 2")}
+
+	if _, err := m.Down(context.Background(), 1); !errors.Is(err, ErrMissingFile) {
+		t.Errorf("Down error = %v, want ErrMissingFile", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	up, down, err := Create(dir, "Add Users!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(up) != "0001_add_users.up.sql" || filepath.Base(down) != "0001_add_users.down.sql" {
+		t.Errorf("Create = %s, %s; want 0001_add_users up and down", up, down)
+	}
+	// File kosong belum lolos Load, jadi isi dulu sebelum membuat migrasi berikutnya
+	for _, name := range []string{up, down} {
+		if err := os.WriteFile(name, []byte("SELECT 1;"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	up, _, err = Create(dir, "add-posts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(up) != "0002_add_posts.up.sql" {
+		t.Errorf("second Create = %s, want 0002_add_posts.up.sql", up)
+	}
+
+	if _, _, err := Create(dir, "!!!"); err == nil {
+		t.Error("Create accepted a name without letters or digits")
+	}
+}
+
+// fakeDB adalah driver database/sql di memori yang memahami query Migrator, agar urutan dan jumlah
+// langkah migrasi bisa diuji tanpa PostgreSQL. Perubahan schema_migrations dalam transaksi baru
+// berlaku saat commit.
+type fakeDB struct {
+	mu       sync.Mutex
+	applied  map[int64]appliedRow
+	scripts  []string
+	settings map[string]string
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{applied: map[int64]appliedRow{}, settings: map[string]string{}}
+}
+
+func (db *fakeDB) appliedVersions() []int64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	out := []int64{}
+	for v := range db.applied {
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db      *fakeDB
+	pending []func()
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { c.pending = nil; return c, nil }
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	for _, fn := range c.pending {
+		fn()
+	}
+	c.db.mu.Unlock()
+	c.pending = nil
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.pending = nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	db := c.db
+	switch {
+	case strings.Contains(query, "pg_advisory"), strings.Contains(query, "CREATE TABLE IF NOT EXISTS schema_migrations"):
+	case strings.Contains(query, "set_config"):
+		name, value := args[0].Value.(string), args[1].Value.(string)
+		c.pending = append(c.pending, func() { db.settings[name] = value })
+	case strings.HasPrefix(query, "INSERT INTO schema_migrations"):
+		version, name, checksum := args[0].Value.(int64), args[1].Value.(string), args[2].Value.(string)
+		c.pending = append(c.pending, func() {
+			db.applied[version] = appliedRow{name: name, checksum: checksum, appliedAt: time.Now()}
+		})
+	case strings.HasPrefix(query, "DELETE FROM schema_migrations"):
+		version := args[0].Value.(int64)
+		c.pending = append(c.pending, func() { delete(db.applied, version) })
+	case strings.Contains(query, "FAIL"):
+		return nil, errors.New("syntax error")
+	default:
+		c.pending = append(c.pending, func() { db.scripts = append(db.scripts, query) })
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.HasPrefix(query, "SELECT version, name, checksum, applied_at FROM schema_migrations") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	rows := &fakeRows{}
+	for version, row := range c.db.applied {
+		rows.values = append(rows.values, []driver.Value{version, row.name, row.checksum, row.appliedAt})
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version", "name", "checksum", "applied_at"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,127 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bannedWordCheck memberi skor untuk setiap kata terlarang yang ditemukan
+type bannedWordCheck struct {
+	patterns map[string]*regexp.Regexp
+}
+
+// NewBannedWordCheck membuat check kata terlarang dari daftar kata per bahasa
+func NewBannedWordCheck(words map[string][]string) Check {
+	patterns := make(map[string]*regexp.Regexp)
+	for _, list := range words {
+		for _, word := range list {
+			word = strings.ToLower(strings.TrimSpace(word))
+			if word == "" {
+				continue
+			}
+			patterns[word] = regexp.MustCompile(`(^|\W)` + regexp.QuoteMeta(word) + `($|\W)`)
+		}
+	}
+	return &bannedWordCheck{patterns: patterns}
+}
+
+func (c *bannedWordCheck) Name() string { return "banned_words" }
+
+func (c *bannedWordCheck) Check(in Input) (float64, string, error) {
+	content := strings.ToLower(in.Content + " " + in.Username)
+	var found []string
+	for word, pattern := range c.patterns {
+		if pattern.MatchString(content) {
+			found = append(found, word)
+		}
+	}
+	if len(found) == 0 {
+		return 0, "", nil
+	}
+	sort.Strings(found)
+	return 0.6 * float64(len(found)), fmt.Sprintf("contains banned words (%s)", strings.Join(found, ", ")), nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// linkCountCheck memberi skor jika komentar berisi terlalu banyak link
+type linkCountCheck struct {
+	maxLinks int
+}
+
+// NewLinkCountCheck membuat check jumlah link
+func NewLinkCountCheck(maxLinks int) Check {
+	return &linkCountCheck{maxLinks: maxLinks}
+}
+
+func (c *linkCountCheck) Name() string { return "link_count" }
+
+func (c *linkCountCheck) Check(in Input) (float64, string, error) {
+	links := len(linkPattern.FindAllString(in.Content, -1))
+	if links <= c.maxLinks {
+		return 0, "", nil
+	}
+	return 0.5 + 0.25*float64(links-c.maxLinks-1), fmt.Sprintf("%d links (max %d)", links, c.maxLinks), nil
+}
+
+// duplicateCheck memberi skor jika penulis yang sama sudah mengirim isi yang sama
+type duplicateCheck struct {
+	store  Store
+	window time.Duration
+}
+
+// NewDuplicateCheck membuat check konten duplikat
+func NewDuplicateCheck(store Store, window time.Duration) Check {
+	return &duplicateCheck{store: store, window: window}
+}
+
+func (c *duplicateCheck) Name() string { return "duplicate" }
+
+func (c *duplicateCheck) Check(in Input) (float64, string, error) {
+	recent, err := c.store.RecentComments(in.Email, in.IPAddress, time.Now().Add(-c.window))
+	if err != nil {
+		return 0, "", err
+	}
+	content := normalizeContent(in.Content)
+	for _, previous := range recent {
+		if normalizeContent(previous) == content {
+			return 0.8, "same content was already posted recently", nil
+		}
+	}
+	return 0, "", nil
+}
+
+// rateLimitCheck langsung menolak jika email/IP mengirim terlalu banyak komentar
+type rateLimitCheck struct {
+	store  Store
+	limit  int
+	window time.Duration
+}
+
+// NewRateLimitCheck membuat check rate limit per email/IP
+func NewRateLimitCheck(store Store, limit int, window time.Duration) Check {
+	return &rateLimitCheck{store: store, limit: limit, window: window}
+}
+
+func (c *rateLimitCheck) Name() string { return "rate_limit" }
+
+func (c *rateLimitCheck) Check(in Input) (float64, string, error) {
+	if c.limit <= 0 {
+		return 0, "", nil
+	}
+	recent, err := c.store.RecentComments(in.Email, in.IPAddress, time.Now().Add(-c.window))
+	if err != nil {
+		return 0, "", err
+	}
+	if len(recent) < c.limit {
+		return 0, "", nil
+	}
+	return 1.0, fmt.Sprintf("%d comments within %s", len(recent), c.window), nil
+}
+
+func normalizeContent(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
@@ -0,0 +1,32 @@
+package moderation
+
+import "time"
+
+// Config mengatur ambang skor dan parameter setiap check moderasi
+type Config struct {
+	ApproveBelow    float64             // Skor di bawah nilai ini otomatis disetujui
+	RejectAt        float64             // Skor mulai nilai ini otomatis ditolak
+	MaxLinks        int                 // Jumlah link yang masih diperbolehkan dalam satu komentar
+	DuplicateWindow time.Duration       // Rentang waktu pengecekan komentar duplikat
+	RateLimit       int                 // Jumlah komentar maksimum per email/IP dalam RateWindow
+	RateWindow      time.Duration       // Rentang waktu rate limit
+	BannedWords     map[string][]string // Daftar kata terlarang per bahasa ("id", "en")
+	ReportThreshold int                 // Jumlah laporan pembaca sebelum komentar disembunyikan otomatis
+}
+
+// DefaultConfig mengembalikan konfigurasi moderasi bawaan
+func DefaultConfig() Config {
+	return Config{
+		ApproveBelow:    0.3,
+		RejectAt:        1.0,
+		MaxLinks:        2,
+		DuplicateWindow: 24 * time.Hour,
+		RateLimit:       5,
+		RateWindow:      10 * time.Minute,
+		ReportThreshold: 3,
+		BannedWords: map[string][]string{
+			"id": {"anjing", "bangsat", "bajingan", "kontol", "memek", "goblok", "tolol", "judi online", "slot gacor", "togel"},
+			"en": {"fuck", "shit", "bitch", "asshole", "viagra", "casino", "porn", "free money"},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package moderation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Keputusan moderasi otomatis, sama dengan nilai kolom status di tabel comments
+const (
+	DecisionApproved = "approved"
+	DecisionPending  = "pending"
+	DecisionRejected = "rejected"
+)
+
+// Input adalah data komentar yang dinilai oleh pipeline moderasi
+type Input struct {
+	ArticleID int
+	Username  string
+	Email     string
+	IPAddress string
+	Content   string
+}
+
+// Result adalah hasil penilaian pipeline: total skor, keputusan dan alasan dari setiap check
+type Result struct {
+	Score    float64  `json:"score"`
+	Decision string   `json:"decision"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+// Reason menggabungkan semua alasan menjadi satu string untuk disimpan di database
+func (r Result) Reason() string {
+	return strings.Join(r.Reasons, "; ")
+}
+
+// Check adalah satu aturan moderasi. Skor 0 berarti bersih; semakin tinggi semakin mencurigakan.
+type Check interface {
+	Name() string
+	Check(in Input) (score float64, reason string, err error)
+}
+
+// Pipeline menjalankan semua check secara berurutan dan menjumlahkan skornya
+type Pipeline struct {
+	checks []Check
+	config Config
+}
+
+// NewPipeline membuat pipeline dengan check yang diberikan
+func NewPipeline(cfg Config, checks ...Check) *Pipeline {
+	return &Pipeline{checks: checks, config: cfg}
+}
+
+// NewDefaultPipeline membuat pipeline dengan check bawaan: kata terlarang, jumlah link,
+// konten duplikat dan rate limit per email/IP
+func NewDefaultPipeline(cfg Config, store Store) *Pipeline {
+	return NewPipeline(cfg,
+		NewBannedWordCheck(cfg.BannedWords),
+		NewLinkCountCheck(cfg.MaxLinks),
+		NewDuplicateCheck(store, cfg.DuplicateWindow),
+		NewRateLimitCheck(store, cfg.RateLimit, cfg.RateWindow),
+	)
+}
+
+// Evaluate menilai komentar. Skor di bawah ApproveBelow langsung disetujui, skor di atas atau sama
+// dengan RejectAt langsung ditolak, sisanya masuk antrian review manual.
+func (p *Pipeline) Evaluate(in Input) (Result, error) {
+	var result Result
+	for _, check := range p.checks {
+		score, reason, err := check.Check(in)
+		if err != nil {
+			return result, fmt.Errorf("moderation check %s failed: %w", check.Name(), err)
+		}
+		if score > 0 {
+			result.Score += score
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", check.Name(), reason))
+		}
+	}
+
+	switch {
+	case result.Score >= p.config.RejectAt:
+		result.Decision = DecisionRejected
+	case result.Score < p.config.ApproveBelow:
+		result.Decision = DecisionApproved
+	default:
+		result.Decision = DecisionPending
+	}
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeStore mengembalikan riwayat komentar tetap dan mencatat batas waktu yang diminta
+type fakeStore struct {
+	comments []string
+	err      error
+	since    time.Time
+}
+
+func (s *fakeStore) RecentComments(email, ipAddress string, since time.Time) ([]string, error) {
+	s.since = since
+	return s.comments, s.err
+}
+
+// fixedCheck adalah check dengan skor tetap untuk menguji ambang keputusan pipeline
+type fixedCheck struct {
+	name  string
+	score float64
+	err   error
+}
+
+func (c fixedCheck) Name() string { return c.name }
+
+func (c fixedCheck) Check(Input) (float64, string, error) {
+	return c.score, "fixed", c.err
+}
+
+func TestChecks(t *testing.T) {
+	words := map[string][]string{"id": {"judi online", "tolol"}, "en": {"casino", " "}}
+	history := &fakeStore{comments: []string{"Great  ARTICLE, thanks", "first", "second", "third"}}
+
+	tests := []struct {
+		name       string
+		check      Check
+		in         Input
+		wantScore  float64
+		wantReason string
+	}{
+		{"clean content", NewBannedWordCheck(words), Input{Content: "Artikel yang sangat membantu"}, 0, ""},
+		{"banned word", NewBannedWordCheck(words), Input{Content: "Dasar TOLOL!"}, 0.6, "contains banned words (tolol)"},
+		{"banned phrase and word in username", NewBannedWordCheck(words), Input{Content: "main judi online yuk", Username: "Casino King"},
+			1.2, "contains banned words (casino, judi online)"},
+		{"banned word inside another word", NewBannedWordCheck(words), Input{Content: "casinos and pretolol"}, 0, ""},
+		{"links within limit", NewLinkCountCheck(2), Input{Content: "see https://a.example and www.b.example"}, 0, ""},
+		{"one link over limit", NewLinkCountCheck(2), Input{Content: "http://a.example https://b.example www.c.example"},
+			0.5, "3 links (max 2)"},
+		{"three links over limit", NewLinkCountCheck(0), Input{Content: "http://a.example http://b.example http://c.example"},
+			1.0, "3 links (max 0)"},
+		{"duplicate ignores case and spacing", NewDuplicateCheck(history, time.Hour), Input{Content: "great article,   thanks"},
+			0.8, "same content was already posted recently"},
+		{"not a duplicate", NewDuplicateCheck(history, time.Hour), Input{Content: "great article"}, 0, ""},
+		{"below rate limit", NewRateLimitCheck(history, 5, time.Minute), Input{Content: "hi"}, 0, ""},
+		{"at rate limit", NewRateLimitCheck(history, 4, time.Minute), Input{Content: "hi"}, 1.0, "4 comments within 1m0s"},
+		{"rate limit disabled", NewRateLimitCheck(history, 0, time.Minute), Input{Content: "hi"}, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reason, err := tt.check.Check(tt.in)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if score != tt.wantScore || reason != tt.wantReason {
+				t.Errorf("Check() = %v, %q; want %v, %q", score, reason, tt.wantScore, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestStoreChecksUseWindowAndErrors(t *testing.T) {
+	storeErr := errors.New("db down")
+	for _, newCheck := range []func(Store) Check{
+		func(s Store) Check { return NewDuplicateCheck(s, time.Hour) },
+		func(s Store) Check { return NewRateLimitCheck(s, 1, time.Hour) },
+	} {
+		store := &fakeStore{}
+		check := newCheck(store)
+		before := time.Now().Add(-time.Hour)
+		if _, _, err := check.Check(Input{Content: "hi"}); err != nil {
+			t.Fatalf("%s: Check() error = %v", check.Name(), err)
+		}
+		if store.since.Before(before) || store.since.After(time.Now().Add(-time.Hour)) {
+			t.Errorf("%s: since = %s, want one window before now", check.Name(), store.since)
+		}
+
+		store.err = storeErr
+		if _, _, err := check.Check(Input{Content: "hi"}); !errors.Is(err, storeErr) {
+			t.Errorf("%s: Check() error = %v, want %v", check.Name(), err, storeErr)
+		}
+	}
+}
+
+func TestPipelineEvaluate(t *testing.T) {
+	cfg := Config{ApproveBelow: 0.3, RejectAt: 1.0}
+
+	tests := []struct {
+		name         string
+		scores       []float64
+		wantDecision string
+		wantReasons  int
+	}{
+		{"no score", []float64{0, 0}, DecisionApproved, 0},
+		{"just below approve threshold", []float64{0.29}, DecisionApproved, 1},
+		{"at approve threshold", []float64{0.3}, DecisionPending, 1},
+		{"just below reject threshold", []float64{0.5, 0.49}, DecisionPending, 2},
+		{"scores add up to reject threshold", []float64{0.6, 0, 0.4}, DecisionRejected, 2},
+		{"single check over reject threshold", []float64{1.2}, DecisionRejected, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks []Check
+			for i, score := range tt.scores {
+				checks = append(checks, fixedCheck{name: string(rune('a' + i)), score: score})
+			}
+			result, err := NewPipeline(cfg, checks...).Evaluate(Input{})
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if result.Decision != tt.wantDecision {
+				t.Errorf("Decision = %s (score %v), want %s", result.Decision, result.Score, tt.wantDecision)
+			}
+			if len(result.Reasons) != tt.wantReasons {
+				t.Errorf("Reasons = %v, want %d reasons", result.Reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestPipelineEvaluateStopsOnCheckError(t *testing.T) {
+	checkErr := errors.New("store unavailable")
+	p := NewPipeline(DefaultConfig(), fixedCheck{name: "links", score: 0.5}, fixedCheck{name: "duplicate", err: checkErr})
+
+	_, err := p.Evaluate(Input{})
+	if !errors.Is(err, checkErr) || !strings.Contains(err.Error(), "moderation check duplicate failed") {
+		t.Errorf("Evaluate() error = %v, want wrapped %v naming the check", err, checkErr)
+	}
+}
+
+func TestDefaultPipeline(t *testing.T) {
+	store := &fakeStore{comments: []string{"Buy now at http://a.example"}}
+	p := NewDefaultPipeline(DefaultConfig(), store)
+
+	tests := []struct {
+		name         string
+		in           Input
+		wantDecision string
+	}{
+		{"clean comment", Input{Content: "Terima kasih, artikelnya jelas"}, DecisionApproved},
+		{"too many links", Input{Content: "http://a.example http://b.example http://c.example"}, DecisionPending},
+		{"banned word", Input{Content: "buy now at http://b.example casino"}, DecisionPending},
+		{"duplicate with banned word", Input{Content: "Buy now at http://a.example", Username: "casino"}, DecisionRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.Evaluate(tt.in)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if result.Decision != tt.wantDecision {
+				t.Errorf("Decision = %s (score %v, reasons %q), want %s", result.Decision, result.Score, result.Reason(), tt.wantDecision)
+			}
+		})
+	}
+}
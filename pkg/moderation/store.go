@@ -0,0 +1,43 @@
+package moderation
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Store menyediakan riwayat komentar yang dibutuhkan check duplikat dan rate limit
+type Store interface {
+	// RecentComments mengembalikan isi komentar dari email atau IP yang sama sejak waktu tertentu
+	RecentComments(email, ipAddress string, since time.Time) ([]string, error)
+}
+
+// SQLStore adalah implementasi Store berbasis tabel comments
+type SQLStore struct {
+	DB *sql.DB
+}
+
+// NewSQLStore membuat Store yang membaca dari database
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db}
+}
+
+func (s *SQLStore) RecentComments(email, ipAddress string, since time.Time) ([]string, error) {
+	query := `SELECT COALESCE(comment, '') FROM comments
+			  WHERE created_at >= $1 AND ((email <> '' AND email = $2) OR (ip_address <> '' AND ip_address = $3))
+			  ORDER BY created_at DESC`
+	rows, err := s.DB.Query(query, since, email, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []string
+	for rows.Next() {
+		var comment string
+		if err := rows.Scan(&comment); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+)
+
+// UserDirectory mencari penerima notifikasi dari tabel users. Hanya pengguna aktif yang dikembalikan.
+type UserDirectory struct {
+	DB *sql.DB
+}
+
+// NewUserDirectory membuat RecipientResolver berbasis database
+func NewUserDirectory(db *sql.DB) *UserDirectory {
+	return &UserDirectory{DB: db}
+}
+
+const recipientColumns = `SELECT id, COALESCE(name, ''), COALESCE(email, ''), COALESCE(phone_number, ''), COALESCE(role, ''), COALESCE(locale, '')
+	FROM users WHERE COALESCE(status, 'active') = 'active'`
+
+// ByRole mengambil semua pengguna aktif dengan salah satu peran tersebut.
+// Slice dikirim langsung sebagai array Postgres oleh driver pgx.
+func (d *UserDirectory) ByRole(ctx context.Context, roles ...string) ([]Recipient, error) {
+	return d.query(ctx, recipientColumns+` AND role = ANY($1) ORDER BY id`, roles)
+}
+
+// ByID mengambil pengguna aktif berdasarkan ID
+func (d *UserDirectory) ByID(ctx context.Context, ids ...int) ([]Recipient, error) {
+	return d.query(ctx, recipientColumns+` AND id = ANY($1) ORDER BY id`, ids)
+}
+
+func (d *UserDirectory) query(ctx context.Context, query string, args ...any) ([]Recipient, error) {
+	rows, err := d.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []Recipient
+	for rows.Next() {
+		var r Recipient
+		if err := rows.Scan(&r.UserID, &r.Name, &r.Email, &r.Phone, &r.Role, &r.Locale); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+	return recipients, rows.Err()
+}
@@ -0,0 +1,239 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-project/pkg/utils"
+)
+
+// Kanal pengiriman notifikasi
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+	ChannelInApp    = "in_app"
+)
+
+// Event yang memicu notifikasi
+const (
+	EventArticleSubmitted = "article.submitted"
+	EventVideoSubmitted   = "video.submitted"
+)
+
+// Event konfirmasi dan pengingat appointment dan webinar yang dikirim job reminder, satu per jenis dan penerima
+const (
+	EventBookingConfirmationUser = "appointment.booking_confirmation.user"
+	EventHostAssignedUser        = "appointment.host_assigned.user"
+	EventHostAssignedHost        = "appointment.host_assigned.host"
+	EventReminderUser            = "appointment.reminder.user"
+	EventReminderHost            = "appointment.reminder.host"
+	EventWebinarRegisteredUser   = "webinar.registered.user"
+	EventWebinarWaitlistedUser   = "webinar.waitlisted.user"
+	EventWebinarPromotedUser     = "webinar.promoted.user"
+	EventWebinarReminderUser     = "webinar.reminder.user"
+	EventWebinarCancelledUser    = "webinar.cancelled.user"
+)
+
+// Event balasan otomatis untuk pesan WhatsApp klien (YA/BATAL) sesuai hasil pemrosesannya
+const (
+	EventWhatsAppReplyConfirmed         = "whatsapp_reply.confirmed"
+	EventWhatsAppReplyAlreadyConfirmed  = "whatsapp_reply.already_confirmed"
+	EventWhatsAppReplyCancelled         = "whatsapp_reply.cancelled"
+	EventWhatsAppReplyUnknownKeyword    = "whatsapp_reply.unknown_keyword"
+	EventWhatsAppReplyNoAppointment     = "whatsapp_reply.no_appointment"
+	EventWhatsAppReplyCutoffPassed      = "whatsapp_reply.cutoff_passed"
+	EventWhatsAppReplyInvalidTransition = "whatsapp_reply.invalid_transition"
+	EventWhatsAppReplyFailed            = "whatsapp_reply.failed"
+)
+
+// ErrNoAddress dikembalikan Notifier jika penerima tidak memiliki alamat untuk kanal tersebut,
+// misalnya admin tanpa nomor WhatsApp. Router melewati penerima itu tanpa menganggapnya gagal.
+var ErrNoAddress = errors.New("recipient has no address for this channel")
+
+// Recipient adalah pengguna yang menerima notifikasi
+type Recipient struct {
+	UserID int
+	Name   string
+	Email  string
+	Phone  string
+	Role   string
+	Locale string // Bahasa pesan, salah satu Locales
+}
+
+// Event adalah kejadian yang akan diberitahukan ke penerima sesuai aturan routing
+type Event struct {
+	Name        string             `json:"name"`              // Salah satu konstanta Event*
+	Type        string             `json:"type"`              // Kategori notifikasi in-app, misalnya "article"
+	Subject     string             `json:"subject,omitempty"` // Diisi dari template jika Vars diisi
+	Body        string             `json:"body,omitempty"`
+	Vars        json.RawMessage    `json:"vars,omitempty"`     // Variabel template event, lihat TemplateSpecs
+	UserIDs     []int              `json:"user_ids,omitempty"` // Penerima tambahan di luar peran pada aturan routing
+	ActorID     int                `json:"actor_id,omitempty"` // Pengguna yang memicu event; tidak ikut diberi notifikasi
+	Attachments []utils.Attachment `json:"attachments,omitempty"`
+}
+
+// Notifier mengirim pesan lewat satu kanal
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, to Recipient, e Event) error
+}
+
+// Dispatcher meneruskan event ke penerima dan kanal yang sesuai. Dipakai service agar tidak
+// bergantung pada penyedia pengiriman tertentu.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, e Event) error
+}
+
+// RecipientResolver mencari penerima berdasarkan peran atau ID pengguna
+type RecipientResolver interface {
+	ByRole(ctx context.Context, roles ...string) ([]Recipient, error)
+	ByID(ctx context.Context, ids ...int) ([]Recipient, error)
+}
+
+// Rule menentukan siapa yang menerima sebuah event dan lewat kanal apa
+type Rule struct {
+	Roles    []string
+	Channels []string
+}
+
+// DefaultRules adalah aturan routing bawaan: konten baru yang menunggu review diberitahukan ke admin
+func DefaultRules() map[string]Rule {
+	return map[string]Rule{
+		EventArticleSubmitted: {Roles: []string{"admin"}, Channels: []string{ChannelInApp, ChannelWhatsApp, ChannelEmail}},
+		EventVideoSubmitted:   {Roles: []string{"admin"}, Channels: []string{ChannelInApp, ChannelWhatsApp, ChannelEmail}},
+	}
+}
+
+// Router adalah Dispatcher yang memakai aturan per event untuk memilih penerima dan kanal
+type Router struct {
+	Rules       map[string]Rule
+	Notifiers   map[string]Notifier
+	Recipients  RecipientResolver
+	Preferences Preferences // Kanal yang dibisukan pengguna; nil berarti tidak ada yang dibisukan
+	Templates   *Templates  // Subjek dan isi pesan per bahasa untuk event dengan Vars
+}
+
+// NewRouter membuat Router dari aturan routing dan daftar Notifier yang aktif
+func NewRouter(rules map[string]Rule, recipients RecipientResolver, notifiers ...Notifier) *Router {
+	r := &Router{Rules: rules, Notifiers: map[string]Notifier{}, Recipients: recipients}
+	for _, n := range notifiers {
+		r.Notifiers[n.Channel()] = n
+	}
+	return r
+}
+
+// Target adalah satu pengiriman: satu penerima pada satu kanal
+type Target struct {
+	Channel string
+	To      Recipient
+}
+
+// Dispatch mengirim event ke semua penerima pada setiap kanal di aturan routing-nya. Event tanpa aturan
+// diabaikan. Kegagalan satu kanal tidak menghentikan kanal lain; semua error dikembalikan bersama.
+func (r *Router) Dispatch(ctx context.Context, e Event) error {
+	targets, err := r.Targets(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, t := range targets {
+		if err := r.Send(ctx, t, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s to user %d: %w", t.Channel, t.To.UserID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Targets menentukan penerima dan kanal sebuah event sesuai aturan routing. Kanal tanpa Notifier
+// yang aktif dan penerima yang membisukan tipe event di kanal tersebut dilewati.
+func (r *Router) Targets(ctx context.Context, e Event) ([]Target, error) {
+	rule, ok := r.Rules[e.Name]
+	if !ok {
+		return nil, nil
+	}
+	recipients, err := r.resolve(ctx, rule, e)
+	if err != nil {
+		return nil, fmt.Errorf("resolve recipients for %s: %w", e.Name, err)
+	}
+
+	var targets []Target
+	for _, channel := range rule.Channels {
+		if _, ok := r.Notifiers[channel]; !ok {
+			continue
+		}
+		muted, err := r.muted(ctx, e.Type, channel, recipients)
+		if err != nil {
+			return nil, fmt.Errorf("load preferences for %s: %w", e.Name, err)
+		}
+		for _, to := range recipients {
+			if !muted[to.UserID] {
+				targets = append(targets, Target{Channel: channel, To: to})
+			}
+		}
+	}
+	return targets, nil
+}
+
+// Send mengirim event ke satu target. Event dengan Vars dirender dari template dalam bahasa penerima.
+// Penerima tanpa alamat untuk kanal tersebut tidak dianggap gagal.
+func (r *Router) Send(ctx context.Context, t Target, e Event) error {
+	n, ok := r.Notifiers[t.Channel]
+	if !ok {
+		return fmt.Errorf("no notifier for channel %s", t.Channel)
+	}
+	if e.Vars != nil && r.Templates != nil {
+		subject, body, err := r.Templates.Render(ctx, e.Name, t.To.Locale, e.Vars)
+		if err != nil {
+			return fmt.Errorf("render %s: %w", e.Name, err)
+		}
+		e.Subject, e.Body = subject, body
+	}
+	if err := n.Send(ctx, t.To, e); err != nil && !errors.Is(err, ErrNoAddress) {
+		return err
+	}
+	return nil
+}
+
+// resolve menggabungkan penerima berdasarkan peran dan ID tanpa duplikat, kecuali pemicu event
+func (r *Router) resolve(ctx context.Context, rule Rule, e Event) ([]Recipient, error) {
+	var all []Recipient
+	if len(rule.Roles) > 0 {
+		byRole, err := r.Recipients.ByRole(ctx, rule.Roles...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, byRole...)
+	}
+	if len(e.UserIDs) > 0 {
+		byID, err := r.Recipients.ByID(ctx, e.UserIDs...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, byID...)
+	}
+
+	seen := map[int]bool{}
+	recipients := all[:0]
+	for _, to := range all {
+		if seen[to.UserID] || (e.ActorID != 0 && to.UserID == e.ActorID) {
+			continue
+		}
+		seen[to.UserID] = true
+		recipients = append(recipients, to)
+	}
+	return recipients, nil
+}
+
+// muted mengembalikan penerima yang membisukan tipe event di kanal tersebut
+func (r *Router) muted(ctx context.Context, eventType, channel string, recipients []Recipient) (map[int]bool, error) {
+	if r.Preferences == nil || eventType == "" || len(recipients) == 0 {
+		return nil, nil
+	}
+	ids := make([]int, len(recipients))
+	for i, to := range recipients {
+		ids[i] = to.UserID
+	}
+	return r.Preferences.Muted(ctx, eventType, channel, ids...)
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// staticPreferences membisukan pasangan "tipe/kanal" untuk pengguna tertentu
+type staticPreferences map[string][]int
+
+func (p staticPreferences) Muted(_ context.Context, eventType, channel string, userIDs ...int) (map[int]bool, error) {
+	muted := map[int]bool{}
+	for _, id := range p[eventType+"/"+channel] {
+		muted[id] = true
+	}
+	return muted, nil
+}
+
+var testRecipients = StaticRecipients{
+	{UserID: 1, Role: "admin", Email: "a1@example.com"},
+	{UserID: 2, Role: "admin", Email: "a2@example.com"},
+	{UserID: 3, Role: "staff", Email: "s3@example.com"},
+	{UserID: 4, Role: "user", Email: "u4@example.com"},
+}
+
+// sentTo meringkas pesan yang dicatat Recorder sebagai "kanal:userID", diurutkan
+func sentTo(recorders ...*Recorder) string {
+	var out []string
+	for _, r := range recorders {
+		for _, s := range r.Messages() {
+			out = append(out, fmt.Sprintf("%s:%d", s.Channel, s.To.UserID))
+		}
+	}
+	sort.Strings(out)
+	return strings.Join(out, " ")
+}
+
+func TestRouterDispatch(t *testing.T) {
+	rules := map[string]Rule{
+		EventArticleSubmitted: {Roles: []string{"admin"}, Channels: []string{ChannelInApp, ChannelEmail, ChannelWhatsApp}},
+		EventVideoSubmitted:   {Roles: []string{"admin", "staff"}, Channels: []string{ChannelEmail}},
+		"comment.replied":     {Channels: []string{ChannelInApp}},
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		prefs staticPreferences
+		want  string
+	}{
+		{"role recipients on every active channel", Event{Name: EventArticleSubmitted},
+			nil, "email:1 email:2 in_app:1 in_app:2"},
+		{"several roles", Event{Name: EventVideoSubmitted},
+			nil, "email:1 email:2 email:3"},
+		{"extra user ids without duplicates", Event{Name: EventVideoSubmitted, UserIDs: []int{3, 4}},
+			nil, "email:1 email:2 email:3 email:4"},
+		{"actor is skipped", Event{Name: EventArticleSubmitted, ActorID: 2},
+			nil, "email:1 in_app:1"},
+		{"rule without roles uses user ids only", Event{Name: "comment.replied", UserIDs: []int{4}},
+			nil, "in_app:4"},
+		{"event without rule", Event{Name: "unknown.event", UserIDs: []int{1}},
+			nil, ""},
+		{"muted channel per user", Event{Name: EventArticleSubmitted, Type: "article"},
+			staticPreferences{"article/" + ChannelEmail: {1}}, "email:2 in_app:1 in_app:2"},
+		{"preferences ignored for events without type", Event{Name: EventArticleSubmitted},
+			staticPreferences{"/" + ChannelEmail: {1, 2}}, "email:1 email:2 in_app:1 in_app:2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inApp, email := NewRecorder(ChannelInApp), NewRecorder(ChannelEmail)
+			// Tidak ada Notifier WhatsApp, sehingga kanal itu dilewati
+			router := NewRouter(rules, testRecipients, inApp, email)
+			if tt.prefs != nil {
+				router.Preferences = tt.prefs
+			}
+
+			if err := router.Dispatch(context.Background(), tt.event); err != nil {
+				t.Fatalf("Dispatch() error = %v", err)
+			}
+			if got := sentTo(inApp, email); got != tt.want {
+				t.Errorf("sent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// noAddressNotifier menolak setiap penerima karena tidak punya alamat di kanal ini
+type noAddressNotifier struct{}
+
+func (noAddressNotifier) Channel() string { return ChannelWhatsApp }
+
+func (noAddressNotifier) Send(context.Context, Recipient, Event) error { return ErrNoAddress }
+
+func TestRouterDispatchFailingChannel(t *testing.T) {
+	rules := map[string]Rule{
+		EventArticleSubmitted: {Roles: []string{"admin"}, Channels: []string{ChannelWhatsApp, ChannelEmail, ChannelInApp}},
+	}
+	inApp, email := NewRecorder(ChannelInApp), NewRecorder(ChannelEmail)
+	email.Err = errors.New("smtp down")
+	router := NewRouter(rules, testRecipients, inApp, email, noAddressNotifier{})
+
+	err := router.Dispatch(context.Background(), Event{Name: EventArticleSubmitted})
+	if !errors.Is(err, email.Err) {
+		t.Fatalf("Dispatch() error = %v, want %v", err, email.Err)
+	}
+	for _, want := range []string{"email to user 1", "email to user 2"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Dispatch() error = %v, want it to mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), ChannelWhatsApp) {
+		t.Errorf("Dispatch() error = %v, recipients without a WhatsApp number must not count as failures", err)
+	}
+	// Kanal yang gagal tidak menghentikan kanal lain
+	if got := sentTo(inApp); got != "in_app:1 in_app:2" {
+		t.Errorf("in-app sent = %q, want both admins", got)
+	}
+
+	inApp.Reset()
+	if got := inApp.Messages(); len(got) != 0 {
+		t.Errorf("Messages() after Reset = %v, want none", got)
+	}
+}
@@ -0,0 +1,257 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Execer adalah *sql.DB atau *sql.Tx. Dengan *sql.Tx event ikut tersimpan atau batal bersama
+// perubahan konten yang memicunya.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Enqueue menulis event ke tabel notification_outbox untuk dikirim oleh Worker
+func Enqueue(ctx context.Context, db Execer, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `INSERT INTO notification_outbox (event, payload) VALUES ($1, $2)`, e.Name, payload)
+	return err
+}
+
+// WorkerConfig mengatur pengiriman notifikasi dari outbox
+type WorkerConfig struct {
+	Interval    time.Duration // jarak antar putaran worker
+	BatchSize   int           // jumlah event dan pengiriman maksimal per putaran
+	MaxAttempts int           // percobaan sebelum pengiriman dipindah ke dead-letter
+	BaseDelay   time.Duration // jeda sebelum percobaan kedua; berlipat dua setiap kali gagal
+	MaxDelay    time.Duration // jeda maksimal antar percobaan
+}
+
+// DefaultWorkerConfig mengembalikan pengaturan bawaan worker outbox
+func DefaultWorkerConfig() WorkerConfig {
+	return WorkerConfig{
+		Interval:    5 * time.Second,
+		BatchSize:   50,
+		MaxAttempts: 6,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    time.Hour,
+	}
+}
+
+// Backoff menghitung jeda sebelum percobaan berikutnya setelah attempts kali gagal
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// Worker memproses outbox dalam dua tahap: event dipecah menjadi baris notification_deliveries per
+// penerima dan kanal, lalu setiap pengiriman dicoba sendiri-sendiri. Dengan begitu kanal yang gagal
+// dikirim ulang tanpa menggandakan pesan di kanal lain.
+type Worker struct {
+	DB     *sql.DB
+	Router *Router
+	Config WorkerConfig
+}
+
+// NewWorker membuat worker outbox
+func NewWorker(db *sql.DB, router *Router, cfg WorkerConfig) *Worker {
+	return &Worker{DB: db, Router: router, Config: cfg}
+}
+
+// Start menjalankan worker secara berkala sampai context dibatalkan
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx); err != nil {
+			log.Printf("notification outbox: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce memecah event yang tertunda lalu mengirim pengiriman yang jatuh tempo
+func (w *Worker) RunOnce(ctx context.Context) error {
+	for i := 0; i < w.Config.BatchSize; i++ {
+		ok, err := w.expandNext(ctx)
+		if err != nil {
+			return fmt.Errorf("expand: %w", err)
+		}
+		if !ok {
+			break
+		}
+	}
+	for i := 0; i < w.Config.BatchSize; i++ {
+		ok, err := w.deliverNext(ctx)
+		if err != nil {
+			return fmt.Errorf("deliver: %w", err)
+		}
+		if !ok {
+			break
+		}
+	}
+	return nil
+}
+
+// expandNext mengambil satu event tertunda dan mencatat target pengirimannya
+func (w *Worker) expandNext(ctx context.Context) (bool, error) {
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var id, attempts int
+	var payload []byte
+	err = tx.QueryRowContext(ctx, `SELECT id, attempts, payload FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED`).Scan(&id, &attempts, &payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var e Event
+	var targets []Target
+	err = json.Unmarshal(payload, &e)
+	if err == nil {
+		targets, err = w.Router.Targets(ctx, e)
+	}
+	if err != nil {
+		if err := w.fail(ctx, tx, id, attempts, err); err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+
+	for _, t := range targets {
+		_, err := tx.ExecContext(ctx, `INSERT INTO notification_deliveries (outbox_id, channel, user_id)
+				VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`, id, t.Channel, t.To.UserID)
+		if err != nil {
+			return false, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE notification_outbox
+			SET status = 'dispatched', attempts = attempts + 1, last_error = NULL, dispatched_at = NOW(), updated_at = NOW()
+			WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// leaseDuration adalah lama pengiriman yang sedang berjalan tidak diambil worker lain. Jika proses berhenti
+// sebelum hasilnya tersimpan, pengiriman dicoba lagi setelah lease habis.
+const leaseDuration = 5 * time.Minute
+
+// deliverNext mengambil satu pengiriman yang jatuh tempo, mengirimnya lalu menyimpan hasilnya.
+// Baris dikunci dengan SKIP LOCKED lalu diberi lease dan transaksi di-commit sebelum pengiriman, sehingga
+// koneksi tidak ditahan selama penyedia dihubungi. Alamat penerima dibaca ulang sehingga perubahan nomor
+// atau email ikut terpakai saat percobaan ulang.
+func (w *Worker) deliverNext(ctx context.Context) (bool, error) {
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var id, userID, attempts int
+	var channel string
+	var payload []byte
+	err = tx.QueryRowContext(ctx, `SELECT d.id, d.channel, d.user_id, d.attempts, o.payload
+			FROM notification_deliveries d JOIN notification_outbox o ON o.id = d.outbox_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW()
+			ORDER BY d.id LIMIT 1 FOR UPDATE OF d SKIP LOCKED`).Scan(&id, &channel, &userID, &attempts, &payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE notification_deliveries
+			SET attempts = attempts + 1, next_attempt_at = NOW() + $1 * interval '1 second', updated_at = NOW()
+			WHERE id = $2`, int(leaseDuration/time.Second), id)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	attempts++
+
+	// Hasil tetap disimpan walaupun worker sedang dihentikan, agar pesan yang sudah terkirim tidak dikirim ulang
+	return true, w.saveDelivery(context.WithoutCancel(ctx), id, attempts, w.send(ctx, channel, userID, payload))
+}
+
+// saveDelivery menyimpan hasil pengiriman yang lease-nya dipegang worker ini. Jumlah percobaan dipakai
+// sebagai penanda lease, sehingga hasil tidak menimpa baris yang sudah diambil ulang setelah lease habis.
+func (w *Worker) saveDelivery(ctx context.Context, id, attempts int, sendErr error) error {
+	if sendErr == nil {
+		_, err := w.DB.ExecContext(ctx, `UPDATE notification_deliveries
+				SET status = 'sent', last_error = NULL, sent_at = NOW(), updated_at = NOW()
+				WHERE id = $1 AND attempts = $2 AND status = 'pending'`, id, attempts)
+		return err
+	}
+	status := "pending"
+	if attempts >= w.Config.MaxAttempts {
+		status = "dead"
+	}
+	delay := Backoff(attempts, w.Config.BaseDelay, w.Config.MaxDelay)
+	_, err := w.DB.ExecContext(ctx, `UPDATE notification_deliveries
+			SET status = $1, last_error = $2, next_attempt_at = NOW() + $3 * interval '1 millisecond', updated_at = NOW()
+			WHERE id = $4 AND attempts = $5 AND status = 'pending'`, status, sendErr.Error(), delay.Milliseconds(), id, attempts)
+	return err
+}
+
+func (w *Worker) send(ctx context.Context, channel string, userID int, payload []byte) error {
+	var e Event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		return err
+	}
+	recipients, err := w.Router.Recipients.ByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("user %d not found or inactive", userID)
+	}
+	return w.Router.Send(ctx, Target{Channel: channel, To: recipients[0]}, e)
+}
+
+// fail mencatat percobaan event outbox yang gagal. Setelah MaxAttempts baris dipindah ke dead-letter (status dead)
+// dan hanya dikirim ulang jika admin memintanya.
+func (w *Worker) fail(ctx context.Context, tx *sql.Tx, id, attempts int, cause error) error {
+	attempts++
+	status := "pending"
+	if attempts >= w.Config.MaxAttempts {
+		status = "dead"
+	}
+	delay := Backoff(attempts, w.Config.BaseDelay, w.Config.MaxDelay)
+	_, err := tx.ExecContext(ctx, `UPDATE notification_outbox
+			SET status = $1, attempts = $2, last_error = $3,
+			    next_attempt_at = NOW() + $4 * interval '1 millisecond', updated_at = NOW()
+			WHERE id = $5`, status, attempts, cause.Error(), delay.Milliseconds(), id)
+	return err
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Preferences memberi tahu Router pengguna mana yang membisukan suatu tipe notifikasi di sebuah kanal
+type Preferences interface {
+	Muted(ctx context.Context, eventType, channel string, userIDs ...int) (map[int]bool, error)
+}
+
+// UserPreferences membaca pengaturan bisu dari tabel notification_preferences
+type UserPreferences struct {
+	DB *sql.DB
+}
+
+// NewUserPreferences membuat Preferences berbasis database
+func NewUserPreferences(db *sql.DB) *UserPreferences {
+	return &UserPreferences{DB: db}
+}
+
+// Muted mengembalikan ID pengguna yang membisukan eventType di channel
+func (p *UserPreferences) Muted(ctx context.Context, eventType, channel string, userIDs ...int) (map[int]bool, error) {
+	muted := map[int]bool{}
+	if len(userIDs) == 0 {
+		return muted, nil
+	}
+	rows, err := p.DB.QueryContext(ctx, `SELECT user_id FROM notification_preferences
+		WHERE type = $1 AND channel = $2 AND muted AND user_id = ANY($3)`, eventType, channel, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		muted[id] = true
+	}
+	return muted, rows.Err()
+}
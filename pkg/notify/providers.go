@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"go-project/pkg/utils"
+)
+
+// WhatsApp mengirim notifikasi WhatsApp lewat Twilio ke nomor telepon penerima
+type WhatsApp struct {
+	SendMessage func(to, body string) error
+}
+
+// NewWhatsApp membuat Notifier WhatsApp berbasis Twilio (TWILIO_WHATSAPP_FROM)
+func NewWhatsApp() *WhatsApp {
+	return &WhatsApp{SendMessage: utils.SendWhatsAppNotification}
+}
+
+func (w *WhatsApp) Channel() string { return ChannelWhatsApp }
+
+func (w *WhatsApp) Send(_ context.Context, to Recipient, e Event) error {
+	if to.Phone == "" {
+		return ErrNoAddress
+	}
+	return w.SendMessage(to.Phone, e.Body)
+}
+
+// Email mengirim notifikasi lewat SMTP ke alamat email penerima
+type Email struct {
+	SendMail func(to, subject, body string, attachments ...utils.Attachment) error
+}
+
+// NewEmail membuat Notifier email berbasis SMTP (SMTP_HOST, SMTP_FROM, ...)
+func NewEmail() *Email {
+	return &Email{SendMail: utils.SendEmail}
+}
+
+func (m *Email) Channel() string { return ChannelEmail }
+
+func (m *Email) Send(_ context.Context, to Recipient, e Event) error {
+	if to.Email == "" {
+		return ErrNoAddress
+	}
+	return m.SendMail(to.Email, e.Subject, e.Body, e.Attachments...)
+}
+
+// InApp menyimpan notifikasi ke tabel notifications untuk ditampilkan di aplikasi
+type InApp struct {
+	DB *sql.DB
+}
+
+// NewInApp membuat Notifier in-app berbasis database
+func NewInApp(db *sql.DB) *InApp {
+	return &InApp{DB: db}
+}
+
+func (n *InApp) Channel() string { return ChannelInApp }
+
+func (n *InApp) Send(ctx context.Context, to Recipient, e Event) error {
+	if to.UserID == 0 {
+		return ErrNoAddress
+	}
+	_, err := n.DB.ExecContext(ctx, `INSERT INTO notifications (user_id, type, message, status, created_at, updated_at)
+		VALUES ($1, $2, $3, 'unread', NOW(), NOW())`, to.UserID, e.Type, e.Body)
+	return err
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Sent adalah satu pesan yang dicatat Recorder
+type Sent struct {
+	Channel string
+	To      Recipient
+	Event   Event
+}
+
+// Recorder adalah Notifier pengganti untuk pengujian dan pengembangan lokal. Pesan tidak dikirim ke
+// mana pun, hanya dicatat agar bisa diperiksa. Isi Err untuk mensimulasikan kanal yang gagal.
+type Recorder struct {
+	Name string // Kanal yang disimulasikan, bawaan ChannelInApp
+	Err  error
+
+	mu   sync.Mutex
+	sent []Sent
+}
+
+// NewRecorder membuat Recorder untuk sebuah kanal
+func NewRecorder(channel string) *Recorder {
+	return &Recorder{Name: channel}
+}
+
+func (r *Recorder) Channel() string {
+	if r.Name == "" {
+		return ChannelInApp
+	}
+	return r.Name
+}
+
+func (r *Recorder) Send(_ context.Context, to Recipient, e Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return r.Err
+	}
+	r.sent = append(r.sent, Sent{Channel: r.Channel(), To: to, Event: e})
+	return nil
+}
+
+// Messages mengembalikan salinan semua pesan yang sudah dicatat
+func (r *Recorder) Messages() []Sent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Sent(nil), r.sent...)
+}
+
+// Reset menghapus semua pesan yang sudah dicatat
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = nil
+}
+
+// StaticRecipients adalah RecipientResolver dari daftar tetap, pasangan Recorder saat pengujian
+type StaticRecipients []Recipient
+
+func (s StaticRecipients) ByRole(_ context.Context, roles ...string) ([]Recipient, error) {
+	var out []Recipient
+	for _, r := range s {
+		for _, role := range roles {
+			if r.Role == role {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s StaticRecipients) ByID(_ context.Context, ids ...int) ([]Recipient, error) {
+	var out []Recipient
+	for _, r := range s {
+		for _, id := range ids {
+			if r.UserID == id {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out, nil
+}
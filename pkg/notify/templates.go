@@ -0,0 +1,450 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Bahasa pesan notifikasi. Penerima tanpa bahasa atau dengan bahasa yang tidak dikenal memakai DefaultLocale.
+const (
+	LocaleID      = "id"
+	LocaleEN      = "en"
+	DefaultLocale = LocaleID
+)
+
+// Locales adalah bahasa yang didukung template notifikasi
+var Locales = []string{LocaleID, LocaleEN}
+
+var (
+	// ErrUnknownTemplate dikembalikan jika event atau bahasa tidak memiliki template
+	ErrUnknownTemplate = errors.New("unknown notification template")
+	// ErrInvalidTemplate dikembalikan jika template tidak bisa diparse atau memakai variabel yang tidak ada
+	ErrInvalidTemplate = errors.New("invalid notification template")
+)
+
+// ContentSubmittedVars adalah variabel template untuk artikel atau video baru yang menunggu review
+type ContentSubmittedVars struct {
+	Title      string `desc:"Judul konten"`
+	AuthorName string `desc:"Nama staff pengunggah; bisa kosong"`
+}
+
+// ReminderVars adalah variabel template konfirmasi dan pengingat appointment serta webinar yang dikirim job reminder
+type ReminderVars struct {
+	Name          string `desc:"Nama peserta appointment atau webinar"`
+	Title         string `desc:"Judul webinar; kosong untuk appointment"`
+	HostName      string `desc:"Nama host; bisa kosong"`
+	Time          string `desc:"Waktu mulai, misalnya 01 Jan 2026 10:00 WIB"`
+	Status        string `desc:"Status appointment"`
+	LinkMeet      string `desc:"Link meeting; bisa kosong"`
+	OffsetHours   int    `desc:"Jarak pengingat dalam jam; 0 jika tidak bulat per jam"`
+	OffsetMinutes int    `desc:"Jarak pengingat dalam menit"`
+	Note          string `desc:"Alasan pembatalan webinar; bisa kosong"`
+	CanReply      bool   `desc:"True jika penerima bisa membalas pesan WhatsApp untuk konfirmasi atau pembatalan"`
+}
+
+// sampleReminder adalah contoh variabel untuk pratinjau template reminder
+var sampleReminder = ReminderVars{
+	Name: "Budi", Title: "Gizi Seimbang untuk Keluarga", HostName: "Dr. Sari", Time: "01 Jan 2026 10:00 WIB",
+	Status: "confirmed", LinkMeet: "https://meet.jit.si/edukasi-contoh", OffsetHours: 1, OffsetMinutes: 60,
+	Note: "Pembicara berhalangan", CanReply: true,
+}
+
+// WhatsAppReplyVars adalah variabel template balasan otomatis pesan WhatsApp klien. Balasan hanya memakai isi pesan.
+type WhatsAppReplyVars struct {
+	Time   string `desc:"Waktu mulai appointment, misalnya 01 Jan 2026 10:00 WIB; kosong jika appointment tidak ditemukan"`
+	Cutoff string `desc:"Batas waktu pembatalan sebelum appointment dimulai, misalnya 24h0m0s"`
+}
+
+// sampleWhatsAppReply adalah contoh variabel untuk pratinjau template balasan WhatsApp
+var sampleWhatsAppReply = WhatsAppReplyVars{Time: "01 Jan 2026 10:00 WIB", Cutoff: "24h0m0s"}
+
+// whatsAppReplySpec membuat pendaftaran template balasan WhatsApp dari isi pesan per bahasa
+func whatsAppReplySpec(event, bodyID, bodyEN string) TemplateSpec {
+	return TemplateSpec{
+		Event:  event,
+		Sample: sampleWhatsAppReply,
+		Defaults: map[string]Template{
+			LocaleID: {Body: bodyID},
+			LocaleEN: {Body: bodyEN},
+		},
+	}
+}
+
+// Template adalah subjek dan isi pesan sebuah event dalam satu bahasa. Keduanya memakai sintaks
+// text/template dengan variabel dari struct *Vars milik event, misalnya {{.Title}}.
+type Template struct {
+	Event     string     `json:"event"`
+	Locale    string     `json:"locale"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	Custom    bool       `json:"custom"` // True jika diubah admin, false jika memakai bawaan
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Variable menjelaskan satu variabel yang bisa dipakai di template
+type Variable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// TemplateSpec mendaftarkan variabel dan template bawaan sebuah event
+type TemplateSpec struct {
+	Event    string
+	Sample   any                 // Contoh nilai variabel untuk pratinjau dan validasi; tipenya menentukan variabel event
+	Defaults map[string]Template // Template bawaan per bahasa
+}
+
+// Variables mengembalikan daftar variabel yang tersedia untuk template event ini
+func (s TemplateSpec) Variables() []Variable {
+	t := reflect.TypeOf(s.Sample)
+	vars := make([]Variable, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		vars = append(vars, Variable{Name: f.Name, Type: f.Type.String(), Description: f.Tag.Get("desc")})
+	}
+	return vars
+}
+
+// decode mengubah variabel JSON menjadi struct bertipe milik event. Dengan strict, field yang tidak
+// dikenal ditolak; event lama di outbox tetap bisa dirender walau variabelnya sudah berubah.
+func (s TemplateSpec) decode(raw json.RawMessage, strict bool) (any, error) {
+	ptr := reflect.New(reflect.TypeOf(s.Sample))
+	if len(raw) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(ptr.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: vars: %v", ErrInvalidTemplate, err)
+		}
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// TemplateSpecs adalah semua event yang pesannya memakai template
+var TemplateSpecs = map[string]TemplateSpec{
+	EventArticleSubmitted: {
+		Event:  EventArticleSubmitted,
+		Sample: ContentSubmittedVars{Title: "Mengenal Pendidikan Inklusif", AuthorName: "Budi"},
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Artikel baru menunggu review", Body: "Artikel baru '{{.Title}}' telah diunggah{{if .AuthorName}} oleh {{.AuthorName}}{{end}}!"},
+			LocaleEN: {Subject: "New article awaiting review", Body: "A new article '{{.Title}}' has been submitted{{if .AuthorName}} by {{.AuthorName}}{{end}}!"},
+		},
+	},
+	EventVideoSubmitted: {
+		Event:  EventVideoSubmitted,
+		Sample: ContentSubmittedVars{Title: "Tips Belajar dari Rumah", AuthorName: "Budi"},
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Video baru menunggu review", Body: "Video baru '{{.Title}}' telah diunggah{{if .AuthorName}} oleh {{.AuthorName}}{{end}}!"},
+			LocaleEN: {Subject: "New video awaiting review", Body: "A new video '{{.Title}}' has been submitted{{if .AuthorName}} by {{.AuthorName}}{{end}}!"},
+		},
+	},
+	EventBookingConfirmationUser: {
+		Event:  EventBookingConfirmationUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Konfirmasi booking appointment", Body: "Halo {{.Name}}, booking appointment Anda pada {{.Time}} sudah kami terima dengan status {{.Status}}." +
+				"{{if .HostName}} Host Anda: {{.HostName}}.{{end}}"},
+			LocaleEN: {Subject: "Appointment booking confirmation", Body: "Hi {{.Name}}, we have received your appointment booking on {{.Time}} with status {{.Status}}." +
+				"{{if .HostName}} Your host: {{.HostName}}.{{end}}"},
+		},
+	},
+	EventHostAssignedUser: {
+		Event:  EventHostAssignedUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Host appointment Anda sudah ditentukan", Body: "Halo {{.Name}}, appointment Anda pada {{.Time}} akan didampingi oleh {{.HostName}}." +
+				"{{if .LinkMeet}} Link meeting: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "Your appointment host has been assigned", Body: "Hi {{.Name}}, your appointment on {{.Time}} will be hosted by {{.HostName}}." +
+				"{{if .LinkMeet}} Meeting link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventHostAssignedHost: {
+		Event:  EventHostAssignedHost,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Appointment baru untuk Anda", Body: "Halo {{.HostName}}, Anda ditugaskan sebagai host appointment dengan {{.Name}} pada {{.Time}}." +
+				"{{if .LinkMeet}} Link meeting: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "New appointment for you", Body: "Hi {{.HostName}}, you have been assigned to host an appointment with {{.Name}} on {{.Time}}." +
+				"{{if .LinkMeet}} Meeting link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventReminderUser: {
+		Event:  EventReminderUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Pengingat appointment", Body: "Halo {{.Name}}, pengingat: appointment Anda dimulai " +
+				"{{if .OffsetHours}}{{.OffsetHours}} jam{{else}}{{.OffsetMinutes}} menit{{end}} lagi pada {{.Time}}." +
+				"{{if .LinkMeet}} Link meeting: {{.LinkMeet}}{{end}}" +
+				"{{if .CanReply}} Balas YA untuk konfirmasi atau BATAL untuk membatalkan.{{end}}"},
+			LocaleEN: {Subject: "Appointment reminder", Body: "Hi {{.Name}}, reminder: your appointment starts in " +
+				"{{if .OffsetHours}}{{.OffsetHours}} hour(s){{else}}{{.OffsetMinutes}} minutes{{end}} on {{.Time}}." +
+				"{{if .LinkMeet}} Meeting link: {{.LinkMeet}}{{end}}" +
+				"{{if .CanReply}} Reply YA to confirm or BATAL to cancel.{{end}}"},
+		},
+	},
+	EventReminderHost: {
+		Event:  EventReminderHost,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Pengingat appointment", Body: "Halo {{.HostName}}, pengingat: appointment dengan {{.Name}} dimulai " +
+				"{{if .OffsetHours}}{{.OffsetHours}} jam{{else}}{{.OffsetMinutes}} menit{{end}} lagi pada {{.Time}}." +
+				"{{if .LinkMeet}} Link meeting: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "Appointment reminder", Body: "Hi {{.HostName}}, reminder: your appointment with {{.Name}} starts in " +
+				"{{if .OffsetHours}}{{.OffsetHours}} hour(s){{else}}{{.OffsetMinutes}} minutes{{end}} on {{.Time}}." +
+				"{{if .LinkMeet}} Meeting link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventWebinarRegisteredUser: {
+		Event:  EventWebinarRegisteredUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Pendaftaran webinar berhasil", Body: "Halo {{.Name}}, Anda terdaftar di webinar \"{{.Title}}\" pada {{.Time}}." +
+				"{{if .LinkMeet}} Link webinar: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "Webinar registration successful", Body: "Hi {{.Name}}, you are registered for the webinar \"{{.Title}}\" on {{.Time}}." +
+				"{{if .LinkMeet}} Webinar link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventWebinarWaitlistedUser: {
+		Event:  EventWebinarWaitlistedUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Anda masuk daftar tunggu webinar", Body: "Halo {{.Name}}, kuota webinar \"{{.Title}}\" pada {{.Time}} sudah penuh. " +
+				"Anda masuk daftar tunggu dan akan kami kabari jika ada kursi kosong."},
+			LocaleEN: {Subject: "You are on the webinar waitlist", Body: "Hi {{.Name}}, the webinar \"{{.Title}}\" on {{.Time}} is full. " +
+				"You are on the waitlist and we will let you know if a seat opens up."},
+		},
+	},
+	EventWebinarPromotedUser: {
+		Event:  EventWebinarPromotedUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Anda mendapat kursi webinar", Body: "Halo {{.Name}}, ada kursi kosong untuk Anda di webinar \"{{.Title}}\" pada {{.Time}}." +
+				"{{if .LinkMeet}} Link webinar: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "You got a webinar seat", Body: "Hi {{.Name}}, a seat has opened up for you in the webinar \"{{.Title}}\" on {{.Time}}." +
+				"{{if .LinkMeet}} Webinar link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventWebinarReminderUser: {
+		Event:  EventWebinarReminderUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Pengingat webinar", Body: "Halo {{.Name}}, pengingat: webinar \"{{.Title}}\" dimulai " +
+				"{{if .OffsetHours}}{{.OffsetHours}} jam{{else}}{{.OffsetMinutes}} menit{{end}} lagi pada {{.Time}}." +
+				"{{if .LinkMeet}} Link webinar: {{.LinkMeet}}{{end}}"},
+			LocaleEN: {Subject: "Webinar reminder", Body: "Hi {{.Name}}, reminder: the webinar \"{{.Title}}\" starts in " +
+				"{{if .OffsetHours}}{{.OffsetHours}} hour(s){{else}}{{.OffsetMinutes}} minutes{{end}} on {{.Time}}." +
+				"{{if .LinkMeet}} Webinar link: {{.LinkMeet}}{{end}}"},
+		},
+	},
+	EventWebinarCancelledUser: {
+		Event:  EventWebinarCancelledUser,
+		Sample: sampleReminder,
+		Defaults: map[string]Template{
+			LocaleID: {Subject: "Webinar dibatalkan", Body: "Halo {{.Name}}, mohon maaf, webinar \"{{.Title}}\" pada {{.Time}} dibatalkan." +
+				"{{if .Note}} Alasan: {{.Note}}{{end}}"},
+			LocaleEN: {Subject: "Webinar cancelled", Body: "Hi {{.Name}}, we are sorry, the webinar \"{{.Title}}\" on {{.Time}} has been cancelled." +
+				"{{if .Note}} Reason: {{.Note}}{{end}}"},
+		},
+	},
+	EventWhatsAppReplyConfirmed: whatsAppReplySpec(EventWhatsAppReplyConfirmed,
+		"Terima kasih, appointment Anda pada {{.Time}} sudah dikonfirmasi.",
+		"Thank you, your appointment on {{.Time}} has been confirmed."),
+	EventWhatsAppReplyAlreadyConfirmed: whatsAppReplySpec(EventWhatsAppReplyAlreadyConfirmed,
+		"Appointment Anda pada {{.Time}} sudah terkonfirmasi. Sampai jumpa!",
+		"Your appointment on {{.Time}} is already confirmed. See you then!"),
+	EventWhatsAppReplyCancelled: whatsAppReplySpec(EventWhatsAppReplyCancelled,
+		"Appointment Anda pada {{.Time}} sudah dibatalkan.",
+		"Your appointment on {{.Time}} has been cancelled."),
+	EventWhatsAppReplyUnknownKeyword: whatsAppReplySpec(EventWhatsAppReplyUnknownKeyword,
+		"Balas YA untuk mengonfirmasi atau BATAL untuk membatalkan appointment Anda pada {{.Time}}.",
+		"Reply YA to confirm or BATAL to cancel your appointment on {{.Time}}."),
+	EventWhatsAppReplyNoAppointment: whatsAppReplySpec(EventWhatsAppReplyNoAppointment,
+		"Maaf, kami tidak menemukan appointment aktif untuk nomor ini.",
+		"Sorry, we could not find an active appointment for this number."),
+	EventWhatsAppReplyCutoffPassed: whatsAppReplySpec(EventWhatsAppReplyCutoffPassed,
+		"Maaf, appointment tidak bisa dibatalkan kurang dari {{.Cutoff}} sebelum dimulai. Silakan hubungi kami.",
+		"Sorry, appointments cannot be cancelled less than {{.Cutoff}} before they start. Please contact us."),
+	EventWhatsAppReplyInvalidTransition: whatsAppReplySpec(EventWhatsAppReplyInvalidTransition,
+		"Maaf, status appointment Anda tidak bisa diubah lagi.",
+		"Sorry, your appointment status can no longer be changed."),
+	EventWhatsAppReplyFailed: whatsAppReplySpec(EventWhatsAppReplyFailed,
+		"Maaf, balasan Anda belum bisa diproses. Silakan coba lagi nanti.",
+		"Sorry, we could not process your reply. Please try again later."),
+}
+
+// Vars mengubah struct variabel template menjadi JSON untuk Event.Vars
+func Vars(v any) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: vars: %v", ErrInvalidTemplate, err)
+	}
+	return data, nil
+}
+
+// Templates menyimpan template yang diubah admin di tabel notification_templates.
+// Event dan bahasa tanpa baris di tabel memakai template bawaan dari TemplateSpecs.
+// Templates tanpa DB hanya memakai template bawaan dan tidak bisa menyimpan ubahan.
+type Templates struct {
+	DB *sql.DB
+}
+
+// NewTemplates membuat registry template berbasis database
+func NewTemplates(db *sql.DB) *Templates {
+	return &Templates{DB: db}
+}
+
+// Spec mengembalikan pendaftaran template sebuah event
+func (t *Templates) Spec(event string) (TemplateSpec, error) {
+	spec, ok := TemplateSpecs[event]
+	if !ok {
+		return TemplateSpec{}, ErrUnknownTemplate
+	}
+	return spec, nil
+}
+
+// Get mengambil template yang berlaku untuk event dan bahasa
+func (t *Templates) Get(ctx context.Context, event, locale string) (Template, error) {
+	spec, err := t.Spec(event)
+	if err != nil {
+		return Template{}, err
+	}
+	def, ok := spec.Defaults[locale]
+	if !ok {
+		return Template{}, ErrUnknownTemplate
+	}
+
+	tmpl := Template{Event: event, Locale: locale}
+	if t.DB == nil {
+		tmpl.Subject, tmpl.Body = def.Subject, def.Body
+		return tmpl, nil
+	}
+	err = t.DB.QueryRowContext(ctx, `SELECT subject, body, updated_at FROM notification_templates
+		WHERE event = $1 AND locale = $2`, event, locale).Scan(&tmpl.Subject, &tmpl.Body, &tmpl.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		tmpl.Subject, tmpl.Body = def.Subject, def.Body
+		return tmpl, nil
+	}
+	if err != nil {
+		return Template{}, err
+	}
+	tmpl.Custom = true
+	return tmpl, nil
+}
+
+// List mengambil template yang berlaku untuk semua event dan bahasa, diurutkan per event
+func (t *Templates) List(ctx context.Context) ([]Template, error) {
+	events := make([]string, 0, len(TemplateSpecs))
+	for event := range TemplateSpecs {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var list []Template
+	for _, event := range events {
+		for _, locale := range Locales {
+			tmpl, err := t.Get(ctx, event, locale)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, tmpl)
+		}
+	}
+	return list, nil
+}
+
+// Save memvalidasi lalu menyimpan template hasil ubahan admin
+func (t *Templates) Save(ctx context.Context, tmpl Template, updatedBy string) (Template, error) {
+	if strings.TrimSpace(tmpl.Body) == "" {
+		return Template{}, fmt.Errorf("%w: body is required", ErrInvalidTemplate)
+	}
+	if _, _, err := t.Preview(tmpl, nil); err != nil {
+		return Template{}, err
+	}
+	_, err := t.DB.ExecContext(ctx, `INSERT INTO notification_templates (event, locale, subject, body, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, (SELECT id FROM users WHERE email = $5), NOW())
+		ON CONFLICT (event, locale) DO UPDATE
+		SET subject = EXCLUDED.subject, body = EXCLUDED.body, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tmpl.Event, tmpl.Locale, tmpl.Subject, tmpl.Body, updatedBy)
+	if err != nil {
+		return Template{}, err
+	}
+	return t.Get(ctx, tmpl.Event, tmpl.Locale)
+}
+
+// Reset menghapus ubahan admin sehingga event dan bahasa tersebut kembali memakai template bawaan
+func (t *Templates) Reset(ctx context.Context, event, locale string) (Template, error) {
+	if _, err := t.Get(ctx, event, locale); err != nil {
+		return Template{}, err
+	}
+	if _, err := t.DB.ExecContext(ctx, `DELETE FROM notification_templates WHERE event = $1 AND locale = $2`, event, locale); err != nil {
+		return Template{}, err
+	}
+	return t.Get(ctx, event, locale)
+}
+
+// Preview merender template (tersimpan atau belum) dengan variabel yang diberikan,
+// atau dengan contoh variabel event jika vars kosong
+func (t *Templates) Preview(tmpl Template, vars json.RawMessage) (string, string, error) {
+	spec, err := t.Spec(tmpl.Event)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := spec.Defaults[tmpl.Locale]; !ok {
+		return "", "", ErrUnknownTemplate
+	}
+	data := spec.Sample
+	if len(vars) > 0 {
+		if data, err = spec.decode(vars, true); err != nil {
+			return "", "", err
+		}
+	}
+	return renderTemplate(tmpl, data)
+}
+
+// Render membuat subjek dan isi pesan event untuk bahasa penerima. Bahasa yang tidak dikenal
+// memakai DefaultLocale.
+func (t *Templates) Render(ctx context.Context, event, locale string, vars json.RawMessage) (string, string, error) {
+	spec, err := t.Spec(event)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := spec.Defaults[locale]; !ok {
+		locale = DefaultLocale
+	}
+	tmpl, err := t.Get(ctx, event, locale)
+	if err != nil {
+		return "", "", err
+	}
+	data, err := spec.decode(vars, false)
+	if err != nil {
+		return "", "", err
+	}
+	return renderTemplate(tmpl, data)
+}
+
+// renderTemplate mengeksekusi subjek dan isi. Variabel yang tidak ada di struct event membuat
+// eksekusi gagal sehingga salah ketik ketahuan saat disimpan, bukan saat dikirim.
+func renderTemplate(tmpl Template, data any) (string, string, error) {
+	var out [2]bytes.Buffer
+	for i, text := range []string{tmpl.Subject, tmpl.Body} {
+		parsed, err := template.New(tmpl.Event).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if err := parsed.Execute(&out[i], data); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+	}
+	return out[0].String(), out[1].String(), nil
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefaultTemplatesRenderSample(t *testing.T) {
+	templates := NewTemplates(nil)
+	for event, spec := range TemplateSpecs {
+		if spec.Event != event {
+			t.Errorf("TemplateSpecs[%q].Event = %q", event, spec.Event)
+		}
+		for _, locale := range Locales {
+			tmpl, err := templates.Get(context.Background(), event, locale)
+			if err != nil {
+				t.Errorf("%s/%s: Get: %v", event, locale, err)
+				continue
+			}
+			_, body, err := templates.Preview(tmpl, nil)
+			if err != nil {
+				t.Errorf("%s/%s: Preview: %v", event, locale, err)
+				continue
+			}
+			if strings.TrimSpace(body) == "" || strings.Contains(body, "<no value>") {
+				t.Errorf("%s/%s: body = %q", event, locale, body)
+			}
+		}
+	}
+}
+
+func TestPreviewRejectsUnknownVariables(t *testing.T) {
+	templates := NewTemplates(nil)
+	tmpl := Template{Event: EventArticleSubmitted, Locale: LocaleID, Subject: "Artikel", Body: "{{.Judul}}"}
+	if _, _, err := templates.Preview(tmpl, nil); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("Preview with unknown template field = %v, want ErrInvalidTemplate", err)
+	}
+
+	tmpl.Body = "{{.Title}}"
+	if _, _, err := templates.Preview(tmpl, json.RawMessage(`{"Judul":"x"}`)); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("Preview with unknown vars field = %v, want ErrInvalidTemplate", err)
+	}
+
+	tmpl.Event = "unknown.event"
+	if _, _, err := templates.Preview(tmpl, nil); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Preview with unknown event = %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	templates := NewTemplates(nil)
+	vars, err := Vars(ContentSubmittedVars{Title: "Gizi Anak", AuthorName: "Sari"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, body, err := templates.Render(context.Background(), EventArticleSubmitted, LocaleEN, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "New article awaiting review" || body != "A new article 'Gizi Anak' has been submitted by Sari!" {
+		t.Errorf("Render(en) = %q, %q", subject, body)
+	}
+
+	// Bahasa yang tidak dikenal memakai DefaultLocale
+	_, body, err = templates.Render(context.Background(), EventArticleSubmitted, "fr", vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "Artikel baru 'Gizi Anak' telah diunggah oleh Sari!" {
+		t.Errorf("Render(fr) = %q, want the Indonesian template", body)
+	}
+
+	// Variabel lama di outbox yang sudah tidak ada di struct tetap bisa dirender
+	if _, _, err := templates.Render(context.Background(), EventArticleSubmitted, LocaleID, json.RawMessage(`{"Title":"x","Removed":1}`)); err != nil {
+		t.Errorf("Render with extra vars = %v, want nil", err)
+	}
+
+	if _, _, err := templates.Render(context.Background(), "unknown.event", LocaleID, vars); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Render unknown event = %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestVarsRejectsUnmarshalableValue(t *testing.T) {
+	if _, err := Vars(struct{ C chan int }{make(chan int)}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("Vars(chan) = %v, want ErrInvalidTemplate", err)
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"go-project/pkg/utils"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter membatasi jumlah request per kunci (misalnya IP client) dalam jendela waktu tetap.
+// Penghitung disimpan di memori proses, sehingga batas berlaku per instance aplikasi.
+type Limiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*counter
+	lastSweep time.Time
+}
+
+type counter struct {
+	count   int
+	resetAt time.Time
+}
+
+// New membuat limiter yang mengizinkan limit request per kunci setiap window.
+// limit <= 0 atau window <= 0 berarti tidak ada batas.
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{limit: limit, window: window, now: time.Now, windows: map[string]*counter{}}
+}
+
+// Allow mencatat satu request untuk key dan melaporkan apakah request masih diizinkan.
+// Jika ditolak, retryAfter adalah sisa waktu sampai jendela berikutnya dimulai.
+func (l *Limiter) Allow(key string) (allowed bool, retryAfter time.Duration) {
+	if l == nil || l.limit <= 0 || l.window <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &counter{resetAt: now.Add(l.window)}
+		l.windows[key] = w
+	}
+	if w.count >= l.limit {
+		return false, w.resetAt.Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// sweep membuang jendela yang sudah lewat paling sering sekali per window agar map tidak terus membesar
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, w := range l.windows {
+		if !now.Before(w.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Middleware menolak request dengan 429 Too Many Requests jika IP client melewati batas
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if allowed, retryAfter := l.Allow(utils.ClientIP(r)); !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "Too many requests, please try again later", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
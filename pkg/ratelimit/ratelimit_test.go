@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	l := New(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("1.2.3.4"); !ok {
+			t.Fatalf("request %d rejected, want allowed", i+1)
+		}
+	}
+	ok, retryAfter := l.Allow("1.2.3.4")
+	if ok {
+		t.Fatal("third request allowed, want rejected")
+	}
+	if retryAfter != time.Minute {
+		t.Errorf("retryAfter = %s, want 1m", retryAfter)
+	}
+	if ok, _ := l.Allow("5.6.7.8"); !ok {
+		t.Error("other key rejected, want allowed")
+	}
+
+	now = now.Add(time.Minute)
+	if ok, _ := l.Allow("1.2.3.4"); !ok {
+		t.Error("request in next window rejected, want allowed")
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	var nilLimiter *Limiter
+	for _, l := range []*Limiter{nilLimiter, New(0, time.Minute)} {
+		for i := 0; i < 10; i++ {
+			if ok, _ := l.Allow("1.2.3.4"); !ok {
+				t.Fatal("disabled limiter rejected a request")
+			}
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := New(1, time.Hour).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	codes := make([]int, 2)
+	for i := range codes {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+		codes[i] = rec.Code
+		if i == 1 && rec.Header().Get("Retry-After") != "3600" {
+			t.Errorf("Retry-After = %q, want 3600", rec.Header().Get("Retry-After"))
+		}
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("status codes = %v, want [200 429]", codes)
+	}
+}
@@ -0,0 +1,225 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-project/pkg/middleware"
+	"go-project/pkg/utils"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// heartbeat menjaga koneksi tetap terbuka melewati proxy yang menutup koneksi diam
+const heartbeat = 25 * time.Second
+
+// Handler melayani stream event untuk pengguna yang sudah login
+type Handler struct {
+	DB  *sql.DB
+	Hub *Hub
+	// Origin adalah origin aplikasi (server.base_url). Handshake WebSocket dari origin lain ditolak
+	// karena browser tidak menerapkan CORS pada WebSocket.
+	Origin string
+}
+
+// NewHandler membuat Handler stream untuk aplikasi yang dilayani di baseURL
+func NewHandler(db *sql.DB, hub *Hub, baseURL string) *Handler {
+	return &Handler{DB: db, Hub: hub, Origin: origin(baseURL)}
+}
+
+// origin mengambil scheme dan host dari sebuah URL, misalnya https://example.com
+func origin(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// IssueTicket membuat tiket stream untuk user yang sedang login. Tiket dikirim sebagai query parameter
+// ticket saat membuka /realtime/events atau /realtime/ws.
+func (h *Handler) IssueTicket(w http.ResponseWriter, r *http.Request) {
+	ticket, err := utils.GenerateStreamTicket(middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]any{
+		"ticket":     ticket,
+		"expires_in": int(utils.StreamTicketTTL / time.Second),
+	})
+}
+
+// allowedOrigin memeriksa header Origin handshake WebSocket. Klien non-browser yang tidak mengirim
+// Origin tetap diterima karena tetap membutuhkan tiket.
+func (h *Handler) allowedOrigin(r *http.Request) bool {
+	o := r.Header.Get("Origin")
+	return o == "" || (h.Origin != "" && strings.EqualFold(strings.TrimRight(o, "/"), h.Origin))
+}
+
+// envelope adalah bentuk pesan yang dikirim ke klien WebSocket
+type envelope struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// ServeSSE mengirim event sebagai Server-Sent Events. Setiap pesan berisi "event: <nama>" dan
+// "data: <json>". Browser EventSource tidak bisa mengirim header, jadi request memakai tiket stream
+// dari IssueTicket di query parameter ticket.
+func (h *Handler) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	client, err := h.client(r.Context(), middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	snapshot, err := h.snapshot(r.Context(), client)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sub := h.Hub.Subscribe(client)
+	defer h.Hub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, m := range snapshot {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", m.Event, m.Data)
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case m := <-sub.C:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", m.Event, m.Data)
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// ServeWebSocket mengirim event yang sama lewat WebSocket sebagai pesan teks JSON
+// {"event": "...", "data": {...}}.
+func (h *Handler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	if !h.allowedOrigin(r) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return
+	}
+	client, err := h.client(r.Context(), middleware.GetUserEmail(r.Context()))
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	snapshot, err := h.snapshot(r.Context(), client)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrade(w, r)
+	if errors.Is(err, errNotWebSocket) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("realtime websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	sub := h.Hub.Subscribe(client)
+	defer h.Hub.Unsubscribe(sub)
+
+	closed := make(chan struct{})
+	go func() {
+		conn.readLoop()
+		close(closed)
+	}()
+
+	send := func(m Message) error {
+		payload, _ := json.Marshal(envelope{Event: m.Event, Data: m.Data})
+		return conn.writeFrame(opText, payload)
+	}
+	for _, m := range snapshot {
+		if err := send(m); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-closed:
+			return
+		case m := <-sub.C:
+			err = send(m)
+		case <-ticker.C:
+			err = conn.writeFrame(opPing, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// client mencari ID dan peran pengguna yang sedang login
+func (h *Handler) client(ctx context.Context, email string) (Client, error) {
+	var c Client
+	err := h.DB.QueryRowContext(ctx, `SELECT id, COALESCE(role, '') FROM users
+		WHERE email = $1 AND COALESCE(status, 'active') = 'active'`, email).Scan(&c.UserID, &c.Role)
+	return c, err
+}
+
+// snapshot adalah keadaan awal yang dikirim saat klien terhubung, agar klien tidak perlu
+// menunggu perubahan berikutnya untuk menampilkan badge
+func (h *Handler) snapshot(ctx context.Context, c Client) ([]Message, error) {
+	var unread int
+	err := h.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND status = 'unread'`,
+		c.UserID).Scan(&unread)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(map[string]int{"unread": unread})
+	messages := []Message{{Event: EventUnreadCount, Data: data}}
+
+	if c.Role == ModerationRole {
+		counts, err := LoadModerationCounts(ctx, h.DB)
+		if err != nil {
+			return nil, err
+		}
+		data, _ := json.Marshal(counts)
+		messages = append(messages, Message{Event: EventModerationQueue, Data: data})
+	}
+	return messages, nil
+}
+
+func writeClientError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "User not found or inactive", http.StatusForbidden)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
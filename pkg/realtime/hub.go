@@ -0,0 +1,86 @@
+// Package realtime mengirim notifikasi in-app dan jumlah antrean moderasi ke klien yang terhubung
+// lewat Server-Sent Events atau WebSocket. Perubahan diumumkan oleh trigger database lewat
+// Postgres NOTIFY, sehingga setiap instance server menerima event yang sama.
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Event yang dikirim ke klien
+const (
+	EventNotification    = "notification"        // Notifikasi in-app baru beserta jumlah yang belum dibaca
+	EventUnreadCount     = "notification.unread" // Jumlah notifikasi belum dibaca berubah
+	EventModerationQueue = "moderation.queue"    // Jumlah konten yang menunggu moderasi berubah
+)
+
+// Channel adalah nama kanal LISTEN/NOTIFY di Postgres
+const Channel = "realtime"
+
+// Message adalah satu event untuk klien. UserID dan Role membatasi penerimanya;
+// nilai kosong berarti semua klien.
+type Message struct {
+	Event  string          `json:"event"`
+	UserID int             `json:"user_id,omitempty"`
+	Role   string          `json:"role,omitempty"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Client adalah pengguna yang sedang terhubung
+type Client struct {
+	UserID int
+	Role   string
+}
+
+// Subscription menerima pesan untuk satu koneksi klien
+type Subscription struct {
+	Client Client
+	C      chan Message
+}
+
+// Hub meneruskan pesan ke semua Subscription yang sesuai di instance ini
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewHub membuat Hub kosong
+func NewHub() *Hub {
+	return &Hub{subs: map[*Subscription]struct{}{}}
+}
+
+// Subscribe mendaftarkan koneksi baru. Panggil Unsubscribe saat koneksi ditutup.
+func (h *Hub) Subscribe(c Client) *Subscription {
+	sub := &Subscription{Client: c, C: make(chan Message, 16)}
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// Unsubscribe menghapus koneksi dari Hub
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	delete(h.subs, sub)
+	h.mu.Unlock()
+}
+
+// Publish mengirim pesan ke klien yang sesuai. Klien yang lambat membaca dilewati agar tidak
+// menahan klien lain; event berikutnya tetap membawa jumlah terbaru.
+func (h *Hub) Publish(m Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subs {
+		if m.UserID != 0 && m.UserID != sub.Client.UserID {
+			continue
+		}
+		if m.Role != "" && m.Role != sub.Client.Role {
+			continue
+		}
+		select {
+		case sub.C <- m:
+		default:
+		}
+	}
+}
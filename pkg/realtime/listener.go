@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// ModerationRole adalah peran yang menerima jumlah antrean moderasi
+const ModerationRole = "admin"
+
+// ModerationCounts adalah jumlah konten yang menunggu keputusan moderator
+type ModerationCounts struct {
+	PendingArticles  int `json:"pending_articles"`
+	PendingVideos    int `json:"pending_videos"`
+	PendingComments  int `json:"pending_comments"`
+	ReportedComments int `json:"reported_comments"`
+}
+
+// LoadModerationCounts menghitung antrean moderasi saat ini
+func LoadModerationCounts(ctx context.Context, db *sql.DB) (ModerationCounts, error) {
+	var c ModerationCounts
+	err := db.QueryRowContext(ctx, `SELECT
+			(SELECT COUNT(*) FROM articles WHERE status = 'pending approval'),
+			(SELECT COUNT(*) FROM videos WHERE status = 'pending approval'),
+			(SELECT COUNT(*) FROM comments WHERE status = 'pending'),
+			(SELECT COUNT(DISTINCT comment_id) FROM comment_reports WHERE status = 'open')`).
+		Scan(&c.PendingArticles, &c.PendingVideos, &c.PendingComments, &c.ReportedComments)
+	return c, err
+}
+
+// Listener mendengarkan kanal Postgres dan meneruskan event ke Hub. Listener memakai satu koneksi
+// dari pool selama berjalan dan menyambung ulang jika koneksi terputus.
+type Listener struct {
+	DB       *sql.DB
+	Hub      *Hub
+	Debounce time.Duration // Jeda sebelum antrean moderasi dihitung ulang, agar perubahan beruntun cukup dihitung sekali
+
+	refresh chan struct{}
+}
+
+// NewListener membuat Listener untuk Hub
+func NewListener(db *sql.DB, hub *Hub) *Listener {
+	return &Listener{DB: db, Hub: hub, Debounce: 500 * time.Millisecond, refresh: make(chan struct{}, 1)}
+}
+
+// Start mendengarkan kanal sampai context dibatalkan
+func (l *Listener) Start(ctx context.Context) {
+	go l.refreshModeration(ctx)
+
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > time.Minute {
+			delay = time.Second
+		}
+		log.Printf("realtime listener: %v; reconnecting in %s", err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay < 30*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+// listen menjalankan LISTEN pada koneksi khusus lalu menunggu notifikasi. Koneksi selalu dibuang
+// setelah selesai karena masih berstatus LISTEN.
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		pg := driverConn.(*stdlib.Conn).Conn()
+		if _, err := pg.Exec(ctx, "LISTEN "+Channel); err != nil {
+			return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+		}
+		// Klien yang terhubung saat koneksi terputus mungkin melewatkan perubahan
+		l.scheduleRefresh()
+		for {
+			n, err := pg.WaitForNotification(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+			}
+			l.handle([]byte(n.Payload))
+		}
+	})
+}
+
+// handle meneruskan payload dari trigger database. Event antrean moderasi hanya menandai bahwa
+// jumlahnya perlu dihitung ulang.
+func (l *Listener) handle(payload []byte) {
+	var m Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		log.Printf("realtime listener: invalid payload: %v", err)
+		return
+	}
+	if m.Event == EventModerationQueue {
+		l.scheduleRefresh()
+		return
+	}
+	l.Hub.Publish(m)
+}
+
+func (l *Listener) scheduleRefresh() {
+	select {
+	case l.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (l *Listener) refreshModeration(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.refresh:
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.Debounce):
+		}
+
+		counts, err := LoadModerationCounts(ctx, l.DB)
+		if err != nil {
+			log.Printf("realtime listener: moderation counts: %v", err)
+			continue
+		}
+		data, _ := json.Marshal(counts)
+		l.Hub.Publish(Message{Event: EventModerationQueue, Role: ModerationRole, Data: data})
+	}
+}
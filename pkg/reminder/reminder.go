@@ -0,0 +1,189 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"go-project/pkg/calendar"
+	"go-project/pkg/utils"
+	"log"
+	"time"
+)
+
+// Jenis notifikasi appointment
+const (
+	KindBookingConfirmation = "booking_confirmation"
+	KindHostAssigned        = "host_assigned"
+	KindReminder            = "reminder"
+)
+
+// Kanal pengiriman
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+)
+
+// Penerima notifikasi
+const (
+	RecipientUser = "user"
+	RecipientHost = "host"
+)
+
+// Config mengatur job pengiriman konfirmasi dan pengingat appointment
+type Config struct {
+	Interval    time.Duration   // jarak antar putaran job
+	Offsets     []time.Duration // pengingat dikirim sebesar offset sebelum appointment dimulai
+	Channels    []string        // kanal yang aktif
+	Lookback    time.Duration   // booking/penetapan host lebih lama dari ini tidak dikonfirmasi lagi
+	BatchSize   int             // jumlah notifikasi maksimal per putaran
+	MaxAttempts int             // percobaan kirim sebelum notifikasi ditandai gagal
+}
+
+// DefaultConfig mengembalikan pengaturan bawaan: pengingat 24 jam dan 1 jam sebelum appointment
+func DefaultConfig() Config {
+	return Config{
+		Interval:    time.Minute,
+		Offsets:     []time.Duration{24 * time.Hour, time.Hour},
+		Channels:    []string{ChannelWhatsApp, ChannelEmail},
+		Lookback:    24 * time.Hour,
+		BatchSize:   50,
+		MaxAttempts: 3,
+	}
+}
+
+// SendFunc mengirim satu pesan ke alamat tujuan pada sebuah kanal. Subject dan lampiran hanya dipakai email.
+type SendFunc func(to, subject, body string, attachments ...utils.Attachment) error
+
+// Job mencatat notifikasi yang jatuh tempo ke tabel appointment_notifications dan webinar_notifications
+// lalu mengirimkannya. Status pengiriman disimpan di database sehingga notifikasi tidak terkirim dua kali
+// setelah restart.
+type Job struct {
+	Store     *Store
+	Webinars  *WebinarStore // nil jika notifikasi webinar tidak aktif
+	Config    Config
+	Senders   map[string]SendFunc
+	Templates Renderer // Template pesan per jenis notifikasi dan penerima
+	Location  *time.Location
+}
+
+// NewJob membuat job notifikasi appointment dan webinar
+func NewJob(store *Store, webinars *WebinarStore, cfg Config, loc *time.Location, templates Renderer, senders map[string]SendFunc) *Job {
+	return &Job{Store: store, Webinars: webinars, Config: cfg, Senders: senders, Templates: templates, Location: loc}
+}
+
+// Start menjalankan job secara berkala sampai context dibatalkan
+func (j *Job) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.Config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.RunOnce(ctx); err != nil {
+			log.Printf("appointment notifications: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce mencatat notifikasi baru yang jatuh tempo lalu mengirim notifikasi yang tertunda
+func (j *Job) RunOnce(ctx context.Context) error {
+	now := time.Now()
+	for _, channel := range j.Config.Channels {
+		if _, ok := j.Senders[channel]; !ok {
+			continue
+		}
+		if err := j.Store.Enqueue(ctx, channel, now, j.Config.Lookback, j.Config.Offsets); err != nil {
+			return fmt.Errorf("enqueue %s: %w", channel, err)
+		}
+		if j.Webinars != nil {
+			if err := j.Webinars.Enqueue(ctx, channel, now, j.Config.Lookback, j.Config.Offsets); err != nil {
+				return fmt.Errorf("enqueue webinar %s: %w", channel, err)
+			}
+		}
+	}
+
+	deliver := func(n Notification) error {
+		return j.deliver(ctx, n)
+	}
+	if err := deliverBatch(ctx, j.Config.BatchSize, j.Store.DeliverNext, deliver); err != nil {
+		return fmt.Errorf("deliver: %w", err)
+	}
+	if j.Webinars != nil {
+		if err := deliverBatch(ctx, j.Config.BatchSize, j.Webinars.DeliverNext, deliver); err != nil {
+			return fmt.Errorf("deliver webinar: %w", err)
+		}
+	}
+	return nil
+}
+
+// deliverBatch mengirim paling banyak size notifikasi tertunda dari satu store
+func deliverBatch(ctx context.Context, size int, next func(context.Context, func(Notification) error) (bool, error), send func(Notification) error) error {
+	for i := 0; i < size; i++ {
+		delivered, err := next(ctx, send)
+		if err != nil || !delivered {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver merender template dan mengirim satu notifikasi
+func (j *Job) deliver(ctx context.Context, n Notification) error {
+	send, ok := j.Senders[n.Channel]
+	if !ok {
+		return fmt.Errorf("no sender for channel %s", n.Channel)
+	}
+	subject, body, err := Render(ctx, j.Templates, n, j.Location)
+	if err != nil {
+		return err
+	}
+
+	// Email konfirmasi menyertakan undangan kalender
+	var attachments []utils.Attachment
+	if n.Channel == ChannelEmail && n.Kind != KindReminder && n.Kind != KindWebinarReminder &&
+		n.Kind != KindWebinarWaitlisted && n.Kind != KindWebinarCancelled {
+		attachments = append(attachments, utils.Attachment{
+			Filename:    "invite.ics",
+			ContentType: calendar.ContentType + "; method=PUBLISH",
+			Data:        Invite(n).Bytes(),
+		})
+	}
+	return send(n.Recipient, subject, body, attachments...)
+}
+
+// Invite membuat undangan kalender untuk appointment atau webinar pada notifikasi
+func Invite(n Notification) calendar.Calendar {
+	if n.WebinarID != 0 {
+		return calendar.Calendar{Name: n.Title, Events: []calendar.Event{{
+			UID:       calendar.WebinarUID(n.WebinarID),
+			Summary:   n.Title,
+			Location:  n.LinkMeet,
+			URL:       n.LinkMeet,
+			Start:     n.Time,
+			End:       n.EndTime,
+			Status:    calendar.StatusConfirmed,
+			Organizer: n.HostEmail,
+			Attendees: []string{n.Email},
+		}}}
+	}
+
+	event := calendar.Event{
+		UID:       calendar.AppointmentUID(n.AppointmentID),
+		Summary:   "Appointment " + n.Name,
+		Location:  n.LinkMeet,
+		URL:       n.LinkMeet,
+		Start:     n.Time,
+		End:       n.EndTime,
+		Status:    calendar.AppointmentStatus(n.Status),
+		Organizer: n.HostEmail,
+	}
+	if n.Email != "" {
+		event.Attendees = []string{n.Email}
+	}
+	if n.HostName != "" {
+		event.Summary = "Appointment " + n.Name + " dengan " + n.HostName
+	}
+	return calendar.Calendar{Name: "Appointment", Events: []calendar.Event{event}}
+}
@@ -0,0 +1,210 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Notification adalah satu notifikasi appointment atau webinar beserta data yang dibutuhkan template
+type Notification struct {
+	ID            int
+	AppointmentID int
+	WebinarID     int // Diisi untuk notifikasi pendaftar webinar
+	Kind          string
+	RecipientRole string
+	Channel       string
+	Recipient     string
+	OffsetMinutes int
+	Attempts      int
+
+	Name      string
+	Email     string
+	HostName  string
+	HostEmail string
+	Time      time.Time
+	EndTime   time.Time
+	Status    string
+	LinkMeet  string
+	Title     string // Judul webinar
+	Note      string // Alasan pembatalan webinar
+}
+
+// Store menyimpan status pengiriman notifikasi appointment di tabel appointment_notifications
+type Store struct {
+	DB          *sql.DB
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+// NewStore membuat Store berbasis database
+func NewStore(db *sql.DB, cfg Config) *Store {
+	return &Store{DB: db, MaxAttempts: cfg.MaxAttempts, RetryDelay: cfg.Interval}
+}
+
+// Alamat penerima sesuai kanal: email atau nomor telepon
+const (
+	userRecipient = `CASE WHEN $1 = 'email' THEN a.email ELSE a.phone_number END`
+	hostRecipient = `CASE WHEN $1 = 'email' THEN h.email ELSE h.phone_number END`
+)
+
+// Enqueue mencatat konfirmasi booking, penetapan host dan pengingat yang sudah jatuh tempo.
+// Unique constraint pada tabel membuat setiap notifikasi hanya tercatat sekali.
+func (s *Store) Enqueue(ctx context.Context, channel string, now time.Time, lookback time.Duration, offsets []time.Duration) error {
+	lookbackMinutes := int(lookback / time.Minute)
+
+	statements := []string{
+		// Konfirmasi booking untuk user
+		`INSERT INTO appointment_notifications (appointment_id, kind, recipient_role, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT a.id, 'booking_confirmation', 'user', $1, ` + userRecipient + `, 0, ''
+		 FROM appointments a
+		 WHERE a.created_at >= NOW() - $2 * interval '1 minute' AND a.status <> 'cancelled'
+		   AND COALESCE(` + userRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		// Penetapan host untuk user, satu kali per host. Host yang dipilih saat booking sudah
+		// disebutkan di konfirmasi booking.
+		`INSERT INTO appointment_notifications (appointment_id, kind, recipient_role, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT a.id, 'host_assigned', 'user', $1, ` + userRecipient + `, 0, a.host_id::text
+		 FROM appointments a
+		 WHERE a.host_id IS NOT NULL AND a.host_assigned_at >= NOW() - $2 * interval '1 minute' AND a.status <> 'cancelled'
+		   AND a.host_assigned_at > a.created_at AND COALESCE(` + userRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		// Penetapan host untuk host
+		`INSERT INTO appointment_notifications (appointment_id, kind, recipient_role, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT a.id, 'host_assigned', 'host', $1, ` + hostRecipient + `, 0, a.host_id::text
+		 FROM appointments a JOIN users h ON h.id = a.host_id
+		 WHERE a.host_assigned_at >= NOW() - $2 * interval '1 minute' AND a.status <> 'cancelled'
+		   AND COALESCE(` + hostRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+	}
+	for _, query := range statements {
+		if _, err := s.DB.ExecContext(ctx, query, channel, lookbackMinutes); err != nil {
+			return err
+		}
+	}
+
+	// Pengingat diurutkan dari offset terbesar. Jika appointment dibooking mendadak sehingga beberapa
+	// pengingat jatuh tempo bersamaan, hanya pengingat dengan offset terkecil yang dikirim.
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	reminders := []string{
+		`INSERT INTO appointment_notifications (appointment_id, kind, recipient_role, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT a.id, 'reminder', 'user', $1, ` + userRecipient + `, $3, to_char(a.time, 'YYYY-MM-DD"T"HH24:MI')
+		 FROM appointments a
+		 WHERE a.status IN ('pending', 'confirmed', 'rescheduled') AND a.time > $2
+		   AND a.time - $3 * interval '1 minute' <= $2 AND a.time - $4 * interval '1 minute' > $2
+		   AND COALESCE(` + userRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		`INSERT INTO appointment_notifications (appointment_id, kind, recipient_role, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT a.id, 'reminder', 'host', $1, ` + hostRecipient + `, $3, to_char(a.time, 'YYYY-MM-DD"T"HH24:MI')
+		 FROM appointments a JOIN users h ON h.id = a.host_id
+		 WHERE a.status IN ('pending', 'confirmed', 'rescheduled') AND a.time > $2
+		   AND a.time - $3 * interval '1 minute' <= $2 AND a.time - $4 * interval '1 minute' > $2
+		   AND COALESCE(` + hostRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+	}
+	for i, offset := range sorted {
+		next := 0
+		if i+1 < len(sorted) {
+			next = int(sorted[i+1] / time.Minute)
+		}
+		for _, query := range reminders {
+			if _, err := s.DB.ExecContext(ctx, query, channel, now, int(offset/time.Minute), next); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeliverNext mengambil satu notifikasi tertunda, mengirimnya dengan send lalu menyimpan hasilnya.
+// Baris dikunci dengan SKIP LOCKED lalu diberi lease dan transaksi di-commit sebelum pengiriman, sehingga
+// beberapa instance aplikasi tidak mengirim notifikasi yang sama dan koneksi tidak ditahan selama pengiriman.
+// Mengembalikan false jika tidak ada notifikasi yang perlu dikirim.
+func (s *Store) DeliverNext(ctx context.Context, send func(Notification) error) (bool, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT n.id, n.appointment_id, n.kind, n.recipient_role, n.channel, n.recipient, n.offset_minutes, n.attempts,
+			  COALESCE(a.name, ''), COALESCE(a.email, ''), COALESCE(h.name, ''), COALESCE(h.email, ''),
+			  a.time, COALESCE(a.end_time, a.time + interval '60 minutes'), a.status, COALESCE(a.link_meet, '')
+			  FROM appointment_notifications n
+			  JOIN appointments a ON a.id = n.appointment_id
+			  LEFT JOIN users h ON h.id = a.host_id
+			  WHERE n.status = 'pending' AND n.next_attempt_at <= NOW()
+			  ORDER BY n.id
+			  LIMIT 1
+			  FOR UPDATE OF n SKIP LOCKED`
+	var n Notification
+	err = tx.QueryRowContext(ctx, query).Scan(&n.ID, &n.AppointmentID, &n.Kind, &n.RecipientRole, &n.Channel, &n.Recipient,
+		&n.OffsetMinutes, &n.Attempts, &n.Name, &n.Email, &n.HostName, &n.HostEmail, &n.Time, &n.EndTime, &n.Status, &n.LinkMeet)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Appointment yang dibatalkan atau sudah selesai tidak perlu diingatkan lagi
+	skip := n.Kind == KindReminder && (n.Status == "cancelled" || n.Status == "completed" || n.Status == "no-show")
+	return deliverClaimed(ctx, s.DB, tx, "appointment_notifications", n, skip, send, s.MaxAttempts, s.RetryDelay)
+}
+
+// leaseDuration adalah lama notifikasi yang sedang dikirim tidak diambil worker lain. Jika proses berhenti
+// sebelum hasil pengiriman tersimpan, notifikasi dicoba lagi setelah lease habis.
+const leaseDuration = 5 * time.Minute
+
+// deliverClaimed menyelesaikan notifikasi yang sudah dikunci di tx. Notifikasi yang tidak perlu dikirim
+// langsung ditandai skipped. Selain itu percobaan dihitung dan next_attempt_at dimajukan sebagai lease, tx
+// di-commit, lalu notifikasi dikirim di luar transaksi dan hasilnya disimpan.
+func deliverClaimed(ctx context.Context, db *sql.DB, tx *sql.Tx, table string, n Notification, skip bool,
+	send func(Notification) error, maxAttempts int, retryDelay time.Duration) (bool, error) {
+	if skip {
+		if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET status = 'skipped', updated_at = NOW() WHERE id = $1`, n.ID); err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+
+	_, err := tx.ExecContext(ctx, `UPDATE `+table+`
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $1 * interval '1 second', updated_at = NOW()
+		WHERE id = $2`, int(leaseDuration/time.Second), n.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	n.Attempts++
+
+	// Hasil tetap disimpan walaupun job sedang dihentikan, agar pesan yang sudah terkirim tidak dikirim ulang
+	return true, saveResult(context.WithoutCancel(ctx), db, table, n, send(n), maxAttempts, retryDelay)
+}
+
+// saveResult menyimpan hasil pengiriman notifikasi yang lease-nya dipegang pemanggil. Pengiriman yang gagal
+// dicoba lagi dengan jeda yang makin panjang sampai maxAttempts. Jumlah percobaan dipakai sebagai penanda
+// lease, sehingga hasil tidak menimpa baris yang sudah diambil ulang worker lain setelah lease habis.
+func saveResult(ctx context.Context, db *sql.DB, table string, n Notification, sendErr error, maxAttempts int, retryDelay time.Duration) error {
+	var err error
+	if sendErr != nil {
+		status := "pending"
+		if n.Attempts >= maxAttempts {
+			status = "failed"
+		}
+		_, err = db.ExecContext(ctx, `UPDATE `+table+`
+			SET status = $1, last_error = $2, next_attempt_at = NOW() + $3 * interval '1 second', updated_at = NOW()
+			WHERE id = $4 AND attempts = $5 AND status = 'pending'`,
+			status, sendErr.Error(), int(retryDelay/time.Second)*n.Attempts, n.ID, n.Attempts)
+	} else {
+		_, err = db.ExecContext(ctx, `UPDATE `+table+`
+			SET status = 'sent', last_error = NULL, sent_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND attempts = $2 AND status = 'pending'`, n.ID, n.Attempts)
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-project/pkg/notify"
+	"time"
+)
+
+// Renderer merender template notifikasi per event dan bahasa; dipenuhi *notify.Templates
+type Renderer interface {
+	Render(ctx context.Context, event, locale string, vars json.RawMessage) (string, string, error)
+}
+
+// events memetakan jenis notifikasi dan penerima ke event template di notify.TemplateSpecs,
+// sehingga pesan bisa diubah admin lewat registry template
+var events = map[string]string{
+	KindBookingConfirmation + ":" + RecipientUser: notify.EventBookingConfirmationUser,
+	KindHostAssigned + ":" + RecipientUser:        notify.EventHostAssignedUser,
+	KindHostAssigned + ":" + RecipientHost:        notify.EventHostAssignedHost,
+	KindReminder + ":" + RecipientUser:            notify.EventReminderUser,
+	KindReminder + ":" + RecipientHost:            notify.EventReminderHost,
+	KindWebinarRegistered + ":" + RecipientUser:   notify.EventWebinarRegisteredUser,
+	KindWebinarWaitlisted + ":" + RecipientUser:   notify.EventWebinarWaitlistedUser,
+	KindWebinarPromoted + ":" + RecipientUser:     notify.EventWebinarPromotedUser,
+	KindWebinarReminder + ":" + RecipientUser:     notify.EventWebinarReminderUser,
+	KindWebinarCancelled + ":" + RecipientUser:    notify.EventWebinarCancelledUser,
+}
+
+// Render menghasilkan subject dan isi pesan untuk sebuah notifikasi dengan template dari registry.
+// Penerima appointment dan webinar belum memiliki pilihan bahasa, jadi pesan memakai bahasa bawaan.
+func Render(ctx context.Context, templates Renderer, n Notification, loc *time.Location) (string, string, error) {
+	event, ok := events[n.Kind+":"+n.RecipientRole]
+	if !ok {
+		return "", "", fmt.Errorf("no template for %s to %s", n.Kind, n.RecipientRole)
+	}
+
+	offsetHours := 0
+	if n.OffsetMinutes >= 60 && n.OffsetMinutes%60 == 0 {
+		offsetHours = n.OffsetMinutes / 60
+	}
+	vars, err := notify.Vars(notify.ReminderVars{
+		Name:          n.Name,
+		Title:         n.Title,
+		HostName:      n.HostName,
+		Time:          n.Time.In(loc).Format("02 Jan 2006 15:04 MST"),
+		Status:        n.Status,
+		LinkMeet:      n.LinkMeet,
+		OffsetHours:   offsetHours,
+		OffsetMinutes: n.OffsetMinutes,
+		Note:          n.Note,
+		CanReply:      n.Channel == ChannelWhatsApp,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return templates.Render(ctx, event, notify.DefaultLocale, vars)
+}
@@ -0,0 +1,74 @@
+package reminder
+
+import (
+	"context"
+	"go-project/pkg/notify"
+	"strings"
+	"testing"
+	"time"
+)
+
+// enqueued adalah pasangan jenis notifikasi dan penerima yang dicatat Store.Enqueue dan WebinarStore.Enqueue
+var enqueued = []struct{ kind, recipient string }{
+	{KindBookingConfirmation, RecipientUser},
+	{KindHostAssigned, RecipientUser},
+	{KindHostAssigned, RecipientHost},
+	{KindReminder, RecipientUser},
+	{KindReminder, RecipientHost},
+	{KindWebinarRegistered, RecipientUser},
+	{KindWebinarWaitlisted, RecipientUser},
+	{KindWebinarPromoted, RecipientUser},
+	{KindWebinarReminder, RecipientUser},
+	{KindWebinarCancelled, RecipientUser},
+}
+
+func TestRenderEveryEnqueuedKind(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates := notify.NewTemplates(nil)
+	for _, e := range enqueued {
+		for _, channel := range []string{ChannelEmail, ChannelWhatsApp} {
+			n := Notification{
+				Kind:          e.kind,
+				RecipientRole: e.recipient,
+				Channel:       channel,
+				Name:          "Budi",
+				Title:         "Webinar Gizi",
+				HostName:      "Dr. Sari",
+				Time:          time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+				Status:        "pending",
+				LinkMeet:      "https://meet.test/abc",
+				OffsetMinutes: 60,
+				Note:          "Pembicara berhalangan",
+			}
+			subject, body, err := Render(context.Background(), templates, n, loc)
+			if err != nil {
+				t.Errorf("Render(%s, %s, %s): %v", e.kind, e.recipient, channel, err)
+				continue
+			}
+			if subject == "" || strings.Contains(body, "<no value>") {
+				t.Errorf("Render(%s, %s, %s) = %q, %q", e.kind, e.recipient, channel, subject, body)
+			}
+		}
+	}
+	if len(events) != len(enqueued) {
+		t.Errorf("%d template events but %d enqueued kinds; add new kinds to enqueued", len(events), len(enqueued))
+	}
+}
+
+func TestRenderReminderOffset(t *testing.T) {
+	templates := notify.NewTemplates(nil)
+	for minutes, want := range map[int]string{60: "dimulai 1 jam lagi", 1440: "dimulai 24 jam lagi", 30: "dimulai 30 menit lagi", 90: "dimulai 90 menit lagi"} {
+		n := Notification{Kind: KindReminder, RecipientRole: RecipientUser, Channel: ChannelEmail, Name: "Budi",
+			Time: time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC), OffsetMinutes: minutes}
+		_, body, err := Render(context.Background(), templates, n, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(body, want) {
+			t.Errorf("offset %d minutes: body = %q, want %q", minutes, body, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+)
+
+// Jenis notifikasi untuk pendaftar webinar
+const (
+	KindWebinarRegistered = "webinar_registered"
+	KindWebinarWaitlisted = "webinar_waitlisted"
+	KindWebinarPromoted   = "webinar_promoted"
+	KindWebinarReminder   = "webinar_reminder"
+	KindWebinarCancelled  = "webinar_cancelled"
+)
+
+// WebinarStore menyimpan status pengiriman notifikasi pendaftar webinar di tabel webinar_notifications
+type WebinarStore struct {
+	DB          *sql.DB
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+// NewWebinarStore membuat WebinarStore berbasis database
+func NewWebinarStore(db *sql.DB, cfg Config) *WebinarStore {
+	return &WebinarStore{DB: db, MaxAttempts: cfg.MaxAttempts, RetryDelay: cfg.Interval}
+}
+
+const registrantRecipient = `CASE WHEN $1 = 'email' THEN r.email ELSE r.phone_number END`
+
+// Enqueue mencatat konfirmasi pendaftaran, daftar tunggu, promosi dari daftar tunggu, pembatalan webinar
+// dan pengingat yang sudah jatuh tempo untuk pendaftar webinar
+func (s *WebinarStore) Enqueue(ctx context.Context, channel string, now time.Time, lookback time.Duration, offsets []time.Duration) error {
+	lookbackMinutes := int(lookback / time.Minute)
+
+	statements := []string{
+		// Konfirmasi pendaftaran yang langsung mendapat kursi
+		`INSERT INTO webinar_notifications (registration_id, kind, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT r.id, 'webinar_registered', $1, ` + registrantRecipient + `, 0, ''
+		 FROM webinar_registrations r
+		 WHERE r.status = 'registered' AND r.promoted_at IS NULL AND r.created_at >= NOW() - $2 * interval '1 minute'
+		   AND COALESCE(` + registrantRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		// Pendaftar yang masuk daftar tunggu
+		`INSERT INTO webinar_notifications (registration_id, kind, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT r.id, 'webinar_waitlisted', $1, ` + registrantRecipient + `, 0, ''
+		 FROM webinar_registrations r
+		 WHERE r.status = 'waitlisted' AND r.created_at >= NOW() - $2 * interval '1 minute'
+		   AND COALESCE(` + registrantRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		// Pendaftar yang mendapat kursi dari daftar tunggu
+		`INSERT INTO webinar_notifications (registration_id, kind, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT r.id, 'webinar_promoted', $1, ` + registrantRecipient + `, 0, ''
+		 FROM webinar_registrations r
+		 WHERE r.status = 'registered' AND r.promoted_at >= NOW() - $2 * interval '1 minute'
+		   AND COALESCE(` + registrantRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+		// Pemberitahuan ke peserta dan daftar tunggu saat webinar dibatalkan admin
+		`INSERT INTO webinar_notifications (registration_id, kind, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT r.id, 'webinar_cancelled', $1, ` + registrantRecipient + `, 0, ''
+		 FROM webinar_registrations r JOIN webinars w ON w.id = r.webinar_id
+		 WHERE w.status = 'cancelled' AND w.cancelled_at >= NOW() - $2 * interval '1 minute'
+		   AND r.status IN ('registered', 'waitlisted')
+		   AND COALESCE(` + registrantRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`,
+	}
+	for _, query := range statements {
+		if _, err := s.DB.ExecContext(ctx, query, channel, lookbackMinutes); err != nil {
+			return err
+		}
+	}
+
+	// Sama seperti pengingat appointment, hanya offset terkecil yang dikirim jika beberapa jatuh tempo bersamaan
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	reminder := `INSERT INTO webinar_notifications (registration_id, kind, channel, recipient, offset_minutes, dedupe_key)
+		 SELECT r.id, 'webinar_reminder', $1, ` + registrantRecipient + `, $3, to_char(w.start_time, 'YYYY-MM-DD"T"HH24:MI')
+		 FROM webinar_registrations r JOIN webinars w ON w.id = r.webinar_id
+		 WHERE r.status = 'registered' AND w.status = 'scheduled' AND w.start_time > $2
+		   AND w.start_time - $3 * interval '1 minute' <= $2 AND w.start_time - $4 * interval '1 minute' > $2
+		   AND COALESCE(` + registrantRecipient + `, '') <> ''
+		 ON CONFLICT DO NOTHING`
+	for i, offset := range sorted {
+		next := 0
+		if i+1 < len(sorted) {
+			next = int(sorted[i+1] / time.Minute)
+		}
+		if _, err := s.DB.ExecContext(ctx, reminder, channel, now, int(offset/time.Minute), next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverNext mengambil satu notifikasi webinar tertunda, mengirimnya di luar transaksi lalu menyimpan
+// hasilnya (lihat Store.DeliverNext). Mengembalikan false jika tidak ada notifikasi yang perlu dikirim.
+func (s *WebinarStore) DeliverNext(ctx context.Context, send func(Notification) error) (bool, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT n.id, w.id, n.kind, n.channel, n.recipient, n.offset_minutes, n.attempts,
+			  r.name, r.email, COALESCE(h.name, ''), COALESCE(h.email, ''), COALESCE(w.title, ''),
+			  COALESCE(w.start_time, r.created_at), COALESCE(w.end_time, w.start_time + interval '60 minutes', r.created_at), r.status, COALESCE(w.link_meet, ''),
+			  w.status, COALESCE(w.cancellation_reason, '')
+			  FROM webinar_notifications n
+			  JOIN webinar_registrations r ON r.id = n.registration_id
+			  JOIN webinars w ON w.id = r.webinar_id
+			  LEFT JOIN users h ON h.id = w.host_id
+			  WHERE n.status = 'pending' AND n.next_attempt_at <= NOW()
+			  ORDER BY n.id
+			  LIMIT 1
+			  FOR UPDATE OF n SKIP LOCKED`
+	n := Notification{RecipientRole: RecipientUser}
+	var webinarStatus string
+	err = tx.QueryRowContext(ctx, query).Scan(&n.ID, &n.WebinarID, &n.Kind, &n.Channel, &n.Recipient, &n.OffsetMinutes,
+		&n.Attempts, &n.Name, &n.Email, &n.HostName, &n.HostEmail, &n.Title, &n.Time, &n.EndTime, &n.Status, &n.LinkMeet,
+		&webinarStatus, &n.Note)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Pendaftaran yang sudah dibatalkan tidak perlu diberi notifikasi lagi, begitu juga notifikasi
+	// selain pemberitahuan pembatalan untuk webinar yang sudah dibatalkan
+	skip := n.Status == "cancelled" || (webinarStatus == "cancelled" && n.Kind != KindWebinarCancelled)
+	return deliverClaimed(ctx, s.DB, tx, "webinar_notifications", n, skip, send, s.MaxAttempts, s.RetryDelay)
+}
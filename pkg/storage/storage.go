@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound dikembalikan jika file dengan key tersebut tidak ada
+var ErrNotFound = errors.New("file not found")
+
+// Storage menyimpan file unggahan dan mengembalikan key untuk membacanya kembali
+type Storage interface {
+	Save(folder, filename string, r io.Reader) (key string, err error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// LocalStorage menyimpan file di direktori lokal (storage.upload_dir)
+type LocalStorage struct {
+	Dir string
+}
+
+// NewLocalStorage membuat storage lokal di direktori dir
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{Dir: dir}
+}
+
+// Save menyimpan file dengan nama acak agar nama asli dari user tidak dipakai sebagai path
+func (s *LocalStorage) Save(folder, filename string, r io.Reader) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	key := filepath.ToSlash(filepath.Join(cleanFolder(folder), hex.EncodeToString(raw)+strings.ToLower(filepath.Ext(filename))))
+
+	path := filepath.Join(s.Dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	return key, f.Close()
+}
+
+// Open membuka file berdasarkan key yang dikembalikan Save
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, ok := s.path(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete menghapus file berdasarkan key yang dikembalikan Save
+func (s *LocalStorage) Delete(key string) error {
+	path, ok := s.path(key)
+	if !ok {
+		return ErrNotFound
+	}
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// path mengubah key menjadi path di dalam Dir; key yang keluar dari Dir ditolak
+func (s *LocalStorage) path(key string) (string, bool) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+		return "", false
+	}
+	return filepath.Join(s.Dir, clean), true
+}
+
+// cleanFolder hanya mengizinkan huruf, angka, "-" dan "_" pada nama folder
+func cleanFolder(folder string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, folder)
+}
+
+// Serve mengirim file dari storage sebagai unduhan dengan nama file aslinya
+func Serve(w http.ResponseWriter, s Storage, key, filename, contentType string) error {
+	f, err := s.Open(key)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const appointmentTokenAudience = "appointment_manage"
+
+// Membuat token bertanda tangan untuk link kelola appointment (batal/jadwal ulang) milik user.
+// Token ditandatangani dengan key turunan sehingga tidak diterima sebagai token login.
+func GenerateAppointmentToken(appointmentID int, expiresAt time.Time) (string, error) {
+	if len(appointmentKey) == 0 {
+		return "", ErrJWTNotConfigured
+	}
+	claims := &jwt.StandardClaims{
+		Subject:   strconv.Itoa(appointmentID),
+		Audience:  appointmentTokenAudience,
+		Issuer:    jwtIssuer,
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(appointmentKey)
+}
+
+// Validasi token link kelola appointment dan mengembalikan ID appointment
+func ValidateAppointmentToken(tokenString string) (int, error) {
+	if len(appointmentKey) == 0 {
+		return 0, ErrJWTNotConfigured
+	}
+	claims := &jwt.StandardClaims{}
+	if err := parseHS256(tokenString, appointmentKey, claims); err != nil {
+		return 0, err
+	}
+	if !claims.VerifyAudience(appointmentTokenAudience, true) {
+		return 0, errors.New("invalid appointment token")
+	}
+	return strconv.Atoi(claims.Subject)
+}
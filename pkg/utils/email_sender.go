@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// SMTPSettings adalah pengaturan server SMTP untuk SendEmail
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+var smtpSettings SMTPSettings
+
+// ConfigureSMTP mengatur server SMTP yang dipakai SendEmail, dipanggil sekali saat aplikasi mulai
+func ConfigureSMTP(settings SMTPSettings) {
+	smtpSettings = settings
+}
+
+// Attachment adalah file yang dilampirkan pada email, misalnya undangan kalender .ics
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Fungsi untuk mengirimkan email teks melalui SMTP yang diatur dengan ConfigureSMTP
+func SendEmail(to, subject, body string, attachments ...Attachment) error {
+	host, from := smtpSettings.Host, smtpSettings.From
+	if host == "" || from == "" || to == "" {
+		return fmt.Errorf("invalid SMTP configuration or recipient")
+	}
+	port := smtpSettings.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if smtpSettings.Username != "" {
+		auth = smtp.PlainAuth("", smtpSettings.Username, smtpSettings.Password, host)
+	}
+
+	// Karakter baris baru dibuang agar penerima dan subject tidak bisa menyisipkan header lain
+	stripNewlines := strings.NewReplacer("\r", "", "\n", "")
+	to = stripNewlines.Replace(to)
+	subject = stripNewlines.Replace(subject)
+
+	message, err := buildEmail(from, to, subject, body, attachments)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(net.JoinHostPort(host, strconv.Itoa(port)), auth, from, []string{to}, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// buildEmail menyusun pesan MIME: teks biasa, atau multipart/mixed jika ada lampiran
+func buildEmail(from, to, subject, body string, attachments []Attachment) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + to + "\r\n")
+	buf.WriteString("Subject: " + subject + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(attachments) == 0 {
+		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+		buf.WriteString(body)
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	buf.WriteString("Content-Type: multipart/mixed; boundary=" + writer.Boundary() + "\r\n\r\n")
+
+	text, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(text)
+	qp.Write([]byte(body))
+	qp.Close()
+
+	for _, a := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {`attachment; filename="` + a.Filename + `"`},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
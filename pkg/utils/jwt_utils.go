@@ -1,22 +1,72 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"go-project/internal/admin/model"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
-// Secret key untuk signing JWT
-var jwtKey = []byte("your_secret_key")
+// jwtIssuer adalah issuer token login yang diterima AuthMiddleware
+const jwtIssuer = "admin_app"
+
+// Secret key dan masa berlaku token JWT, diatur dengan ConfigureJWT
+var (
+	jwtKey         []byte
+	appointmentKey []byte // Key terpisah untuk token link kelola appointment, diturunkan dari secret JWT
+	streamKey      []byte // Key terpisah untuk tiket stream realtime, diturunkan dari secret JWT
+	jwtTTL         = 24 * time.Hour
+)
+
+// Claims adalah klaim token login. Role adalah peran user saat login (admin, staff atau user)
+// dan dipakai middleware untuk membatasi route admin.
+type Claims struct {
+	Role string `json:"role,omitempty"`
+	jwt.StandardClaims
+}
+
+// ErrJWTNotConfigured dikembalikan jika secret JWT belum diatur, agar token tidak ditandatangani dengan key kosong
+var ErrJWTNotConfigured = errors.New("jwt secret is not configured")
+
+// ConfigureJWT mengatur secret untuk signing JWT dan masa berlaku token, dipanggil sekali saat aplikasi mulai.
+// Token link kelola appointment dan tiket stream ditandatangani dengan key turunan agar tidak bisa dipakai
+// sebagai token login.
+func ConfigureJWT(secret string, ttl time.Duration) {
+	jwtKey = []byte(secret)
+	appointmentKey, streamKey = nil, nil
+	if secret != "" {
+		appointmentKey = deriveKey(jwtKey, appointmentTokenAudience)
+		streamKey = deriveKey(jwtKey, streamTicketAudience)
+	}
+	if ttl > 0 {
+		jwtTTL = ttl
+	}
+}
+
+// deriveKey menurunkan key untuk satu jenis token dari secret utama dengan HMAC-SHA256
+func deriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
 
 // Membuat token untuk admin
 func GenerateJWT(admin model.User) (string, error) {
+	if len(jwtKey) == 0 {
+		return "", ErrJWTNotConfigured
+	}
 	// Membuat klaim (claims) untuk JWT
-	claims := &jwt.StandardClaims{
-		Subject:   admin.Email,
-		Issuer:    "admin_app",
-		ExpiresAt: time.Now().Add(24 * time.Hour).Unix(),
+	claims := &Claims{
+		Role: admin.Role,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   admin.Email,
+			Issuer:    jwtIssuer,
+			ExpiresAt: time.Now().Add(jwtTTL).Unix(),
+		},
 	}
 
 	// Membuat token dengan signing method HMAC dan klaim
@@ -24,18 +74,35 @@ func GenerateJWT(admin model.User) (string, error) {
 	return token.SignedString(jwtKey)
 }
 
-// Validasi JWT dan mendapatkan klaim
-func ValidateJWT(tokenString string) (*jwt.StandardClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
-	})
-	if err != nil || !token.Valid {
+// Validasi JWT login dan mendapatkan klaim. Token dengan audience lain, misalnya token link kelola
+// appointment, ditolak walaupun tanda tangannya valid.
+func ValidateJWT(tokenString string) (*Claims, error) {
+	if len(jwtKey) == 0 {
+		return nil, ErrJWTNotConfigured
+	}
+	claims := &Claims{}
+	if err := parseHS256(tokenString, jwtKey, claims); err != nil {
 		return nil, err
 	}
+	if claims.Audience != "" || claims.Issuer != jwtIssuer {
+		return nil, errors.New("token is not a login token")
+	}
+	return claims, nil
+}
 
-	// Mendapatkan klaim
-	if claims, ok := token.Claims.(*jwt.StandardClaims); ok {
-		return claims, nil
+// parseHS256 memverifikasi tanda tangan HS256 dan masa berlaku token lalu mengisi claims
+func parseHS256(tokenString string, key []byte, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return key, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New("invalid token")
 	}
-	return nil, err
+	return nil
 }
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"go-project/internal/admin/model"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestLoginAndAppointmentTokensAreNotInterchangeable(t *testing.T) {
+	ConfigureJWT(testSecret, time.Hour)
+
+	login, err := GenerateJWT(model.User{Email: "admin@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manage, err := GenerateAppointmentToken(42, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims, err := ValidateJWT(login); err != nil || claims.Subject != "admin@example.com" {
+		t.Errorf("ValidateJWT(login) = %v, %v; want subject admin@example.com", claims, err)
+	}
+	if id, err := ValidateAppointmentToken(manage); err != nil || id != 42 {
+		t.Errorf("ValidateAppointmentToken(manage) = %d, %v; want 42", id, err)
+	}
+	if _, err := ValidateJWT(manage); err == nil {
+		t.Error("ValidateJWT accepted an appointment manage token")
+	}
+	if _, err := ValidateAppointmentToken(login); err == nil {
+		t.Error("ValidateAppointmentToken accepted a login token")
+	}
+}
+
+func TestValidateJWTRejectsManageAudienceSignedWithLoginKey(t *testing.T) {
+	ConfigureJWT(testSecret, time.Hour)
+
+	// Token kelola appointment lama ditandatangani dengan key login
+	claims := &jwt.StandardClaims{
+		Subject:   "42",
+		Audience:  appointmentTokenAudience,
+		Issuer:    jwtIssuer,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateJWT(token); err == nil {
+		t.Error("ValidateJWT accepted a token with the appointment_manage audience")
+	}
+}
+
+func TestValidateJWTReturnsRole(t *testing.T) {
+	ConfigureJWT(testSecret, time.Hour)
+
+	token, err := GenerateJWT(model.User{Email: "staff@example.com", Role: "staff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := ValidateJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.Role != "staff" || claims.Subject != "staff@example.com" {
+		t.Errorf("ValidateJWT = role %q subject %q, want staff and staff@example.com", claims.Role, claims.Subject)
+	}
+}
+
+func TestStreamTicketIsNotALoginToken(t *testing.T) {
+	ConfigureJWT(testSecret, time.Hour)
+
+	ticket, err := GenerateStreamTicket("user@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	login, err := GenerateJWT(model.User{Email: "user@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if email, err := ValidateStreamTicket(ticket); err != nil || email != "user@example.com" {
+		t.Errorf("ValidateStreamTicket(ticket) = %q, %v; want user@example.com", email, err)
+	}
+	if _, err := ValidateJWT(ticket); err == nil {
+		t.Error("ValidateJWT accepted a stream ticket")
+	}
+	if _, err := ValidateStreamTicket(login); err == nil {
+		t.Error("ValidateStreamTicket accepted a login token")
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	if err := ConfigureTrustedProxies([]string{"10.0.0.0/8", "127.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { trustedProxies = nil })
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{"direct client ignores header", "203.0.113.7:5000", "1.2.3.4", "203.0.113.7"},
+		{"trusted proxy uses header", "10.1.2.3:5000", "198.51.100.9", "198.51.100.9"},
+		{"spoofed leftmost entry is skipped", "10.1.2.3:5000", "1.2.3.4, 198.51.100.9", "198.51.100.9"},
+		{"chained trusted proxies", "127.0.0.1:5000", "198.51.100.9, 10.9.9.9", "198.51.100.9"},
+		{"trusted proxy without header", "10.1.2.3:5000", "", "10.1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remote
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigureTrustedProxiesRejectsInvalid(t *testing.T) {
+	if err := ConfigureTrustedProxies([]string{"not-an-ip"}); err == nil {
+		t.Fatal("expected error for invalid proxy address")
+	}
+}
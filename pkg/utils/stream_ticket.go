@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const streamTicketAudience = "realtime_stream"
+
+// StreamTicketTTL adalah masa berlaku tiket stream. Tiket hanya dipakai untuk membuka koneksi
+// sehingga cukup berlaku sebentar.
+const StreamTicketTTL = time.Minute
+
+// Membuat tiket untuk membuka stream realtime (SSE atau WebSocket) milik user yang sedang login.
+// EventSource dan WebSocket di browser tidak bisa mengirim header, jadi tiket dikirim lewat query string
+// menggantikan token login yang berlaku lama. Tiket ditandatangani dengan key turunan sehingga tidak
+// diterima sebagai token login.
+func GenerateStreamTicket(email string) (string, error) {
+	if len(streamKey) == 0 {
+		return "", ErrJWTNotConfigured
+	}
+	claims := &jwt.StandardClaims{
+		Subject:   email,
+		Audience:  streamTicketAudience,
+		Issuer:    jwtIssuer,
+		ExpiresAt: time.Now().Add(StreamTicketTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(streamKey)
+}
+
+// Validasi tiket stream dan mengembalikan email user pemiliknya
+func ValidateStreamTicket(ticket string) (string, error) {
+	if len(streamKey) == 0 {
+		return "", ErrJWTNotConfigured
+	}
+	claims := &jwt.StandardClaims{}
+	if err := parseHS256(ticket, streamKey, claims); err != nil {
+		return "", err
+	}
+	if !claims.VerifyAudience(streamTicketAudience, true) || claims.Subject == "" {
+		return "", errors.New("invalid stream ticket")
+	}
+	return claims.Subject, nil
+}
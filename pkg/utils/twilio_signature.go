@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"net/url"
+
+	"github.com/twilio/twilio-go/client"
+)
+
+// TwilioSignatureHeader adalah header tanda tangan yang dikirim Twilio pada setiap webhook
+const TwilioSignatureHeader = "X-Twilio-Signature"
+
+// Validasi tanda tangan webhook Twilio terhadap URL lengkap yang didaftarkan dan parameter POST.
+// Auth token kosong selalu ditolak.
+func ValidateTwilioSignature(authToken, fullURL string, params url.Values, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+	values := make(map[string]string, len(params))
+	for key := range params {
+		values[key] = params.Get(key)
+	}
+	validator := client.NewRequestValidator(authToken)
+	return validator.Validate(fullURL, values, signature)
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"testing"
+)
+
+// twilioSign menghitung tanda tangan seperti dokumentasi Twilio: HMAC-SHA1 dari URL lalu pasangan
+// kunci dan nilai yang diurutkan menurut kunci, di-encode base64
+func twilioSign(authToken, fullURL string, params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	data := fullURL
+	for _, key := range keys {
+		data += key + params.Get(key)
+	}
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateTwilioSignature(t *testing.T) {
+	const token = "twilio-auth-token"
+	const fullURL = "https://example.com/api/whatsapp/webhook"
+	params := url.Values{"From": {"whatsapp:+628123456789"}, "Body": {"YA"}, "MessageSid": {"SM123"}}
+	valid := twilioSign(token, fullURL, params)
+
+	tests := []struct {
+		name      string
+		token     string
+		url       string
+		params    url.Values
+		signature string
+		want      bool
+	}{
+		{"valid signature", token, fullURL, params, valid, true},
+		{"wrong token", "other-token", fullURL, params, valid, false},
+		{"different url", token, "https://example.com/other", params, valid, false},
+		{"tampered body", token, fullURL, url.Values{"From": {"whatsapp:+628123456789"}, "Body": {"BATAL"}, "MessageSid": {"SM123"}}, valid, false},
+		{"empty token", "", fullURL, params, twilioSign("", fullURL, params), false},
+		{"empty signature", token, fullURL, params, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateTwilioSignature(tt.token, tt.url, tt.params, tt.signature); got != tt.want {
+				t.Errorf("ValidateTwilioSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,24 +2,43 @@ package utils
 
 import (
 	"fmt"
-	"os"
+	"strings"
 
 	"github.com/twilio/twilio-go"
 	openapi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
+// TwilioSettings adalah akun Twilio dan nomor pengirim WhatsApp untuk SendWhatsAppNotification
+type TwilioSettings struct {
+	AccountSID   string
+	AuthToken    string
+	WhatsAppFrom string
+}
+
+var twilioSettings TwilioSettings
+
+// ConfigureTwilio mengatur akun Twilio yang dipakai SendWhatsAppNotification, dipanggil sekali saat aplikasi mulai
+func ConfigureTwilio(settings TwilioSettings) {
+	twilioSettings = settings
+}
+
 // Fungsi untuk mengirimkan notifikasi WhatsApp
 func SendWhatsAppNotification(to string, body string) error {
 	// Validasi input
-	from := os.Getenv("TWILIO_WHATSAPP_FROM")
+	from := twilioSettings.WhatsAppFrom
 	if from == "" || to == "" {
 		return fmt.Errorf("invalid 'from' or 'to' number")
 	}
 
+	// Nomor tujuan tanpa prefix dianggap nomor WhatsApp
+	if !strings.HasPrefix(to, "whatsapp:") {
+		to = "whatsapp:" + to
+	}
+
 	// Buat Twilio client
 	client := twilio.NewRestClientWithParams(twilio.ClientParams{
-		Username: os.Getenv("TWILIO_ACCOUNT_SID"),
-		Password: os.Getenv("TWILIO_AUTH_TOKEN"),
+		Username: twilioSettings.AccountSID,
+		Password: twilioSettings.AuthToken,
 	})
 
 	// Siapkan parameter pesan
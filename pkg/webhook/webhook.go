@@ -0,0 +1,173 @@
+// Package webhook mengirim event konten dan appointment ke URL milik sistem lain (situs marketing,
+// CRM) yang didaftarkan admin. Event dicatat ke webhook_deliveries oleh trigger database dalam
+// transaksi yang sama dengan perubahan datanya, lalu dikirim oleh Worker dengan percobaan ulang.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Event yang bisa dilanggan
+const (
+	EventArticlePublished    = "article.published"
+	EventTestimonialApproved = "testimonial.approved"
+	EventAppointmentBooked   = "appointment.booked"
+	EventTest                = "webhook.test" // Hanya dikirim lewat endpoint uji, tidak bisa dilanggan
+)
+
+// Events adalah event yang bisa dipilih pada langganan webhook
+var Events = map[string]string{
+	EventArticlePublished:    "Artikel disetujui atau diterbitkan",
+	EventTestimonialApproved: "Testimonial disetujui",
+	EventAppointmentBooked:   "Appointment baru dibooking",
+}
+
+// Header pada setiap request webhook
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign menghitung tanda tangan HMAC-SHA256 atas "<timestamp>.<body>". Penerima menghitung ulang dengan
+// secret yang sama dan membandingkannya dengan header X-Webhook-Signature; timestamp ikut ditandatangani
+// agar request lama tidak bisa diputar ulang.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret membuat secret acak untuk langganan baru
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Request adalah satu pengiriman webhook
+type Request struct {
+	DeliveryID int
+	Event      string
+	URL        string
+	Secret     string
+	Payload    []byte
+}
+
+// Result adalah hasil satu percobaan pengiriman. Isi respons tidak disimpan agar endpoint uji tidak
+// bisa dipakai membaca layanan lain.
+type Result struct {
+	StatusCode int   `json:"status_code,omitempty"`
+	Duration   int64 `json:"duration_ms"`
+}
+
+// ErrBlockedAddress dikembalikan jika URL webhook mengarah ke alamat loopback, jaringan privat,
+// link-local atau alamat khusus lain
+var ErrBlockedAddress = errors.New("webhook address is not allowed")
+
+// blockedPrefixes adalah rentang alamat yang tidak tercakup helper netip tetapi tetap bukan alamat publik
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, bisa memetakan alamat IPv4 privat
+}
+
+// AllowedIP melaporkan apakah webhook boleh dikirim ke alamat ip
+func AllowedIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedIP bisa diganti di test agar server lokal httptest bisa dipakai
+var allowedIP = AllowedIP
+
+// checkAddress dipanggil setelah nama host di-resolve, tepat sebelum koneksi dibuka, sehingga nama
+// domain yang mengarah ke alamat internal (termasuk DNS rebinding) tetap ditolak
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil || !allowedIP(addr.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	return nil
+}
+
+// Sender mengirim request webhook. Respons 2xx dianggap berhasil.
+type Sender struct {
+	Client *http.Client
+}
+
+// NewSender membuat Sender dengan batas waktu per request. Request hanya dikirim ke alamat publik,
+// tidak lewat proxy dan tidak mengikuti redirect; respons 3xx dianggap gagal.
+func NewSender(timeout time.Duration) *Sender {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkAddress}
+	return &Sender{Client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Send mengirim payload bertanda tangan ke URL langganan
+func (s *Sender) Send(ctx context.Context, req Request) (Result, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return Result{}, err
+	}
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "edukasi-webhook/1")
+	httpReq.Header.Set(HeaderEvent, req.Event)
+	httpReq.Header.Set(HeaderDelivery, strconv.Itoa(req.DeliveryID))
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	started := time.Now()
+	resp, err := s.Client.Do(httpReq)
+	result := Result{Duration: time.Since(started).Milliseconds()}
+	if err != nil {
+		return result, err
+	}
+	resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestAllowedIP(t *testing.T) {
+	cases := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for addr, want := range cases {
+		if got := AllowedIP(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("AllowedIP(%s) = %t, want %t", addr, got, want)
+		}
+	}
+}
+
+func TestSendRejectsLoopback(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+	defer srv.Close()
+
+	_, err := NewSender(time.Second).Send(context.Background(), Request{URL: srv.URL, Event: EventTest, Secret: "s"})
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Send to %s error = %v, want ErrBlockedAddress", srv.URL, err)
+	}
+	if called {
+		t.Error("request reached the loopback server")
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	// Server httptest berjalan di loopback, jadi pemeriksaan alamat dilonggarkan untuk test ini
+	allowedIP = func(netip.Addr) bool { return true }
+	defer func() { allowedIP = AllowedIP }()
+
+	redirected := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	})
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) { redirected = true })
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	result, err := NewSender(time.Second).Send(context.Background(), Request{URL: srv.URL + "/hook", Event: EventTest, Secret: "s"})
+	if err == nil || result.StatusCode != http.StatusFound {
+		t.Errorf("Send = %+v, %v; want status 302 and an error", result, err)
+	}
+	if redirected {
+		t.Error("redirect was followed")
+	}
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-project/pkg/notify"
+	"log"
+	"time"
+)
+
+// Config mengatur pengiriman webhook
+type Config struct {
+	Interval    time.Duration // jarak antar putaran worker
+	BatchSize   int           // pengiriman maksimal per putaran
+	MaxAttempts int           // percobaan sebelum pengiriman dipindah ke dead-letter
+	BaseDelay   time.Duration // jeda sebelum percobaan kedua; berlipat dua setiap kali gagal
+	MaxDelay    time.Duration // jeda maksimal antar percobaan
+	Timeout     time.Duration // batas waktu satu request
+}
+
+// DefaultConfig mengembalikan pengaturan bawaan webhook
+func DefaultConfig() Config {
+	return Config{
+		Interval:    5 * time.Second,
+		BatchSize:   50,
+		MaxAttempts: 8,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    6 * time.Hour,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// Worker mengirim webhook_deliveries yang tertunda
+type Worker struct {
+	DB     *sql.DB
+	Sender *Sender
+	Config Config
+}
+
+// NewWorker membuat worker webhook
+func NewWorker(db *sql.DB, cfg Config) *Worker {
+	return &Worker{DB: db, Sender: NewSender(cfg.Timeout), Config: cfg}
+}
+
+// Start menjalankan worker secara berkala sampai context dibatalkan
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx); err != nil {
+			log.Printf("webhook worker: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce mengirim pengiriman yang jatuh tempo
+func (w *Worker) RunOnce(ctx context.Context) error {
+	for i := 0; i < w.Config.BatchSize; i++ {
+		ok, err := w.deliverNext(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+	return nil
+}
+
+// leaseDuration adalah lama pengiriman yang sedang berjalan tidak diambil worker lain. Jika proses berhenti
+// sebelum hasilnya tersimpan, pengiriman dicoba lagi setelah lease habis.
+const leaseDuration = 5 * time.Minute
+
+// deliverNext mengunci satu pengiriman, memberinya lease lalu meng-commit transaksi sebelum request dikirim,
+// sehingga koneksi database tidak ditahan selama menunggu penerima. Langganan yang dinonaktifkan setelah
+// event dicatat tidak dikirimi dan pengirimannya ditandai dead.
+func (w *Worker) deliverNext(ctx context.Context) (bool, error) {
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var req Request
+	var attempts int
+	var active bool
+	err = tx.QueryRowContext(ctx, `SELECT d.id, d.event, d.payload, d.attempts, s.url, s.secret, s.active
+			FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW()
+			ORDER BY d.id LIMIT 1 FOR UPDATE OF d SKIP LOCKED`).
+		Scan(&req.DeliveryID, &req.Event, &req.Payload, &attempts, &req.URL, &req.Secret, &active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !active {
+		if err := Record(ctx, tx, req.DeliveryID, attempts, w.Config, Result{}, errors.New("subscription is inactive"), false); err != nil {
+			return false, err
+		}
+		return true, tx.Commit()
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET attempts = attempts + 1, next_attempt_at = NOW() + $1 * interval '1 second', updated_at = NOW()
+			WHERE id = $2`, int(leaseDuration/time.Second), req.DeliveryID)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	attempts++
+
+	result, sendErr := w.Sender.Send(ctx, req)
+	// Hasil tetap disimpan walaupun worker sedang dihentikan, agar event yang sudah terkirim tidak dikirim ulang
+	return true, Record(context.WithoutCancel(ctx), w.DB, req.DeliveryID, attempts, w.Config, result, sendErr, true)
+}
+
+// Record menyimpan hasil percobaan ke-attempts yang sudah tercatat di baris pengiriman. Pengiriman yang gagal
+// dijadwalkan ulang dengan backoff eksponensial sampai MaxAttempts, lalu masuk dead-letter. Tanpa retry,
+// kegagalan langsung dead. Jumlah percobaan dipakai sebagai penanda lease, sehingga hasil tidak menimpa
+// baris yang sudah diambil ulang worker lain setelah lease habis.
+func Record(ctx context.Context, exec notify.Execer, id, attempts int, cfg Config, result Result, sendErr error, retry bool) error {
+	status, lastError := "sent", ""
+	delay := time.Duration(0)
+	if sendErr != nil {
+		status, lastError = "pending", sendErr.Error()
+		delay = notify.Backoff(attempts, cfg.BaseDelay, cfg.MaxDelay)
+		if !retry || attempts >= cfg.MaxAttempts {
+			status = "dead"
+		}
+	}
+	_, err := exec.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET status = $1, last_error = NULLIF($2, ''), response_status = NULLIF($3, 0), duration_ms = $4,
+			    next_attempt_at = NOW() + $5 * interval '1 millisecond',
+			    sent_at = CASE WHEN $1 = 'sent' THEN NOW() ELSE sent_at END, updated_at = NOW()
+			WHERE id = $6 AND attempts = $7 AND status = 'pending'`,
+		status, lastError, result.StatusCode, result.Duration, delay.Milliseconds(), id, attempts)
+	if err != nil {
+		return fmt.Errorf("record delivery %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package webinar
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cara peserta tercatat hadir
+const (
+	CheckInHost = "host" // ditandai manual oleh host
+	CheckInSelf = "self" // peserta memasukkan kode check-in selama sesi berlangsung
+)
+
+// DefaultCertificateThreshold adalah persentase kehadiran minimal untuk mendapat sertifikat
+const DefaultCertificateThreshold = 75
+
+// Attendance adalah satu baris laporan kehadiran webinar
+type Attendance struct {
+	RegistrationID  int        `json:"registration_id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	PhoneNumber     string     `json:"phone_number,omitempty"`
+	Status          string     `json:"status"` // Status pendaftaran
+	Attended        bool       `json:"attended"`
+	Method          string     `json:"method,omitempty"`
+	CheckedInAt     *time.Time `json:"checked_in_at,omitempty"`
+	LeftAt          *time.Time `json:"left_at,omitempty"`
+	AttendedPercent int        `json:"attended_percent"`
+	CertificateCode string     `json:"certificate_code,omitempty"`
+}
+
+// AttendedPercent menghitung persentase durasi sesi yang diikuti peserta dari check-in sampai check-out
+// (leftAt nil berarti sampai sesi selesai). Check-in sebelum sesi dimulai dihitung sejak sesi dimulai.
+// Kehadiran yang dikonfirmasi host dihitung penuh.
+func AttendedPercent(method string, start, end, checkedIn time.Time, leftAt *time.Time) int {
+	total := end.Sub(start)
+	if method == CheckInHost || total <= 0 {
+		return 100
+	}
+	if checkedIn.Before(start) {
+		checkedIn = start
+	}
+	if leftAt != nil && leftAt.Before(end) {
+		end = *leftAt
+	}
+	attended := end.Sub(checkedIn)
+	if attended <= 0 {
+		return 0
+	}
+	return int(attended * 100 / total)
+}
+
+// CheckIn mencatat kehadiran peserta dengan waktu dari database, baik check-in mandiri maupun konfirmasi
+// host (markedBy 0 untuk check-in mandiri). Waktu check-in pertama yang dipakai; konfirmasi host menimpa
+// cara check-in mandiri dan check-in ulang membatalkan check-out sebelumnya. Mengembalikan sql.ErrNoRows
+// jika pendaftaran tidak ada di webinar ini atau tidak berstatus registered.
+func CheckIn(db *sql.DB, webinarID, registrationID int, method string, markedBy int) error {
+	query := `INSERT INTO webinar_attendance (registration_id, webinar_id, method, checked_in_at, marked_by)
+			  SELECT r.id, r.webinar_id, $3, NOW(), NULLIF($4, 0)
+			  FROM webinar_registrations r
+			  WHERE r.id = $1 AND r.webinar_id = $2 AND r.status = 'registered'
+			  ON CONFLICT (registration_id) DO UPDATE
+			  SET method = CASE WHEN EXCLUDED.method = 'host' THEN 'host' ELSE webinar_attendance.method END,
+			      marked_by = COALESCE(EXCLUDED.marked_by, webinar_attendance.marked_by),
+			      left_at = NULL`
+	result, err := db.Exec(query, registrationID, webinarID, method, markedBy)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CheckOut mencatat waktu peserta meninggalkan webinar. Mengembalikan sql.ErrNoRows jika peserta belum check-in.
+func CheckOut(db *sql.DB, webinarID, registrationID int) error {
+	result, err := db.Exec(`UPDATE webinar_attendance SET left_at = NOW() WHERE registration_id = $1 AND webinar_id = $2`,
+		registrationID, webinarID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// LoadAttendance mengambil laporan kehadiran semua pendaftar aktif sebuah webinar
+func LoadAttendance(db *sql.DB, webinarID int) ([]Attendance, error) {
+	query := `SELECT r.id, r.name, r.email, COALESCE(r.phone_number, ''), r.status,
+			  a.method, a.checked_in_at, a.left_at, w.start_time, w.end_time, COALESCE(c.code, '')
+			  FROM webinar_registrations r
+			  JOIN webinars w ON w.id = r.webinar_id
+			  LEFT JOIN webinar_attendance a ON a.registration_id = r.id
+			  LEFT JOIN webinar_certificates c ON c.registration_id = r.id
+			  WHERE r.webinar_id = $1 AND r.status <> 'cancelled'
+			  ORDER BY r.name, r.id`
+	rows, err := db.Query(query, webinarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []Attendance{}
+	for rows.Next() {
+		var a Attendance
+		var method sql.NullString
+		var checkedIn, leftAt, start, end sql.NullTime
+		if err := rows.Scan(&a.RegistrationID, &a.Name, &a.Email, &a.PhoneNumber, &a.Status,
+			&method, &checkedIn, &leftAt, &start, &end, &a.CertificateCode); err != nil {
+			return nil, err
+		}
+		if checkedIn.Valid {
+			a.Attended = true
+			a.Method = method.String
+			a.CheckedInAt = &checkedIn.Time
+			if leftAt.Valid {
+				a.LeftAt = &leftAt.Time
+			}
+			a.AttendedPercent = 100
+			if start.Valid && end.Valid {
+				a.AttendedPercent = AttendedPercent(a.Method, start.Time, end.Time, checkedIn.Time, a.LeftAt)
+			}
+		}
+		records = append(records, a)
+	}
+	return records, rows.Err()
+}
+
+// WriteAttendanceCSV menulis laporan kehadiran dalam format CSV
+func WriteAttendanceCSV(w io.Writer, records []Attendance, loc *time.Location) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"registration_id", "name", "email", "phone_number", "status", "attended",
+		"method", "checked_in_at", "left_at", "attended_percent", "certificate_code"})
+	for _, a := range records {
+		out.Write([]string{strconv.Itoa(a.RegistrationID), a.Name, a.Email, a.PhoneNumber, a.Status,
+			strconv.FormatBool(a.Attended), a.Method, formatCSVTime(a.CheckedInAt, loc), formatCSVTime(a.LeftAt, loc),
+			strconv.Itoa(a.AttendedPercent), a.CertificateCode})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// formatCSVTime memformat waktu untuk CSV; nil menjadi kolom kosong
+func formatCSVTime(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(loc).Format("2006-01-02 15:04:05")
+}
+
+// IssueCertificates menerbitkan sertifikat untuk peserta webinar yang sudah selesai dan memenuhi
+// batas kehadiran. Peserta yang sudah memiliki sertifikat dilewati sehingga aman dipanggil ulang.
+// Mengembalikan jumlah sertifikat baru.
+func IssueCertificates(db *sql.DB, webinarID int) (int, error) {
+	query := `SELECT r.id, r.name, a.method, a.checked_in_at, a.left_at, w.start_time, w.end_time, w.certificate_threshold
+			  FROM webinar_registrations r
+			  JOIN webinars w ON w.id = r.webinar_id
+			  JOIN webinar_attendance a ON a.registration_id = r.id
+			  WHERE r.webinar_id = $1 AND r.status = 'registered' AND w.status = 'ended'
+			    AND NOT EXISTS (SELECT 1 FROM webinar_certificates c WHERE c.registration_id = r.id)`
+	rows, err := db.Query(query, webinarID)
+	if err != nil {
+		return 0, err
+	}
+
+	type candidate struct {
+		registrationID int
+		name           string
+		percent        int
+	}
+	var qualified []candidate
+	for rows.Next() {
+		var c candidate
+		var method string
+		var checkedIn time.Time
+		var leftAt, start, end sql.NullTime
+		var threshold int
+		if err := rows.Scan(&c.registrationID, &c.name, &method, &checkedIn, &leftAt, &start, &end, &threshold); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		c.percent = 100
+		if start.Valid && end.Valid {
+			var left *time.Time
+			if leftAt.Valid {
+				left = &leftAt.Time
+			}
+			c.percent = AttendedPercent(method, start.Time, end.Time, checkedIn, left)
+		}
+		if c.percent >= threshold {
+			qualified = append(qualified, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	issued := 0
+	for _, c := range qualified {
+		code, err := NewCertificateCode()
+		if err != nil {
+			return issued, err
+		}
+		result, err := db.Exec(`INSERT INTO webinar_certificates (code, registration_id, webinar_id, name, attended_percent)
+				VALUES ($1, $2, $3, $4, $5) ON CONFLICT (registration_id) DO NOTHING`,
+			code, c.registrationID, webinarID, c.name, c.percent)
+		if err != nil {
+			return issued, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			issued++
+		}
+	}
+	return issued, nil
+}
+
+// NewCertificateCode membuat ID sertifikat acak, misalnya "K7QF-2MZP-X4TA-93BD"
+func NewCertificateCode() (string, error) {
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	s := base32.StdEncoding.EncodeToString(raw)
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// NormalizeCertificateCode merapikan ID sertifikat yang diketik pengguna
+func NormalizeCertificateCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// NewCheckInCode membuat kode check-in 6 digit yang dibagikan host selama sesi
+func NewCheckInCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
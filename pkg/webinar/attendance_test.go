@@ -0,0 +1,34 @@
+package webinar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttendedPercent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		method    string
+		checkedIn time.Time
+		leftAt    *time.Time
+		want      int
+	}{
+		{"check-in sebelum mulai sampai selesai", CheckInSelf, at(-10), nil, 100},
+		{"terlambat 30 menit", CheckInSelf, at(30), nil, 75},
+		{"keluar di tengah sesi", CheckInSelf, at(0), ptr(at(60)), 50},
+		{"terlambat lalu keluar", CheckInSelf, at(30), ptr(at(90)), 50},
+		{"check-out setelah selesai", CheckInSelf, at(0), ptr(at(150)), 100},
+		{"check-in setelah selesai", CheckInSelf, at(130), nil, 0},
+		{"dikonfirmasi host", CheckInHost, at(110), ptr(at(115)), 100},
+	}
+	for _, tt := range tests {
+		if got := AttendedPercent(tt.method, start, end, tt.checkedIn, tt.leftAt); got != tt.want {
+			t.Errorf("%s: AttendedPercent = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
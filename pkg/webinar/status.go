@@ -0,0 +1,52 @@
+package webinar
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Status webinar sesuai kolom status di tabel webinars
+const (
+	StatusDraft     = "draft"
+	StatusScheduled = "scheduled"
+	StatusLive      = "live"
+	StatusEnded     = "ended"
+	StatusCancelled = "cancelled"
+)
+
+// ErrInvalidTransition dikembalikan jika perpindahan status webinar tidak diperbolehkan
+var ErrInvalidTransition = errors.New("invalid webinar status transition")
+
+// transitions adalah perpindahan status yang diperbolehkan. Ended dan cancelled adalah status akhir.
+var transitions = map[string][]string{
+	StatusDraft:     {StatusScheduled, StatusCancelled},
+	StatusScheduled: {StatusDraft, StatusLive, StatusCancelled},
+	StatusLive:      {StatusEnded},
+}
+
+// IsValidStatus memeriksa apakah status dikenal
+func IsValidStatus(status string) bool {
+	switch status {
+	case StatusDraft, StatusScheduled, StatusLive, StatusEnded, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// ValidateTransition mengembalikan error jika status tidak boleh berpindah dari `from` ke `to`
+func ValidateTransition(from, to string) error {
+	if !IsValidStatus(to) {
+		return fmt.Errorf("%w: unknown status %s", ErrInvalidTransition, to)
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+}
+
+// AcceptsRegistrations memeriksa apakah webinar dengan status tersebut masih menerima pendaftaran
+func AcceptsRegistrations(status string) bool {
+	return status == StatusScheduled
+}